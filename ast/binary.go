@@ -1,21 +1,70 @@
 package ast
 
 import (
+	"errors"
 	"fmt"
+	"math/bits"
 )
 
 type Section interface {
 	Encode(sink *ZeroCopySink)
 }
 
-func (self *Module) Encode() []byte {
+// EncodeOptions selects how EncodeWithOptions writes a module. The output
+// depends on the module and the options alone, encoding the same module with
+// the same options always gives the same bytes.
+type EncodeOptions struct {
+	// Features are the proposals the module may use, encoding fails with a
+	// FeatureError on anything outside them. The zero value allows the MVP
+	// alone, see DefaultEncodeOptions.
+	Features Features
+	// Names adds the "name" custom section with the identifiers of the text
+	// module.
+	Names bool
+	// PadLEB writes every unsigned 32-bit LEB128, e.g. indices and section
+	// sizes, in its 5 byte form so it can be patched in place later. The
+	// memory and table indices the MVP reserves as a zero byte are left
+	// short.
+	PadLEB bool
+	// Customs are custom sections added to those of the module, each is
+	// placed after the module's own sections at the same place.
+	Customs []Custom
+}
+
+// DefaultEncodeOptions allows every proposal and writes no extra sections,
+// it is what Encode uses.
+func DefaultEncodeOptions() EncodeOptions {
+	return EncodeOptions{Features: AllFeatures}
+}
+
+// Encode emits the binary format of a resolved module. It fails if the
+// module still contains symbolic indices or inline forms, see `Resolve`.
+func (self *Module) Encode() ([]byte, error) {
+	return self.EncodeWithOptions(DefaultEncodeOptions())
+}
+
+// EncodeWithOptions is Encode with the settings of opts. A binary module is
+// returned as written and opts are ignored.
+func (self *Module) EncodeWithOptions(opts EncodeOptions) ([]byte, error) {
 	var fields []ModuleField
-	if t, ok := self.Kind.(ModuleKindText); ok {
-		fields = t.Fields
+	switch kind := self.Kind.(type) {
+	case ModuleKindText:
+		fields = kind.Fields
+	case ModuleKindBinary:
+		var bin []byte
+		for _, b := range kind.Bins {
+			bin = append(bin, b...)
+		}
+		return bin, nil
+	}
+
+	if err := checkFeatures(fields, opts.Features); err != nil {
+		return nil, err
 	}
 
 	magic := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
 	sink := NewZeroCopySink(nil)
+	sink.padLEB = opts.PadLEB
 	sink.WriteBytes(magic)
 
 	var types []Section
@@ -24,11 +73,13 @@ func (self *Module) Encode() []byte {
 	var funcsTypes []Section
 	var tables []Section
 	var memories []Section
+	var tags []Section
 	var globals []Section
 	var exports []Section
 	var start []Section
 	var elem []Section
 	var data []Section
+	var customs []Custom
 
 	for _, item := range fields {
 		switch field := item.(type) {
@@ -43,6 +94,8 @@ func (self *Module) Encode() []byte {
 			tables = append(tables, field)
 		case Memory:
 			memories = append(memories, field)
+		case Tag:
+			tags = append(tags, field)
 		case Global:
 			globals = append(globals, field)
 		case Export:
@@ -53,24 +106,108 @@ func (self *Module) Encode() []byte {
 			elem = append(elem, field)
 		case Data:
 			data = append(data, field)
+		case Custom:
+			customs = append(customs, field)
+		default:
+			return nil, fmt.Errorf("invalid module field type: %T", field)
+		}
+	}
+	customs = append(customs, opts.Customs...)
+
+	// custom sections are placed around their anchor even if the anchor
+	// section itself is empty and left out
+	section := func(id byte, encode func()) {
+		writeCustoms(customs, CustomPlace{Before: true, Anchor: id}, sink)
+		encode()
+		writeCustoms(customs, CustomPlace{Before: false, Anchor: id}, sink)
+	}
+
+	writeCustoms(customs, CustomPlace{Before: true}, sink)
+	section(0x1, func() { SectionList(0x1, types, sink) })
+	section(0x2, func() { SectionList(0x2, imports, sink) })
+	section(0x3, func() { SectionList(0x3, funcsTypes, sink) })
+	section(0x4, func() { SectionList(0x4, tables, sink) })
+	section(0x5, func() { SectionList(0x5, memories, sink) })
+	section(0xd, func() { SectionList(0xd, tags, sink) })
+	section(0x6, func() { SectionList(0x6, globals, sink) })
+	section(0x7, func() { SectionList(0x7, exports, sink) })
+	section(0x8, func() {
+		// the start section holds a single index rather than a vector
+		switch len(start) {
+		case 0:
+		case 1:
+			tmpSink := sink.sub()
+			start[0].Encode(tmpSink)
+			if err := tmpSink.Err(); err != nil {
+				sink.Fail(err)
+			}
+			sink.WriteByte(0x8)
+			sink.WriteVarBytes(tmpSink.Bytes())
 		default:
-			panic(fmt.Errorf("invalid module field type: %v", field))
+			sink.Fail(errors.New("multiple start sections"))
+		}
+	})
+	section(0x9, func() { SectionList(0x9, elem, sink) })
+	section(0xc, func() {
+		if needsDataCount(funcs) {
+			tmpSink := sink.sub()
+			tmpSink.WriteUint32(uint32(len(data)))
+			sink.WriteByte(0xc)
+			sink.WriteVarBytes(tmpSink.Bytes())
+		}
+	})
+	section(0xa, func() { SectionList(0xa, funcs, sink) })
+	section(0xb, func() { SectionList(0xb, data, sink) })
+	writeCustoms(customs, CustomPlace{Before: false}, sink)
+
+	if opts.Names {
+		names := collectNames(self.Name, fields)
+		if !names.empty() {
+			tmpSink := sink.sub()
+			names.Encode(tmpSink)
+			sink.WriteByte(0x0)
+			sink.WriteVarBytes(tmpSink.Bytes())
 		}
 	}
 
-	SectionList(0x1, types, sink)
-	SectionList(0x2, imports, sink)
-	SectionList(0x3, funcsTypes, sink)
-	SectionList(0x4, tables, sink)
-	SectionList(0x5, memories, sink)
-	SectionList(0x6, globals, sink)
-	SectionList(0x7, exports, sink)
-	SectionList(0x8, start, sink)
-	SectionList(0x9, elem, sink)
-	SectionList(0xa, funcs, sink)
-	SectionList(0xb, data, sink)
+	if err := sink.Err(); err != nil {
+		return nil, err
+	}
+
+	return sink.Bytes(), nil
+}
+
+// writeCustoms emits the custom sections at place in the order they were
+// written.
+func writeCustoms(customs []Custom, place CustomPlace, sink *ZeroCopySink) {
+	for _, custom := range customs {
+		if custom.Place != place {
+			continue
+		}
+		tmpSink := sink.sub()
+		custom.Encode(tmpSink)
+		sink.WriteByte(0x0)
+		sink.WriteVarBytes(tmpSink.Bytes())
+	}
+}
+
+// needsDataCount reports whether a function body refers to a data segment by
+// index, which requires the data count section before the code section.
+func needsDataCount(funcs []Section) bool {
+	for _, f := range funcs {
+		inline, ok := f.(Func).Kind.(FuncKindInline)
+		if !ok {
+			continue
+		}
+		for _, instr := range inline.Expr.Instrs {
+			switch instr.(type) {
+			case *MemoryInit, *DataDrop:
+				return true
+			}
+		}
+	}
 
-	return sink.Bytes()
+	return false
 }
 
 func SectionList(id byte, l []Section, sink *ZeroCopySink) {
@@ -78,8 +215,11 @@ func SectionList(id byte, l []Section, sink *ZeroCopySink) {
 		return
 	}
 
-	tmpSink := NewZeroCopySink(nil)
+	tmpSink := sink.sub()
 	ListEncode(l, tmpSink)
+	if err := tmpSink.Err(); err != nil {
+		sink.Fail(err)
+	}
 
 	sink.WriteByte(id)
 	sink.WriteVarBytes(tmpSink.Bytes())
@@ -160,13 +300,18 @@ func (f FunctionType) Encode(sink *ZeroCopySink) {
 }
 
 func (t Limits) Encode(sink *ZeroCopySink) {
-	if t.Max == 0 {
-		sink.WriteByte(0x00)
-		sink.WriteUint32(t.Min)
+	t.encode(sink, 0x00)
+}
+
+// encode writes the limits with flags, the flag of the maximum is added here.
+func (t Limits) encode(sink *ZeroCopySink, flags byte) {
+	if !t.HasMax {
+		sink.WriteByte(flags)
+		sink.WriteUint64(t.Min)
 	} else {
-		sink.WriteByte(0x01)
-		sink.WriteUint32(t.Min)
-		sink.WriteUint32(t.Max)
+		sink.WriteByte(flags | 0x01)
+		sink.WriteUint64(t.Min)
+		sink.WriteUint64(t.Max)
 	}
 }
 
@@ -176,21 +321,23 @@ func (t TableType) Encode(sink *ZeroCopySink) {
 }
 
 func (t MemoryType) Encode(sink *ZeroCopySink) {
-	t.Limits.Encode(sink)
+	var flags byte
+	if t.Shared {
+		flags |= 0x02
+	}
+	if t.Is64 {
+		flags |= 0x04
+	}
+	t.Limits.encode(sink, flags)
 }
 
 func (t Table) Encode(sink *ZeroCopySink) {
-	// check why can not zero
-	if len(t.Exports.Names) == 0 {
-		panic("Name should not empty")
-	}
-
 	if x, ok := t.Kind.(TableKindNormal); ok {
 		x.Type.Encode(sink)
 		return
 	}
 
-	panic("TableKind should be normal during encoding")
+	sink.Fail(errors.New("table should be normal during encoding"))
 }
 
 func (t Memory) Encode(sink *ZeroCopySink) {
@@ -199,7 +346,7 @@ func (t Memory) Encode(sink *ZeroCopySink) {
 		return
 	}
 
-	panic("MemoryKind should be normal during encoding")
+	sink.Fail(errors.New("memory should be normal during encoding"))
 }
 
 func (t Import) Encode(sink *ZeroCopySink) {
@@ -216,28 +363,36 @@ func (t Import) Encode(sink *ZeroCopySink) {
 		sink.WriteByte(0x02)
 	case "global":
 		sink.WriteByte(0x03)
+	case "tag":
+		sink.WriteByte(0x04)
 	}
 
 	t.Item.Encode(sink)
 }
 
 func (t Global) Encode(sink *ZeroCopySink) {
-	// check why can not zero
-	if len(t.Exports.Names) == 0 {
-		panic("Name should not empty")
-	}
-
 	t.ValType.Encode(sink)
 
 	exp, ok := t.Kind.(GlobalKindInline)
-
 	if !ok {
-		panic("GlobalKind should be inline during encoding")
+		sink.Fail(errors.New("global should be inline during encoding"))
+		return
 	}
 
 	exp.Expr.Encode(sink)
 }
 
+func (t Tag) Encode(sink *ZeroCopySink) {
+	if _, ok := t.Kind.(TagKindInline); !ok {
+		sink.Fail(errors.New("tag should be inline during encoding"))
+		return
+	}
+
+	// the attribute byte, 0 is the only defined attribute
+	sink.WriteByte(0x00)
+	t.Type.Encode(sink)
+}
+
 func (t Export) Encode(sink *ZeroCopySink) {
 	sink.WriteString(t.Name)
 	sink.WriteByte(byte(t.Type))
@@ -249,7 +404,8 @@ func (t Elem) Encode(sink *ZeroCopySink) {
 	case ElemKindActive:
 		active, _ := t.Kind.(ElemKindActive)
 		if !active.Table.Isnum {
-			panic("expect num in Elem kind")
+			sink.Fail(fmt.Errorf("unresolved index in emission %s", active.Table.Id.Name))
+			return
 		}
 
 		switch t.Payload.(type) {
@@ -265,7 +421,7 @@ func (t Elem) Encode(sink *ZeroCopySink) {
 			}
 		case ElemPayloadExprs:
 			expr, _ := t.Payload.(ElemPayloadExprs)
-			if active.Table.Num == 0 && expr.Type == FuncRef {
+			if active.Table.Num == 0 && expr.Type == FuncRef && !t.forceNonZero {
 				sink.WriteByte(byte(0x04))
 				active.Offset.Encode(sink)
 			} else {
@@ -275,7 +431,8 @@ func (t Elem) Encode(sink *ZeroCopySink) {
 				expr.Type.Encode(sink)
 			}
 		default:
-			panic("error Elem payload Kind")
+			sink.Fail(fmt.Errorf("invalid elem payload: %T", t.Payload))
+			return
 		}
 	case ElemKindPassive:
 		switch t.Payload.(type) {
@@ -287,10 +444,25 @@ func (t Elem) Encode(sink *ZeroCopySink) {
 			sink.WriteByte(byte(0x05))
 			expr.Type.Encode(sink)
 		default:
-			panic("error Elem payload Kind")
+			sink.Fail(fmt.Errorf("invalid elem payload: %T", t.Payload))
+			return
+		}
+	case ElemKindDeclared:
+		switch t.Payload.(type) {
+		case ElemPayloadIndices:
+			sink.WriteByte(byte(0x03))
+			sink.WriteByte(byte(0x00))
+		case ElemPayloadExprs:
+			expr, _ := t.Payload.(ElemPayloadExprs)
+			sink.WriteByte(byte(0x07))
+			expr.Type.Encode(sink)
+		default:
+			sink.Fail(fmt.Errorf("invalid elem payload: %T", t.Payload))
+			return
 		}
 	default:
-		panic("error Elem Kind")
+		sink.Fail(fmt.Errorf("invalid elem kind: %T", t.Kind))
+		return
 	}
 
 	t.Payload.Encode(sink)
@@ -306,6 +478,17 @@ func (t ElemPayloadIndices) Encode(sink *ZeroCopySink) {
 }
 
 func (t ElemPayloadExprs) Encode(sink *ZeroCopySink) {
+	sink.WriteUint32(uint32(len(t.Exprs)))
+	for _, expr := range t.Exprs {
+		if expr.IsSome() {
+			sink.WriteByte(0xd2)
+			expr.Encode(sink)
+		} else {
+			sink.WriteByte(0xd0)
+			t.Type.Encode(sink)
+		}
+		sink.WriteByte(0x0b)
+	}
 }
 
 func (t Data) Encode(sink *ZeroCopySink) {
@@ -320,8 +503,10 @@ func (t Data) Encode(sink *ZeroCopySink) {
 			sink.WriteByte(byte(0x02))
 			active.Memory.Encode(sink)
 		}
+		active.Offset.Encode(sink)
 	default:
-		panic("error data kind")
+		sink.Fail(fmt.Errorf("invalid data kind: %T", t.Kind))
+		return
 	}
 
 	var l uint32
@@ -345,7 +530,7 @@ func (t Func) Encode(sink *ZeroCopySink) {
 			num   uint32
 			local Local
 		}
-		tmpSink := NewZeroCopySink(nil)
+		tmpSink := sink.sub()
 		var comL []compressLocal
 
 		for _, ct := range fun.Locals {
@@ -367,9 +552,12 @@ func (t Func) Encode(sink *ZeroCopySink) {
 		}
 
 		fun.Expr.Encode(tmpSink)
+		if err := tmpSink.Err(); err != nil {
+			sink.Fail(err)
+		}
 		sink.WriteVarBytes(tmpSink.Bytes())
 	default:
-		panic("should only have inline functions in emission")
+		sink.Fail(errors.New("should only have inline functions in emission"))
 	}
 
 }
@@ -383,10 +571,15 @@ func (t Expression) Encode(sink *ZeroCopySink) {
 }
 
 func (t TypeUse) Encode(sink *ZeroCopySink) {
+	if !t.Index.IsSome() {
+		sink.Fail(errors.New("unresolved type use in emission"))
+		return
+	}
 	t.Index.Encode(sink)
 }
 
 func (t StartField) Encode(sink *ZeroCopySink) {
+	t.Index.Encode(sink)
 }
 
 func (self ImportFunc) Encode(sink *ZeroCopySink) {
@@ -405,44 +598,82 @@ func (self ImportTable) Encode(sink *ZeroCopySink) {
 	self.Table.Encode(sink)
 }
 
+func (self ImportTag) Encode(sink *ZeroCopySink) {
+	sink.WriteByte(0x00)
+	self.TypeUse.Encode(sink)
+}
+
 func (t Index) Encode(sink *ZeroCopySink) {
 	if t.Isnum {
 		sink.WriteUint32(t.Num)
 		return
 	}
 
-	panic(fmt.Errorf("unresolved index in emission %s", t.Id.Name))
+	sink.Fail(fmt.Errorf("unresolved index in emission %s", t.Id.Name))
+}
+
+// encodeReservedIndex writes an index the MVP reserves as a zero byte, e.g.
+// the memory of memory.size or the table of call_indirect, in its shortest
+// form even with PadLEB. Engines without multi-memory or reference types
+// read a single byte there.
+func encodeReservedIndex(sink *ZeroCopySink, index Index) {
+	if !index.Isnum {
+		index.Encode(sink)
+		return
+	}
+	sink.WriteBytes(AppendUleb128(nil, uint64(index.Num)))
 }
 
 func (t OptionIndex) Encode(sink *ZeroCopySink) {
+	if !t.IsSome() {
+		sink.Fail(errors.New("missing index in emission"))
+		return
+	}
 	t.ToIndex().Encode(sink)
 }
 
 func (t BlockType) Encode(sink *ZeroCopySink) {
 	if t.Ty.Index.IsSome() {
-		t.Ty.Index.Encode(sink)
+		index := t.Ty.Index.ToIndex()
+		if !index.Isnum {
+			sink.Fail(fmt.Errorf("unresolved index in emission %s", index.Id.Name))
+			return
+		}
+		// a type index is a positive s33 so it can't be mistaken for a value
+		// type
+		sink.WriteInt64(int64(index.Num))
 		return
 	}
 
 	if len(t.Ty.Type.Params) == 0 && len(t.Ty.Type.Results) == 0 {
 		sink.WriteByte(byte(0x40))
+		return
 	}
 
 	if len(t.Ty.Type.Params) == 0 && len(t.Ty.Type.Results) == 1 {
 		t.Ty.Type.Results[0].Encode(sink)
+		return
 	}
 
-	panic("multi-value block types should have an index")
+	sink.Fail(errors.New("unresolved block type in emission"))
 }
 
 func (t MemArg) Encode(sink *ZeroCopySink) {
-	sink.WriteUint32(t.Align)
-	sink.WriteUint32(t.Offset)
+	// the binary format stores the alignment as an exponent of two
+	flags := uint32(bits.TrailingZeros32(t.Align))
+	if t.Memory.Isnum && t.Memory.Num == 0 {
+		sink.WriteUint32(flags)
+	} else {
+		// bit 6 marks an explicit memory index
+		sink.WriteUint32(flags | 0x40)
+		t.Memory.Encode(sink)
+	}
+	sink.WriteUint64(t.Offset)
 }
 
 func (t CallIndirectInner) Encode(sink *ZeroCopySink) {
-	t.Table.Encode(sink)
 	t.Type.Encode(sink)
+	encodeReservedIndex(sink, t.Table)
 }
 
 func (t BrTableIndices) Encode(sink *ZeroCopySink) {
@@ -477,3 +708,35 @@ func (t Float32) Encode(sink *ZeroCopySink) {
 func (t Float64) Encode(sink *ZeroCopySink) {
 	sink.WriteFloat64(t.Bits)
 }
+
+func (t HeapType) Encode(sink *ZeroCopySink) {
+	t.ValType().Encode(sink)
+}
+
+func (t TableInitInner) Encode(sink *ZeroCopySink) {
+	t.Elem.Encode(sink)
+	t.Table.Encode(sink)
+}
+
+func (t TableCopyInner) Encode(sink *ZeroCopySink) {
+	t.Dst.Encode(sink)
+	t.Src.Encode(sink)
+}
+
+func (t MemoryInitInner) Encode(sink *ZeroCopySink) {
+	t.Data.Encode(sink)
+	encodeReservedIndex(sink, t.Memory)
+}
+
+func (t MemoryCopyInner) Encode(sink *ZeroCopySink) {
+	encodeReservedIndex(sink, t.Dst)
+	encodeReservedIndex(sink, t.Src)
+}
+
+func (t V128Bits) Encode(sink *ZeroCopySink) {
+	sink.WriteBytes(t.Bytes[:])
+}
+
+func (t ShuffleLanes) Encode(sink *ZeroCopySink) {
+	sink.WriteBytes(t.Lanes[:])
+}
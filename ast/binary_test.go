@@ -1,7 +1,10 @@
 package ast
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/ontio/wast-parser/parser"
@@ -24,7 +27,342 @@ func TestModuleEncode(t *testing.T) {
 	err = module.Parse(ps)
 	assert.Nil(t, err)
 
-	b := module.Module.Encode()
+	b, err := module.Module.Encode()
+	assert.Nil(t, err)
 	assert.Equal(t, b, []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, 0x01, 0x07, 0x01, 0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7f, 0x03, 0x02, 0x01, 0x00, 0x0a, 0x09, 0x01, 0x07, 0x00, 0x20, 0x00, 0x20, 0x01, 0x6a, 0x0b})
 	fmt.Printf("tokens: %v", module.Module)
 }
+
+func TestEncodeUnresolved(t *testing.T) {
+	module := parseWat(t, `(module (func $f (call $f)))`)
+	_, err := module.Encode()
+	assert.EqualError(t, err, "unresolved type use in emission")
+
+	assert.Nil(t, module.Resolve())
+	_, err = module.Encode()
+	assert.Nil(t, err)
+}
+
+func TestEncodeBlock(t *testing.T) {
+	module := parseWat(t, `(module (func (result i32) (block (result i32) (i32.const 1))))`)
+	assert.Nil(t, module.Resolve())
+	bin := encodeWat(t, &module)
+	assert.Equal(t, []byte{0x02, 0x7f, 0x41, 0x01, 0x0b, 0x0b}, bin[len(bin)-6:])
+}
+
+// roundTrip encodes a valid module and checks that the binary survives
+// decoding, and printing the decoded module in either style, it returns the
+// binary for the checks of the test.
+func roundTrip(t *testing.T, source string) []byte {
+	module := parseWat(t, source)
+	assert.Nil(t, module.Resolve(), source)
+	assert.Empty(t, Validate(&module), source)
+	bin := encodeWat(t, &module)
+
+	decoded, err := DecodeModule(bin)
+	if !assert.Nil(t, err, source) {
+		return bin
+	}
+	assert.Empty(t, Validate(decoded), source)
+	assert.Equal(t, bin, encodeWat(t, decoded), source)
+	for _, style := range []PrintStyle{PrintFlat, PrintFolded} {
+		printed := parseWat(t, decoded.Print(style))
+		assert.Nil(t, printed.Resolve(), source)
+		assert.Equal(t, bin, encodeWat(t, &printed), source)
+	}
+
+	return bin
+}
+
+// assertContains checks that bin holds every one of seqs.
+func assertContains(t *testing.T, bin []byte, seqs ...[]byte) {
+	for _, seq := range seqs {
+		assert.True(t, bytes.Contains(bin, seq), "% x", seq)
+	}
+}
+
+func TestEncodeSimd(t *testing.T) {
+	bin := roundTrip(t, `
+(module
+  (global v128 (v128.const i64x2 1 -1))
+  (func (result i32)
+    (v8x16.shuffle 0 1 2 3 4 5 6 7 16 17 18 19 20 21 22 23
+      (v128.const i8x16 0 1 2 3 4 5 6 7 8 9 10 11 12 13 14 -1)
+      (i32x4.replace_lane 3 (v128.const f32x4 1 -0.5 0x1p-1 inf) (i32.const 7)))
+    (i16x8.extract_lane_u 7)
+    (i64x2.neg (f64x2.splat (f64.const 1.5)))
+    (drop (i64x2.extract_lane 1))))
+`)
+	assertContains(t, bin,
+		[]byte{0xfd, 0x02, 1, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x0b},
+		[]byte{0xfd, 0xc1, 0x01, 0, 1, 2, 3, 4, 5, 6, 7, 16, 17, 18, 19, 20, 21, 22, 23},
+		[]byte{0xfd, 0x0a, 7},
+		// opcodes above 0x7f take two LEB128 bytes after the prefix
+		[]byte{0xfd, 0x15, 0xfd, 0x84, 0x01})
+}
+
+func TestValidateLaneIndex(t *testing.T) {
+	for _, source := range []string{
+		`(module (func (result i32) (i32x4.extract_lane 4 (v128.const i32x4 0 0 0 0))))`,
+		`(module (func (result v128) (v8x16.shuffle 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 32
+		  (v128.const i32x4 0 0 0 0) (v128.const i32x4 0 0 0 0))))`,
+	} {
+		module := parseWat(t, source)
+		errs := Validate(&module)
+		if assert.NotEmpty(t, errs, source) {
+			assert.Contains(t, errs[0].Error(), "invalid lane index")
+		}
+	}
+}
+
+func TestEncodeBulkMemory(t *testing.T) {
+	bin := roundTrip(t, `
+(module
+  (memory 1)
+  (table $t 2 funcref)
+  (table $u 2 funcref)
+  (table $x 1 externref)
+  (func $f)
+  (elem $e func $f)
+  (data $d "abc")
+  (func
+    (memory.init $d (i32.const 0) (i32.const 1) (i32.const 2))
+    (data.drop $d)
+    (table.init $e (i32.const 0) (i32.const 0) (i32.const 1))
+    (table.init $u $e (i32.const 0) (i32.const 0) (i32.const 1))
+    (table.copy $u $t (i32.const 0) (i32.const 0) (i32.const 1))
+    (table.set $x (i32.const 0) (ref.null extern))
+    (drop (table.get (i32.const 1)))
+    (drop (ref.is_null (ref.null func)))
+    (elem.drop $e)))
+`)
+	assertContains(t, bin,
+		// the data count section precedes the code section
+		[]byte{0x0c, 0x01, 0x01, 0x0a},
+		[]byte{0xfc, 0x08, 0x00, 0x00},
+		[]byte{0xfc, 0x0c, 0x00, 0x01},
+		[]byte{0xfc, 0x0e, 0x01, 0x00},
+		[]byte{0xd0, 0x6f, 0x26, 0x02})
+}
+
+func TestEncodeExceptions(t *testing.T) {
+	bin := roundTrip(t, `
+(module
+  (tag $imp (import "env" "e") (param i64))
+  (tag $e (export "e") (param i32))
+  (tag $empty)
+  (func (result i32)
+    (try $outer (result i32)
+      (do
+        (try
+          (do (throw $e (i32.const 1)))
+          (delegate $outer))
+        (i32.const 0))
+      (catch $e)
+      (catch $imp
+        (drop)
+        (rethrow 0))
+      (catch_all
+        (i32.const 2))))
+  (func
+    try
+      throw $empty
+    catch_all
+      rethrow 0
+    end))
+`)
+	assertContains(t, bin,
+		// the tag section sits between the memory and global sections
+		[]byte{0x0d, 0x05, 0x02, 0x00, 0x01, 0x00, 0x02},
+		[]byte{0x03, 0x65, 0x6e, 0x76, 0x01, 0x65, 0x04, 0x00, 0x00},
+		[]byte{0x01, 0x65, 0x04, 0x01},
+		[]byte{0x06, 0x7f, 0x06, 0x40, 0x41, 0x01, 0x08, 0x01, 0x18, 0x00},
+		[]byte{0x07, 0x01, 0x07, 0x00, 0x1a, 0x09, 0x00, 0x19, 0x41, 0x02, 0x0b})
+}
+
+func TestEncodeMultiMemory(t *testing.T) {
+	bin := roundTrip(t, `
+(module
+  (memory $a 1)
+  (memory $b i64 1 0x10000)
+  (memory $c i64 (data "xy"))
+  (data (memory $b) (i64.const 8) "abc")
+  (func (result i64)
+    (i32.store $b offset=0x100000000 (i64.const 0) (i32.load (i32.const 0)))
+    (memory.copy $a $b (i32.const 0) (i64.const 0) (i32.const 1))
+    (memory.fill $b (i64.const 0) (i32.const 0) (i64.const 1))
+    (memory.init $b 0 (i64.const 0) (i32.const 0) (i32.const 1))
+    (drop (memory.grow $a (i32.const 1)))
+    (drop (memory.size))
+    (memory.size $c)))
+`)
+	assertContains(t, bin,
+		[]byte{0x05, 0x0b, 0x03, 0x00, 0x01, 0x05, 0x01, 0x80, 0x80, 0x04, 0x05, 0x01, 0x01},
+		// the memory index follows the flags with bit 6 set
+		[]byte{0x36, 0x42, 0x01, 0x80, 0x80, 0x80, 0x80, 0x10},
+		[]byte{0x28, 0x02, 0x00},
+		[]byte{0xfc, 0x0a, 0x00, 0x01},
+		[]byte{0xfc, 0x08, 0x00, 0x01},
+		[]byte{0x3f, 0x02, 0x0b})
+}
+
+func TestEncodeMultiValue(t *testing.T) {
+	// enough types to need two bytes for the block type index
+	var types strings.Builder
+	for i := 0; i < 70; i++ {
+		fmt.Fprintf(&types, "(type (func (result %s)))\n", strings.Repeat("f32 ", i))
+	}
+	bin := roundTrip(t, `
+(module `+types.String()+`
+  (table 1 funcref)
+  (func $swap (param i32 i64) (result i64 i32)
+    (local.get 1) (local.get 0))
+  (func (result i32 i64)
+    (i32.const 1) (i64.const 2)
+    (block (param i32 i64) (result i64 i32)
+      (call $swap))
+    (call_indirect (param i64 i32) (result i32 i64) (i32.const 0))))
+`)
+	// the block type is type 70, encoded as a signed LEB128
+	assertContains(t, bin, []byte{0x02, 0xc6, 0x00, 0x10, 0x00, 0x0b, 0x41, 0x00, 0x11, 0x48, 0x00, 0x0b})
+}
+
+func TestEncodeElemSegments(t *testing.T) {
+	for _, test := range []struct {
+		elem    string
+		encoded []byte
+	}{
+		{`(elem (i32.const 0) $f)`, []byte{0x00, 0x41, 0x00, 0x0b, 0x01, 0x00}},
+		{`(elem func $f)`, []byte{0x01, 0x00, 0x01, 0x00}},
+		{`(elem (table $u) (i32.const 0) func $f)`, []byte{0x02, 0x01, 0x41, 0x00, 0x0b, 0x00, 0x01, 0x00}},
+		{`(elem declare func $f)`, []byte{0x03, 0x00, 0x01, 0x00}},
+		{`(elem (i32.const 0) funcref (ref.func $f) (ref.null func))`,
+			[]byte{0x04, 0x41, 0x00, 0x0b, 0x02, 0xd2, 0x00, 0x0b, 0xd0, 0x70, 0x0b}},
+		{`(elem funcref (ref.func $f) (ref.null func))`, []byte{0x05, 0x70, 0x02, 0xd2, 0x00, 0x0b, 0xd0, 0x70, 0x0b}},
+		{`(elem (table $x) (i32.const 0) externref (ref.null extern))`,
+			[]byte{0x06, 0x02, 0x41, 0x00, 0x0b, 0x6f, 0x01, 0xd0, 0x6f, 0x0b}},
+		{`(elem declare funcref (ref.func $f))`, []byte{0x07, 0x70, 0x01, 0xd2, 0x00, 0x0b}},
+	} {
+		bin := roundTrip(t, `(module (table 1 funcref) (table $u 1 funcref) (table $x 1 externref)
+		  (func $f) (start $f) `+test.elem+`)`)
+		// the start section precedes the element section
+		assertContains(t, bin,
+			append([]byte{0x09, byte(len(test.encoded) + 1), 0x01}, test.encoded...),
+			[]byte{0x08, 0x01, 0x00, 0x09})
+	}
+}
+
+func TestEncodeNames(t *testing.T) {
+	module := parseWat(t, `
+(module $m
+  (import "env" "log" (func $log (param i32)))
+  (global $g i32 (i32.const 0))
+  (func $main (param $x i32) (local $y i64)
+    (block $done (loop $l (br $done)))))
+`)
+	assert.Nil(t, module.Resolve())
+	plain := encodeWat(t, &module)
+	assert.False(t, bytes.Contains(plain, []byte("name")))
+
+	bin, err := module.EncodeWithOptions(EncodeOptions{Names: true})
+	assert.Nil(t, err)
+	assert.Equal(t, plain, bin[:len(plain)])
+	for _, sub := range [][]byte{
+		{0x04, 'n', 'a', 'm', 'e', 0x00, 0x02, 0x01, 'm'},
+		{0x01, 0x0c, 0x02, 0x00, 0x03, 'l', 'o', 'g', 0x01, 0x04, 'm', 'a', 'i', 'n'},
+		{0x02, 0x09, 0x01, 0x01, 0x02, 0x00, 0x01, 'x', 0x01, 0x01, 'y'},
+		{0x03, 0x0c, 0x01, 0x01, 0x02, 0x00, 0x04, 'd', 'o', 'n', 'e', 0x01, 0x01, 'l'},
+		{0x07, 0x04, 0x01, 0x00, 0x01, 'g'},
+	} {
+		assert.True(t, bytes.Contains(bin[len(plain):], sub), "%x", sub)
+	}
+
+	_, err = DecodeModule(bin)
+	assert.Nil(t, err)
+}
+
+func TestEncodeCustomSections(t *testing.T) {
+	module := parseWat(t, `
+(module
+  (@custom "last" "z")
+  (@name "ignored")
+  (@custom "first" (before first) "a" "b")
+  (@custom "code" (before code))
+  (@custom "type" (after type) "\01")
+  (func))
+`)
+	assert.Nil(t, module.Resolve())
+	bin := encodeWat(t, &module)
+	assert.Equal(t, []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+		0x00, 0x08, 0x05, 'f', 'i', 'r', 's', 't', 'a', 'b',
+		0x01, 0x04, 0x01, 0x60, 0x00, 0x00,
+		0x00, 0x06, 0x04, 't', 'y', 'p', 'e', 0x01,
+		0x03, 0x02, 0x01, 0x00,
+		0x00, 0x05, 0x04, 'c', 'o', 'd', 'e',
+		0x0a, 0x04, 0x01, 0x02, 0x00, 0x0b,
+		0x00, 0x06, 0x04, 'l', 'a', 's', 't', 'z'}, bin)
+
+	decoded, err := DecodeModule(bin)
+	assert.Nil(t, err)
+	again, err := decoded.Encode()
+	assert.Nil(t, err)
+	assert.Equal(t, bin, again)
+	assert.Contains(t, decoded.Print(PrintFolded), `(@custom "type" (after type) "\01")`)
+}
+
+func TestEncodeOptions(t *testing.T) {
+	module := parseWat(t, `
+(module
+  (memory 1)
+  (func (param i32) (result i32)
+    (i32.extend8_s (local.get 0))))
+`)
+	assert.Nil(t, module.Resolve())
+	plain := encodeWat(t, &module)
+
+	_, err := module.EncodeWithOptions(EncodeOptions{})
+	var ferr *FeatureError
+	assert.True(t, errors.As(err, &ferr))
+	assert.Equal(t, FeatureSignExt, ferr.Feature)
+	assert.EqualError(t, err, "instruction i32.extend8_s requires the sign-extension-ops proposal")
+
+	opts := EncodeOptions{Features: FeatureSignExt, PadLEB: true,
+		Customs: []Custom{{Name: "build", Place: CustomPlace{Before: true, Anchor: 0x3}, Data: [][]byte{[]byte("debug")}}}}
+	padded, err := module.EncodeWithOptions(opts)
+	assert.Nil(t, err)
+	again, err := module.EncodeWithOptions(opts)
+	assert.Nil(t, err)
+	assert.Equal(t, padded, again)
+	// the type section: id, padded size, padded count, func type
+	assert.Equal(t, []byte{0x01, 0x92, 0x80, 0x80, 0x80, 0x00, 0x81, 0x80, 0x80, 0x80, 0x00,
+		0x60, 0x81, 0x80, 0x80, 0x80, 0x00, 0x7f, 0x81, 0x80, 0x80, 0x80, 0x00, 0x7f}, padded[8:32])
+	assert.Equal(t, byte(0x00), padded[32])
+	assert.True(t, bytes.Contains(padded, []byte("builddebug")))
+
+	decoded, err := DecodeModule(padded)
+	assert.Nil(t, err)
+	fields := decoded.Kind.(ModuleKindText).Fields
+	assert.Equal(t, Custom{Name: "build", Place: CustomPlace{Anchor: 0x1}, Data: [][]byte{[]byte("debug")}}, fields[len(fields)-1])
+	canonical, err := (&Module{Kind: ModuleKindText{Fields: fields[:len(fields)-1]}}).Encode()
+	assert.Nil(t, err)
+	assert.Equal(t, plain, canonical)
+}
+
+func TestEncodePadReservedIndex(t *testing.T) {
+	module := parseWat(t, `
+(module
+  (memory 1)
+  (func (result i32)
+    (memory.grow (memory.size))))
+`)
+	assert.Nil(t, module.Resolve())
+	padded, err := module.EncodeWithOptions(EncodeOptions{PadLEB: true})
+	assert.Nil(t, err)
+	// the memory indices stay single bytes, the count of local declarations
+	// before them is padded
+	assertContains(t, padded, []byte{0x80, 0x80, 0x80, 0x80, 0x00, 0x3f, 0x00, 0x40, 0x00, 0x0b})
+
+	decoded, err := DecodeModule(padded)
+	assert.Nil(t, err)
+	assert.Equal(t, encodeWat(t, &module), encodeWat(t, decoded))
+}
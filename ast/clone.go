@@ -0,0 +1,65 @@
+package ast
+
+import (
+	"reflect"
+)
+
+// Clone returns a deep copy of the module, so it can be resolved or expanded
+// without changing the tree it was parsed into.
+func (self *Module) Clone() *Module {
+	module := new(Module)
+	deepCopy(reflect.ValueOf(module).Elem(), reflect.ValueOf(self).Elem())
+
+	return module
+}
+
+// deepCopy copies src into dst following pointers, interfaces, slices and
+// maps. The unexported fields of the tree only hold plain values, so they are
+// copied along with their struct.
+func deepCopy(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		ptr := reflect.New(src.Type().Elem())
+		deepCopy(ptr.Elem(), src.Elem())
+		dst.Set(ptr)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		val := reflect.New(src.Elem().Type()).Elem()
+		deepCopy(val, src.Elem())
+		dst.Set(val)
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		slice := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			deepCopy(slice.Index(i), src.Index(i))
+		}
+		dst.Set(slice)
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		for _, key := range src.MapKeys() {
+			val := reflect.New(src.Type().Elem()).Elem()
+			deepCopy(val, src.MapIndex(key))
+			m.SetMapIndex(key, val)
+		}
+		dst.Set(m)
+	case reflect.Struct:
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if src.Type().Field(i).PkgPath == "" {
+				deepCopy(dst.Field(i), src.Field(i))
+			}
+		}
+	default:
+		dst.Set(src)
+	}
+}
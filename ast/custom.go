@@ -0,0 +1,131 @@
+package ast
+
+import (
+	"github.com/ontio/wast-parser/lexer"
+	"github.com/ontio/wast-parser/parser"
+)
+
+// Custom is a custom section written with the `@custom` annotation, Place
+// tells where the section goes relative to the known sections.
+type Custom struct {
+	implModuleField
+	Name  string
+	Place CustomPlace
+	Data  [][]byte
+}
+
+// CustomPlace puts a custom section before or after the section with id
+// Anchor. An Anchor of 0 means the start of the module when Before is set and
+// its end otherwise, the zero value is `(after last)`.
+type CustomPlace struct {
+	Before bool
+	Anchor byte
+}
+
+// customAnchors maps the section names of the text format to section ids.
+var customAnchors = map[string]byte{
+	"type":      0x1,
+	"import":    0x2,
+	"func":      0x3,
+	"table":     0x4,
+	"memory":    0x5,
+	"global":    0x6,
+	"export":    0x7,
+	"start":     0x8,
+	"elem":      0x9,
+	"code":      0xa,
+	"data":      0xb,
+	"datacount": 0xc,
+	"tag":       0xd,
+}
+
+func matchAnnotation(token lexer.Token, name string) bool {
+	annot, ok := token.(lexer.Annotation)
+	return ok && annot.Val == name
+}
+
+func (self *Custom) Parse(ps *parser.ParserBuffer) error {
+	err := ps.ExpectAnnotation("custom")
+	if err != nil {
+		return err
+	}
+	self.Name, err = ps.ExpectName()
+	if err != nil {
+		return err
+	}
+
+	if matchTokenType(ps.PeekToken(), lexer.LParenType) {
+		err = ps.Parens(self.Place.Parse)
+		if err != nil {
+			return err
+		}
+	}
+
+	for !ps.Empty() {
+		str, err := ps.ExpectString()
+		if err != nil {
+			return err
+		}
+		self.Data = append(self.Data, []byte(str))
+	}
+
+	return nil
+}
+
+func (self *CustomPlace) Parse(ps *parser.ParserBuffer) error {
+	kw, err := ps.ExpectKeyword()
+	if err != nil {
+		return err
+	}
+	switch kw {
+	case "before":
+		self.Before = true
+	case "after":
+		self.Before = false
+	default:
+		return ps.UnexpectedPrev("before", "after")
+	}
+
+	kw, err = ps.ExpectKeyword()
+	if err != nil {
+		return err
+	}
+	switch {
+	case kw == "first" && self.Before, kw == "last" && !self.Before:
+		self.Anchor = 0
+	default:
+		anchor, ok := customAnchors[kw]
+		if !ok {
+			return ps.UnexpectedPrev("section name")
+		}
+		self.Anchor = anchor
+	}
+
+	return nil
+}
+
+func (self CustomPlace) String() string {
+	switch {
+	case self.Anchor == 0 && self.Before:
+		return "before first"
+	case self.Anchor == 0:
+		return "after last"
+	}
+	for name, id := range customAnchors {
+		if id == self.Anchor {
+			if self.Before {
+				return "before " + name
+			}
+			return "after " + name
+		}
+	}
+
+	return "after last"
+}
+
+func (self Custom) Encode(sink *ZeroCopySink) {
+	sink.WriteString(self.Name)
+	for _, data := range self.Data {
+		sink.WriteBytes(data)
+	}
+}
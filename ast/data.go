@@ -19,11 +19,16 @@ func (self *Data) Parse(ps *parser.ParserBuffer) error {
 	}
 
 	self.Name.Parse(ps)
-	if matchKeyword(ps.PeekToken(), "passive") {
-		_ = ps.ExpectKeywordMatch("passive")
-		self.Kind = DataKindPassive{}
-	} else if ps.PeekToken().Type() == lexer.StringType {
+	span := ps.Pos()
+	if matchKeyword(ps.PeekToken(), "passive") || matchTokenType(ps.PeekToken(), lexer.StringType) {
+		if matchKeyword(ps.PeekToken(), "passive") {
+			_ = ps.ExpectKeywordMatch("passive")
+		}
 		self.Kind = DataKindPassive{}
+		err = ps.Require(span, FeatureBulkMemory, "passive data segment")
+		if err != nil {
+			return err
+		}
 	} else {
 		var memory OptionIndex
 		if matchKeyword(ps.Peek2Token(), "memory") {
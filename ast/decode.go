@@ -0,0 +1,905 @@
+package ast
+
+// maxLocals bounds the number of locals a decoded function may declare, the
+// compressed binary form could otherwise expand into an unbounded slice.
+const maxLocals = 50000
+
+// DecodeModule parses a binary module into the same field tree the text
+// parser produces. Errors are reported as *DecodeError with the offset of the
+// offending byte.
+func DecodeModule(data []byte) (*Module, error) {
+	var dec decoder
+	fields, err := dec.decode(NewZeroCopySource(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Module{Name: NoneOptionId(), Kind: ModuleKindText{Fields: fields}}, nil
+}
+
+// sectionOrder ranks the known section ids in the order they must appear.
+var sectionOrder = map[byte]int{
+	0x1: 1, 0x2: 2, 0x3: 3, 0x4: 4, 0x5: 5, 0xd: 6, 0x6: 7, 0x7: 8,
+	0x8: 9, 0x9: 10, 0xc: 11, 0xa: 12, 0xb: 13,
+}
+
+type decoder struct {
+	types     []FunctionType
+	funcTypes []uint32
+	dataCount *uint32
+
+	imports  []ModuleField
+	funcs    []ModuleField
+	tables   []ModuleField
+	memories []ModuleField
+	tags     []ModuleField
+	globals  []ModuleField
+	exports  []ModuleField
+	start    []ModuleField
+	elems    []ModuleField
+	datas    []ModuleField
+	customs  []ModuleField
+	// id of the last non-custom section read, custom sections are placed
+	// after it
+	lastId byte
+}
+
+func (self *decoder) decode(source *ZeroCopySource) ([]ModuleField, error) {
+	magic, err := source.NextBytes(4)
+	if err != nil || string(magic) != "\x00asm" {
+		return nil, source.Errorf(0, "magic header not detected")
+	}
+	version, err := source.NextBytes(4)
+	if err != nil || string(version) != "\x01\x00\x00\x00" {
+		return nil, source.Errorf(4, "unknown binary version")
+	}
+
+	last := 0
+	for source.Len() > 0 {
+		pos := source.Pos()
+		id, err := source.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		size, err := source.ReadUint32()
+		if err != nil {
+			return nil, err
+		}
+		if uint64(size) > source.Len() {
+			return nil, source.Errorf(pos, "length out of bounds")
+		}
+		section, err := source.SubSource(uint64(size))
+		if err != nil {
+			return nil, err
+		}
+		if id != 0 {
+			order, ok := sectionOrder[id]
+			if !ok {
+				return nil, source.Errorf(pos, "malformed section id %d", id)
+			}
+			if order <= last {
+				return nil, source.Errorf(pos, "unexpected section id %d", id)
+			}
+			last = order
+			self.lastId = id
+		}
+		err = self.decodeSection(id, section)
+		if err != nil {
+			return nil, err
+		}
+		if section.Len() != 0 {
+			return nil, section.Errorf(section.Pos(), "section size mismatch")
+		}
+	}
+
+	if len(self.funcTypes) != len(self.funcs) {
+		return nil, source.Errorf(source.Pos(), "function and code section have inconsistent lengths")
+	}
+	if self.dataCount != nil && int(*self.dataCount) != len(self.datas) {
+		return nil, source.Errorf(source.Pos(), "data count and data section have inconsistent lengths")
+	}
+
+	var fields []ModuleField
+	for _, ty := range self.types {
+		fields = append(fields, Type{Name: NoneOptionId(), Func: ty})
+	}
+	for _, list := range [][]ModuleField{self.imports, self.funcs, self.tables, self.memories,
+		self.tags, self.globals, self.exports, self.start, self.elems, self.datas, self.customs} {
+		fields = append(fields, list...)
+	}
+
+	return fields, nil
+}
+
+func (self *decoder) decodeSection(id byte, source *ZeroCopySource) error {
+	switch id {
+	case 0x0:
+		// custom sections carry no semantics, only the name is checked
+		name, err := source.ReadString()
+		if err != nil {
+			return err
+		}
+		data, err := source.NextBytes(source.Len())
+		if err != nil {
+			return err
+		}
+		place := CustomPlace{Before: true}
+		if self.lastId != 0 {
+			place = CustomPlace{Anchor: self.lastId}
+		}
+		custom := Custom{Name: name, Place: place}
+		if len(data) != 0 {
+			custom.Data = [][]byte{append([]byte(nil), data...)}
+		}
+		self.customs = append(self.customs, custom)
+		return nil
+	case 0x8:
+		var index Index
+		err := index.Decode(source)
+		if err != nil {
+			return err
+		}
+		self.start = append(self.start, StartField{Index: index})
+		return nil
+	case 0xc:
+		count, err := source.ReadUint32()
+		if err != nil {
+			return err
+		}
+		self.dataCount = &count
+		return nil
+	}
+
+	count, err := source.ReadCount()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		var err error
+		switch id {
+		case 0x1:
+			var ty FunctionType
+			err = ty.Decode(source)
+			self.types = append(self.types, ty)
+		case 0x2:
+			var imp Import
+			err = imp.Decode(source)
+			if err == nil {
+				switch item := imp.Item.(type) {
+				case ImportFunc:
+					fillSignature(&item.TypeUse, self.types)
+					imp.Item = item
+				case ImportTag:
+					fillSignature(&item.TypeUse, self.types)
+					imp.Item = item
+				}
+			}
+			self.imports = append(self.imports, imp)
+		case 0x3:
+			var index uint32
+			index, err = source.ReadUint32()
+			self.funcTypes = append(self.funcTypes, index)
+		case 0x4:
+			var table TableKindNormal
+			err = table.Type.Decode(source)
+			self.tables = append(self.tables, Table{Name: NoneOptionId(), Kind: table})
+		case 0x5:
+			var mem MemoryKindNormal
+			err = mem.Type.Decode(source)
+			self.memories = append(self.memories, Memory{Name: NoneOptionId(), Kind: &mem})
+		case 0xd:
+			var tag Tag
+			err = tag.Decode(source)
+			if err == nil {
+				fillSignature(&tag.Type, self.types)
+			}
+			self.tags = append(self.tags, tag)
+		case 0x6:
+			var global Global
+			err = global.Decode(source)
+			self.globals = append(self.globals, global)
+		case 0x7:
+			var export Export
+			err = export.Decode(source)
+			self.exports = append(self.exports, export)
+		case 0x9:
+			var elem Elem
+			err = elem.Decode(source)
+			self.elems = append(self.elems, elem)
+		case 0xa:
+			err = self.decodeCode(source)
+		case 0xb:
+			var data Data
+			err = data.Decode(source)
+			self.datas = append(self.datas, data)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (self *decoder) decodeCode(source *ZeroCopySource) error {
+	pos := source.Pos()
+	index := len(self.funcs)
+	if index >= len(self.funcTypes) {
+		return source.Errorf(pos, "function and code section have inconsistent lengths")
+	}
+	size, err := source.ReadUint32()
+	if err != nil {
+		return err
+	}
+	body, err := source.SubSource(uint64(size))
+	if err != nil {
+		return err
+	}
+
+	var locals []Local
+	count, err := body.ReadCount()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		localsPos := body.Pos()
+		n, err := body.ReadUint32()
+		if err != nil {
+			return err
+		}
+		var ty ValType
+		err = ty.Decode(body)
+		if err != nil {
+			return err
+		}
+		if uint64(len(locals))+uint64(n) > maxLocals {
+			return body.Errorf(localsPos, "too many locals")
+		}
+		for j := uint32(0); j < n; j++ {
+			locals = append(locals, Local{Id: NoneOptionId(), ValType: ty})
+		}
+	}
+
+	var expr Expression
+	err = expr.Decode(body)
+	if err != nil {
+		return err
+	}
+	if body.Len() != 0 {
+		return body.Errorf(body.Pos(), "section size mismatch")
+	}
+
+	fun := Func{
+		Name: NoneOptionId(),
+		Kind: FuncKindInline{Locals: locals, Expr: expr},
+		Type: TypeUse{Index: NewOptionIndex(NewNumIndex(self.funcTypes[index]))},
+	}
+	fillSignature(&fun.Type, self.types)
+	self.funcs = append(self.funcs, fun)
+	return nil
+}
+
+// fillSignature copies the referenced signature into the inline part of a
+// type use, the same way resolution does for text modules.
+func fillSignature(ty *TypeUse, types []FunctionType) {
+	index := ty.Index.ToIndex().Num
+	if int(index) < len(types) {
+		ty.Type = types[index]
+	}
+}
+
+func (self *Expression) Decode(source *ZeroCopySource) error {
+	depth := 0
+	for {
+		inst, err := decodeInstr(source)
+		if err != nil {
+			return err
+		}
+		switch inst.(type) {
+		case *Block, *Loop, *If, *Try:
+			depth += 1
+		case *Delegate:
+			depth -= 1
+		case *End:
+			if depth == 0 {
+				return nil
+			}
+			depth -= 1
+		}
+		self.Instrs = append(self.Instrs, inst)
+	}
+}
+
+func (self *ValType) Decode(source *ZeroCopySource) error {
+	pos := source.Pos()
+	b, err := source.ReadByte()
+	if err != nil {
+		return err
+	}
+	switch b {
+	case 0x7f:
+		*self = I32
+	case 0x7e:
+		*self = I64
+	case 0x7d:
+		*self = F32
+	case 0x7c:
+		*self = F64
+	case 0x7b:
+		*self = V128
+	case 0x70:
+		*self = Funcref
+	case 0x6f:
+		*self = Anyref
+	default:
+		return source.Errorf(pos, "malformed value type 0x%x", b)
+	}
+
+	return nil
+}
+
+func (self *TableElemType) Decode(source *ZeroCopySource) error {
+	pos := source.Pos()
+	b, err := source.ReadByte()
+	if err != nil {
+		return err
+	}
+	switch b {
+	case 0x70:
+		*self = FuncRef
+	case 0x6f:
+		*self = AnyRef
+	default:
+		return source.Errorf(pos, "malformed reference type 0x%x", b)
+	}
+
+	return nil
+}
+
+func (self *HeapType) Decode(source *ZeroCopySource) error {
+	pos := source.Pos()
+	b, err := source.ReadByte()
+	if err != nil {
+		return err
+	}
+	switch b {
+	case 0x70:
+		*self = HeapFunc
+	case 0x6f:
+		*self = HeapExtern
+	default:
+		return source.Errorf(pos, "malformed reference type 0x%x", b)
+	}
+
+	return nil
+}
+
+func (self *FunctionType) Decode(source *ZeroCopySource) error {
+	pos := source.Pos()
+	form, err := source.ReadByte()
+	if err != nil {
+		return err
+	}
+	if form != TypeFunc {
+		return source.Errorf(pos, "malformed function type 0x%x", form)
+	}
+	count, err := source.ReadCount()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		var param FuncParam
+		err := param.Val.Decode(source)
+		if err != nil {
+			return err
+		}
+		self.Params = append(self.Params, param)
+	}
+	count, err = source.ReadCount()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		var result ValType
+		err := result.Decode(source)
+		if err != nil {
+			return err
+		}
+		self.Results = append(self.Results, result)
+	}
+
+	return nil
+}
+
+func (self *Limits) Decode(source *ZeroCopySource, hasMax bool, is64 bool) error {
+	read := func() (uint64, error) {
+		if is64 {
+			return source.ReadUint64()
+		}
+		val, err := source.ReadUint32()
+		return uint64(val), err
+	}
+	var err error
+	self.Min, err = read()
+	if err != nil {
+		return err
+	}
+	self.HasMax = hasMax
+	if hasMax {
+		self.Max, err = read()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (self *TableType) Decode(source *ZeroCopySource) error {
+	err := self.Elem.Decode(source)
+	if err != nil {
+		return err
+	}
+	pos := source.Pos()
+	flags, err := source.ReadByte()
+	if err != nil {
+		return err
+	}
+	if flags > 1 {
+		return source.Errorf(pos, "integer too large")
+	}
+
+	return self.Limits.Decode(source, flags == 1, false)
+}
+
+func (self *MemoryType) Decode(source *ZeroCopySource) error {
+	pos := source.Pos()
+	flags, err := source.ReadByte()
+	if err != nil {
+		return err
+	}
+	if flags > 7 {
+		return source.Errorf(pos, "integer too large")
+	}
+	self.Shared = flags&0x2 != 0
+	self.Is64 = flags&0x4 != 0
+
+	return self.Limits.Decode(source, flags&0x1 != 0, self.Is64)
+}
+
+func (self *GlobalValType) Decode(source *ZeroCopySource) error {
+	err := self.Type.Decode(source)
+	if err != nil {
+		return err
+	}
+	pos := source.Pos()
+	mut, err := source.ReadByte()
+	if err != nil {
+		return err
+	}
+	if mut > 1 {
+		return source.Errorf(pos, "malformed mutability")
+	}
+	self.Mutable = mut == 1
+
+	return nil
+}
+
+func (self *Import) Decode(source *ZeroCopySource) error {
+	var err error
+	self.Module, err = source.ReadString()
+	if err != nil {
+		return err
+	}
+	self.Field, err = source.ReadString()
+	if err != nil {
+		return err
+	}
+	self.Id = NoneOptionId()
+	pos := source.Pos()
+	kind, err := source.ReadByte()
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case 0x00:
+		var index Index
+		err = index.Decode(source)
+		self.Item = ImportFunc{TypeUse: TypeUse{Index: NewOptionIndex(index)}}
+	case 0x01:
+		var table ImportTable
+		err = table.Table.Decode(source)
+		self.Item = table
+	case 0x02:
+		var mem ImportMemory
+		err = mem.Mem.Decode(source)
+		self.Item = mem
+	case 0x03:
+		var global ImportGlobal
+		err = global.Global.Decode(source)
+		self.Item = global
+	case 0x04:
+		var tag ImportTag
+		err = tag.TypeUse.decodeTag(source)
+		self.Item = tag
+	default:
+		return source.Errorf(pos, "malformed import kind 0x%x", kind)
+	}
+
+	return err
+}
+
+func (self *Global) Decode(source *ZeroCopySource) error {
+	self.Name = NoneOptionId()
+	err := self.ValType.Decode(source)
+	if err != nil {
+		return err
+	}
+	var expr Expression
+	err = expr.Decode(source)
+	if err != nil {
+		return err
+	}
+	self.Kind = GlobalKindInline{Expr: expr}
+
+	return nil
+}
+
+func (self *Tag) Decode(source *ZeroCopySource) error {
+	self.Name = NoneOptionId()
+	self.Kind = TagKindInline{}
+	return self.Type.decodeTag(source)
+}
+
+// decodeTag reads the tag type, the attribute byte followed by the index of
+// the signature.
+func (self *TypeUse) decodeTag(source *ZeroCopySource) error {
+	pos := source.Pos()
+	attr, err := source.ReadByte()
+	if err != nil {
+		return err
+	}
+	if attr != 0 {
+		return source.Errorf(pos, "malformed tag attribute 0x%x", attr)
+	}
+	var index Index
+	err = index.Decode(source)
+	if err != nil {
+		return err
+	}
+	self.Index = NewOptionIndex(index)
+
+	return nil
+}
+
+func (self *Export) Decode(source *ZeroCopySource) error {
+	var err error
+	self.Name, err = source.ReadString()
+	if err != nil {
+		return err
+	}
+	pos := source.Pos()
+	kind, err := source.ReadByte()
+	if err != nil {
+		return err
+	}
+	if kind > byte(ExportTag) {
+		return source.Errorf(pos, "malformed export kind 0x%x", kind)
+	}
+	self.Type = ExportType(kind)
+
+	return self.Index.Decode(source)
+}
+
+func (self *Elem) Decode(source *ZeroCopySource) error {
+	self.Name = NoneOptionId()
+	pos := source.Pos()
+	flags, err := source.ReadUint32()
+	if err != nil {
+		return err
+	}
+	if flags > 7 {
+		return source.Errorf(pos, "malformed elements segment kind %d", flags)
+	}
+
+	exprs := flags&0x4 != 0
+	switch {
+	case flags&0x1 == 0:
+		active := ElemKindActive{Table: NewNumIndex(0)}
+		if flags&0x2 != 0 {
+			self.forceNonZero = true
+			err = active.Table.Decode(source)
+			if err != nil {
+				return err
+			}
+		}
+		err = active.Offset.Decode(source)
+		if err != nil {
+			return err
+		}
+		self.Kind = active
+	case flags&0x2 == 0:
+		self.Kind = ElemKindPassive{}
+	default:
+		self.Kind = ElemKindDeclared{}
+	}
+
+	// only the flags with an explicit table or without one carry the kind
+	elemType := FuncRef
+	if flags&0x3 != 0 {
+		if exprs {
+			err = elemType.Decode(source)
+		} else {
+			pos := source.Pos()
+			var kind byte
+			kind, err = source.ReadByte()
+			if err == nil && kind != 0x00 {
+				return source.Errorf(pos, "malformed element kind 0x%x", kind)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	count, err := source.ReadCount()
+	if err != nil {
+		return err
+	}
+	if !exprs {
+		var payload ElemPayloadIndices
+		for i := uint32(0); i < count; i++ {
+			var index Index
+			err := index.Decode(source)
+			if err != nil {
+				return err
+			}
+			payload.Indices = append(payload.Indices, index)
+		}
+		self.Payload = payload
+		return nil
+	}
+
+	payload := ElemPayloadExprs{Type: elemType}
+	for i := uint32(0); i < count; i++ {
+		pos := source.Pos()
+		var expr Expression
+		err := expr.Decode(source)
+		if err != nil {
+			return err
+		}
+		if len(expr.Instrs) != 1 {
+			return source.Errorf(pos, "unsupported element expression")
+		}
+		switch inst := expr.Instrs[0].(type) {
+		case *RefNull:
+			payload.Exprs = append(payload.Exprs, NoneOptionIndex())
+		case *RefFunc:
+			payload.Exprs = append(payload.Exprs, NewOptionIndex(inst.Index))
+		default:
+			return source.Errorf(pos, "unsupported element expression")
+		}
+	}
+	self.Payload = payload
+
+	return nil
+}
+
+func (self *Data) Decode(source *ZeroCopySource) error {
+	self.Name = NoneOptionId()
+	pos := source.Pos()
+	flags, err := source.ReadUint32()
+	if err != nil {
+		return err
+	}
+	switch flags {
+	case 0x0, 0x2:
+		active := DataKindActive{Memory: NewNumIndex(0)}
+		if flags == 0x2 {
+			err = active.Memory.Decode(source)
+			if err != nil {
+				return err
+			}
+		}
+		err = active.Offset.Decode(source)
+		if err != nil {
+			return err
+		}
+		self.Kind = active
+	case 0x1:
+		self.Kind = DataKindPassive{}
+	default:
+		return source.Errorf(pos, "malformed data segment kind %d", flags)
+	}
+
+	val, err := source.ReadVarBytes()
+	if err != nil {
+		return err
+	}
+	self.Val = [][]byte{val}
+
+	return nil
+}
+
+func (self *Index) Decode(source *ZeroCopySource) error {
+	num, err := source.ReadUint32()
+	if err != nil {
+		return err
+	}
+	*self = NewNumIndex(num)
+
+	return nil
+}
+
+func (self *BlockType) Decode(source *ZeroCopySource) error {
+	pos := source.Pos()
+	b, err := source.ReadByte()
+	if err != nil {
+		return err
+	}
+	if b == 0x40 {
+		return nil
+	}
+	source.BackUp(1)
+
+	var ty ValType
+	if ty.Decode(source) == nil {
+		self.Ty.Type.Results = []ValType{ty}
+		return nil
+	}
+	source.BackUp(source.Pos() - pos)
+
+	index, err := source.ReadInt33()
+	if err != nil {
+		return err
+	}
+	if index < 0 {
+		return source.Errorf(pos, "malformed block type")
+	}
+	self.Ty.Index = NewOptionIndex(NewNumIndex(uint32(index)))
+
+	return nil
+}
+
+func (self *BrTableIndices) Decode(source *ZeroCopySource) error {
+	count, err := source.ReadCount()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		var label Index
+		err := label.Decode(source)
+		if err != nil {
+			return err
+		}
+		self.Labels = append(self.Labels, label)
+	}
+
+	return self.Default.Decode(source)
+}
+
+func (self *CallIndirectInner) Decode(source *ZeroCopySource) error {
+	var index Index
+	err := index.Decode(source)
+	if err != nil {
+		return err
+	}
+	self.Type.Index = NewOptionIndex(index)
+
+	return self.Table.Decode(source)
+}
+
+func (self *TableInitInner) Decode(source *ZeroCopySource) error {
+	err := self.Elem.Decode(source)
+	if err != nil {
+		return err
+	}
+
+	return self.Table.Decode(source)
+}
+
+func (self *TableCopyInner) Decode(source *ZeroCopySource) error {
+	err := self.Dst.Decode(source)
+	if err != nil {
+		return err
+	}
+
+	return self.Src.Decode(source)
+}
+
+func (self *MemoryInitInner) Decode(source *ZeroCopySource) error {
+	err := self.Data.Decode(source)
+	if err != nil {
+		return err
+	}
+
+	return self.Memory.Decode(source)
+}
+
+func (self *MemoryCopyInner) Decode(source *ZeroCopySource) error {
+	err := self.Dst.Decode(source)
+	if err != nil {
+		return err
+	}
+
+	return self.Src.Decode(source)
+}
+
+func (self *SelectTypes) Decode(source *ZeroCopySource) error {
+	op, err := source.ReadByte()
+	if err != nil {
+		return err
+	}
+	if op == 0x1b {
+		return nil
+	}
+	count, err := source.ReadCount()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		var ty ValType
+		err := ty.Decode(source)
+		if err != nil {
+			return err
+		}
+		self.Types = append(self.Types, ty)
+	}
+
+	return nil
+}
+
+func (self *MemArg) Decode(source *ZeroCopySource) error {
+	pos := source.Pos()
+	align, err := source.ReadUint32()
+	if err != nil {
+		return err
+	}
+	self.Memory = NewNumIndex(0)
+	if align&0x40 != 0 {
+		align &^= 0x40
+		err = self.Memory.Decode(source)
+		if err != nil {
+			return err
+		}
+	}
+	if align >= 32 {
+		return source.Errorf(pos, "malformed memop flags")
+	}
+	self.Align = 1 << align
+	self.Offset, err = source.ReadUint64()
+
+	return err
+}
+
+func (self *Float32) Decode(source *ZeroCopySource) error {
+	var err error
+	self.Bits, err = source.ReadFloat32()
+	return err
+}
+
+func (self *Float64) Decode(source *ZeroCopySource) error {
+	var err error
+	self.Bits, err = source.ReadFloat64()
+	return err
+}
+
+func (self *V128Bits) Decode(source *ZeroCopySource) error {
+	buf, err := source.NextBytes(16)
+	if err != nil {
+		return err
+	}
+	copy(self.Bytes[:], buf)
+
+	return nil
+}
+
+func (self *ShuffleLanes) Decode(source *ZeroCopySource) error {
+	buf, err := source.NextBytes(16)
+	if err != nil {
+		return err
+	}
+	copy(self.Lanes[:], buf)
+
+	return nil
+}
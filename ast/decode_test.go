@@ -0,0 +1,75 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeRoundTrip(t *testing.T) {
+	module := parseWat(t, `
+(module
+  (type $t (func (param i32) (result i32)))
+  (import "env" "log" (func $log (param i32)))
+  (import "env" "mem" (memory 1))
+  (table 2 funcref)
+  (global $g (mut i64) (i64.const -1))
+  (func $f (export "f") (type $t) (local i64 i64 f32)
+    local.get 0
+    i32.const -2
+    i32.add
+    i32.load offset=8 align=4
+    call $log
+    f32.const 1.5
+    local.set 3
+    local.get 0
+    global.get $g
+    i32.wrap_i64
+    i32.sub)
+  (elem (i32.const 0) $f $log)
+  (data (i32.const 16) "\00\01\02")
+)
+`)
+	assert.Nil(t, module.Resolve())
+	bin := encodeWat(t, &module)
+
+	decoded, err := DecodeModule(bin)
+	assert.Nil(t, err)
+	assert.Equal(t, bin, encodeWat(t, decoded))
+
+	fields := decoded.Kind.(ModuleKindText).Fields
+	var fun Func
+	for _, field := range fields {
+		if f, ok := field.(Func); ok {
+			fun = f
+		}
+	}
+	assert.Equal(t, []ValType{I32}, fun.Type.Type.Results)
+	instrs := fun.Kind.(FuncKindInline).Expr.Instrs
+	assert.Equal(t, uint32(0xfffffffe), instrs[1].(*I32Const).Val)
+	assert.Equal(t, MemArg{Memory: NewNumIndex(0), Align: 4, Offset: 8}, instrs[3].(*I32Load).MemArg)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	header := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	tests := []struct {
+		bin    []byte
+		offset uint64
+		msg    string
+	}{
+		{[]byte{0x00, 0x61, 0x73}, 0, "magic header not detected"},
+		{[]byte{0x00, 0x61, 0x73, 0x6d, 0x02, 0x00, 0x00, 0x00}, 4, "unknown binary version"},
+		{append(header, 0x01, 0x05, 0x01, 0x60), 8, "length out of bounds"},
+		{append(header, 0x0e, 0x00), 8, "malformed section id 14"},
+		{append(header, 0x03, 0x01, 0x00, 0x01, 0x01, 0x00), 11, "unexpected section id 1"},
+		{append(header, 0x03, 0x02, 0x01, 0x00), 12, "function and code section have inconsistent lengths"},
+		{append(header, 0x01, 0x04, 0x01, 0x60, 0x00, 0x00, 0x03, 0x02, 0x01, 0x00,
+			0x0a, 0x05, 0x01, 0x03, 0x00, 0xf0, 0x0b), 23, "illegal opcode 0xf0"},
+		{append(header, 0x01, 0x06, 0x01, 0x60, 0x80, 0x80, 0x80, 0x80), 16, "unexpected end"},
+	}
+
+	for _, test := range tests {
+		_, err := DecodeModule(test.bin)
+		assert.Equal(t, &DecodeError{Offset: test.offset, Msg: test.msg}, err)
+	}
+}
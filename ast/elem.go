@@ -26,6 +26,10 @@ type ElemKindPassive struct {
 	implElemKind
 }
 
+type ElemKindDeclared struct {
+	implElemKind
+}
+
 type ElemKindActive struct {
 	implElemKind
 	Table  Index
@@ -40,7 +44,7 @@ func (self *Elem) Parse(ps *parser.ParserBuffer) error {
 
 	self.Name.Parse(ps)
 	self.forceNonZero = false
-	if ps.PeekToken().Type() == lexer.LParenType || ps.PeekUint32() {
+	if matchTokenType(ps.PeekToken(), lexer.LParenType) || ps.PeekUint32() {
 		var table OptionIndex
 		if matchKeyword(ps.Peek2Token(), "table") {
 			self.forceNonZero = true
@@ -79,8 +83,20 @@ func (self *Elem) Parse(ps *parser.ParserBuffer) error {
 			Table:  table.ToIndexOr(NewNumIndex(0)),
 			Offset: expr,
 		}
+	} else if matchKeyword(ps.PeekToken(), "declare") {
+		span := ps.Pos()
+		_ = ps.ExpectKeywordMatch("declare")
+		self.Kind = ElemKindDeclared{}
+		err = ps.Require(span, FeatureBulkMemory, "declared element segment")
+		if err != nil {
+			return err
+		}
 	} else {
 		self.Kind = ElemKindPassive{}
+		err = ps.Require(ps.Pos(), FeatureBulkMemory, "passive element segment")
+		if err != nil {
+			return err
+		}
 	}
 
 	self.Payload, err = parseElemPayload(ps)
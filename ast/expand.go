@@ -0,0 +1,251 @@
+package ast
+
+// Expand desugars the inline forms of a text module: inline imports become
+// `Import` fields, inline exports become `Export` fields, and the inline
+// `(memory (data ...))` and `(table funcref (elem ...))` abbreviations become
+// plain memories and tables followed by active data and elem segments.
+//
+// The binary format numbers the imports of an index space before its
+// definitions, so the imports are moved ahead of the other fields, keeping
+// their relative order.
+func (self *Module) Expand() {
+	text, ok := self.Kind.(ModuleKindText)
+	if !ok {
+		return
+	}
+
+	exp := expander{defined: countImports(text.Fields)}
+	for _, field := range text.Fields {
+		exp.expand(field)
+	}
+
+	self.Kind = ModuleKindText{Fields: append(exp.imports, exp.fields...)}
+}
+
+const pageSize = 65536
+
+// indexSpaces counts the items of the index spaces which may be imported.
+type indexSpaces struct {
+	funcs    uint32
+	tables   uint32
+	memories uint32
+	globals  uint32
+	tags     uint32
+}
+
+// countImports counts the imports of each index space, in both the explicit
+// and the inline form.
+func countImports(fields []ModuleField) indexSpaces {
+	var count indexSpaces
+	for _, field := range fields {
+		switch val := field.(type) {
+		case Import:
+			switch val.Item.(type) {
+			case ImportFunc:
+				count.funcs += 1
+			case ImportTable:
+				count.tables += 1
+			case ImportMemory:
+				count.memories += 1
+			case ImportGlobal:
+				count.globals += 1
+			case ImportTag:
+				count.tags += 1
+			}
+		case Func:
+			if _, ok := val.Kind.(FuncKindImport); ok {
+				count.funcs += 1
+			}
+		case Table:
+			if _, ok := val.Kind.(TableKindImport); ok {
+				count.tables += 1
+			}
+		case Memory:
+			if _, ok := val.Kind.(*MemoryKindImport); ok {
+				count.memories += 1
+			}
+		case Global:
+			if _, ok := val.Kind.(GlobalKindImport); ok {
+				count.globals += 1
+			}
+		case Tag:
+			if _, ok := val.Kind.(TagKindImport); ok {
+				count.tags += 1
+			}
+		}
+	}
+
+	return count
+}
+
+// next returns the index of the next item of the space and counts it.
+func next(count *uint32) uint32 {
+	index := *count
+	*count += 1
+
+	return index
+}
+
+type expander struct {
+	imports []ModuleField
+	fields  []ModuleField
+	// imported and defined hold the next index of an import and of a
+	// definition, the definitions start after all the imports
+	imported indexSpaces
+	defined  indexSpaces
+}
+
+func (self *expander) push(field ModuleField) {
+	self.fields = append(self.fields, field)
+}
+
+func (self *expander) pushImport(imp Import) {
+	self.imports = append(self.imports, imp)
+}
+
+func (self *expander) pushExports(exports InlineExport, ty ExportType, index Index) {
+	for _, name := range exports.Names {
+		self.push(Export{Name: name, Type: ty, Index: index})
+	}
+}
+
+func constOffset(offset uint32) Expression {
+	return Expression{Instrs: []Instruction{&I32Const{Val: offset}}}
+}
+
+// itemIndex refers to an item by its identifier if it has one, so that the
+// name survives until resolution. Otherwise the item takes the next index of
+// the imports or of the definitions of its space.
+func (self *expander) itemIndex(name OptionId, imported bool, imports, defs *uint32) Index {
+	count := defs
+	if imported {
+		count = imports
+	}
+	num := next(count)
+	if name.IsSome() {
+		return Index{Id: name.ToId()}
+	}
+
+	return NewNumIndex(num)
+}
+
+func (self *expander) expand(field ModuleField) {
+	switch val := field.(type) {
+	case Import:
+		self.pushImport(val)
+	case Func:
+		imp, imported := val.Kind.(FuncKindImport)
+		index := self.itemIndex(val.Name, imported, &self.imported.funcs, &self.defined.funcs)
+		if imported {
+			self.pushImport(Import{
+				Module: imp.Module,
+				Field:  imp.Name,
+				Id:     val.Name,
+				Item:   ImportFunc{TypeUse: val.Type},
+			})
+		} else {
+			self.push(Func{Name: val.Name, Kind: val.Kind, Type: val.Type})
+		}
+		self.pushExports(val.Exports, ExportFunc, index)
+	case Table:
+		_, imported := val.Kind.(TableKindImport)
+		index := self.itemIndex(val.Name, imported, &self.imported.tables, &self.defined.tables)
+		switch kind := val.Kind.(type) {
+		case TableKindImport:
+			self.pushImport(Import{
+				Module: kind.Module,
+				Field:  kind.Name,
+				Id:     val.Name,
+				Item:   ImportTable{Table: kind.Type},
+			})
+		case TableKindInline:
+			var count uint32
+			switch payload := kind.Payload.(type) {
+			case ElemPayloadIndices:
+				count = uint32(len(payload.Indices))
+			case ElemPayloadExprs:
+				count = uint32(len(payload.Exprs))
+			}
+			self.push(Table{
+				Name: val.Name,
+				Kind: TableKindNormal{Type: TableType{
+					Limits: Limits{Min: uint64(count), Max: uint64(count), HasMax: true},
+					Elem:   kind.Elem,
+				}},
+			})
+			self.push(Elem{
+				Kind:    ElemKindActive{Table: index, Offset: constOffset(0)},
+				Payload: kind.Payload,
+			})
+		default:
+			self.push(Table{Name: val.Name, Kind: val.Kind})
+		}
+		self.pushExports(val.Exports, ExportTable, index)
+	case Memory:
+		_, imported := val.Kind.(*MemoryKindImport)
+		index := self.itemIndex(val.Name, imported, &self.imported.memories, &self.defined.memories)
+		switch kind := val.Kind.(type) {
+		case *MemoryKindImport:
+			self.pushImport(Import{
+				Module: kind.Module,
+				Field:  kind.Name,
+				Id:     val.Name,
+				Item:   ImportMemory{Mem: kind.Type},
+			})
+		case *MemoryKindInline:
+			var size uint32
+			for _, data := range kind.Val {
+				size += uint32(len(data))
+			}
+			pages := (size + pageSize - 1) / pageSize
+			self.push(Memory{
+				Name: val.Name,
+				Kind: &MemoryKindNormal{Type: MemoryType{
+					Limits: Limits{Min: uint64(pages), Max: uint64(pages), HasMax: true},
+					Is64:   kind.Is64,
+				}},
+			})
+			offset := constOffset(0)
+			if kind.Is64 {
+				offset = Expression{Instrs: []Instruction{&I64Const{Val: 0}}}
+			}
+			self.push(Data{
+				Kind: DataKindActive{Memory: index, Offset: offset},
+				Val:  kind.Val,
+			})
+		default:
+			self.push(Memory{Name: val.Name, Kind: val.Kind})
+		}
+		self.pushExports(val.Exports, ExportMemory, index)
+	case Global:
+		imp, imported := val.Kind.(GlobalKindImport)
+		index := self.itemIndex(val.Name, imported, &self.imported.globals, &self.defined.globals)
+		if imported {
+			self.pushImport(Import{
+				Module: imp.Module,
+				Field:  imp.Field,
+				Id:     val.Name,
+				Item:   ImportGlobal{Global: val.ValType},
+			})
+		} else {
+			self.push(Global{Name: val.Name, ValType: val.ValType, Kind: val.Kind})
+		}
+		self.pushExports(val.Exports, ExportGlobal, index)
+	case Tag:
+		imp, imported := val.Kind.(TagKindImport)
+		index := self.itemIndex(val.Name, imported, &self.imported.tags, &self.defined.tags)
+		if imported {
+			self.pushImport(Import{
+				Module: imp.Module,
+				Field:  imp.Field,
+				Id:     val.Name,
+				Item:   ImportTag{TypeUse: val.Type},
+			})
+		} else {
+			self.push(Tag{Name: val.Name, Type: val.Type, Kind: val.Kind})
+		}
+		self.pushExports(val.Exports, ExportTag, index)
+	default:
+		self.push(field)
+	}
+}
@@ -0,0 +1,72 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandInlineForms(t *testing.T) {
+	inline := parseWat(t, `
+(module
+  (type $t (func))
+  (func $imp (import "env" "f") (type $t))
+  (global $g (import "env" "g") i32)
+  (func $f (export "f") (export "f2") (type $t))
+  (table $tab (export "tab") funcref (elem $imp $f))
+  (memory (export "mem") (data "hello" "world"))
+  (global (export "g") (mut i32) (i32.const 7))
+)
+`)
+	explicit := parseWat(t, `
+(module
+  (type (func))
+  (import "env" "f" (func (type 0)))
+  (import "env" "g" (global i32))
+  (func (type 0))
+  (export "f" (func 1))
+  (export "f2" (func 1))
+  (table 2 2 funcref)
+  (elem (i32.const 0) 0 1)
+  (export "tab" (table 0))
+  (memory 1 1)
+  (data (i32.const 0) "hello" "world")
+  (export "mem" (memory 0))
+  (global (mut i32) (i32.const 7))
+  (export "g" (global 1))
+)
+`)
+
+	assert.Nil(t, inline.Resolve())
+	assert.Nil(t, explicit.Resolve())
+	assert.Equal(t, encodeWat(t, &explicit), encodeWat(t, &inline))
+}
+
+func TestExpandImportsFirst(t *testing.T) {
+	module := parseWat(t, `
+(module
+  (func $a (export "a"))
+  (func $b (import "x" "y"))
+  (global (export "g") i32 (i32.const 1))
+  (global (import "x" "g") i32)
+  (func (export "callb") (call $b))
+)
+`)
+	assert.Nil(t, module.Resolve())
+	decoded, err := DecodeModule(encodeWat(t, &module))
+	assert.Nil(t, err)
+
+	exports := make(map[string]uint32)
+	var funcs []Func
+	for _, field := range decoded.Kind.(ModuleKindText).Fields {
+		switch val := field.(type) {
+		case Export:
+			exports[val.Name] = val.Index.Num
+		case Func:
+			funcs = append(funcs, val)
+		}
+	}
+	assert.Equal(t, map[string]uint32{"a": 1, "g": 1, "callb": 2}, exports)
+	instrs := funcs[1].Kind.(FuncKindInline).Expr.Instrs
+	assert.Equal(t, NewNumIndex(0), instrs[0].(*Call).Index)
+}
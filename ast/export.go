@@ -1,16 +1,18 @@
 package ast
 
 import (
-	"fmt"
 	"github.com/ontio/wast-parser/parser"
 )
 
 type ExportType byte
 
-const ExportFunc = ExportType(0)
-const ExportTable = iota
-const ExportMemory = iota
-const ExportGlobal = iota
+const (
+	ExportFunc ExportType = iota
+	ExportTable
+	ExportMemory
+	ExportGlobal
+	ExportTag
+)
 
 type Export struct {
 	implModuleField
@@ -24,7 +26,7 @@ func (self *Export) Parse(ps *parser.ParserBuffer) error {
 	if err != nil {
 		return err
 	}
-	self.Name, err = ps.ExpectString()
+	self.Name, err = ps.ExpectName()
 	if err != nil {
 		return err
 	}
@@ -43,8 +45,10 @@ func (self *Export) Parse(ps *parser.ParserBuffer) error {
 			self.Type = ExportMemory
 		case "global":
 			self.Type = ExportGlobal
+		case "tag":
+			self.Type = ExportTag
 		default:
-			return fmt.Errorf("parse export type error: unexpected keyword %s", kw)
+			return ps.UnexpectedPrev("func", "table", "memory", "global", "tag")
 		}
 
 		return self.Index.Parse(ps)
@@ -64,9 +68,9 @@ func (self *InlineExport) Parse(ps *parser.ParserBuffer) error {
 		err := ps.Parens(func(ps *parser.ParserBuffer) error {
 			err := ps.ExpectKeywordMatch("export")
 			if err != nil {
-				panic(err)
+				return err
 			}
-			name, err := ps.ExpectString()
+			name, err := ps.ExpectName()
 			if err != nil {
 				return err
 			}
@@ -1,7 +1,6 @@
 package ast
 
 import (
-	"fmt"
 	"github.com/ontio/wast-parser/lexer"
 	"github.com/ontio/wast-parser/parser"
 )
@@ -21,19 +20,44 @@ func (self *Expression) Parse(ps *parser.ParserBuffer) error {
 	return nil
 }
 
+// String returns the instructions with their immediates in flat form on a
+// single line.
 func (self *Expression) String() string {
-	str := ""
+	p := &printer{inline: true}
 	for _, inst := range self.Instrs {
-		str += inst.String() + " "
+		p.printInstr(inst)
 	}
 
-	return str
+	return p.buf.String()
 }
 
 type Instruction interface {
 	parseInstrBody(ps *parser.ParserBuffer) error
 	String() string
 	Encode(sink *ZeroCopySink)
+	decodeInstrBody(source *ZeroCopySource) error
+	printInstrBody(p *printer)
+}
+
+// textKeywords are the keywords of the text format other than instructions,
+// they are unexpected rather than unknown in place of an instruction.
+var textKeywords = map[string]bool{
+	"module": true, "type": true, "import": true, "func": true, "table": true,
+	"memory": true, "global": true, "tag": true, "export": true, "start": true,
+	"elem": true, "data": true, "param": true, "result": true, "local": true,
+	"mut": true, "then": true, "offset": true, "item": true, "declare": true,
+	"i32": true, "i64": true, "f32": true, "f64": true, "v128": true,
+	"funcref": true, "externref": true, "anyref": true, "nullref": true,
+}
+
+// unknownInstr is the error for the keyword kw read in place of an
+// instruction at span.
+func unknownInstr(ps *parser.ParserBuffer, span lexer.Span, kw string) error {
+	if textKeywords[kw] {
+		return ps.UnexpectedPrev()
+	}
+
+	return &parser.UnknownInstructionError{Span: span, Name: kw}
 }
 
 type instructions struct {
@@ -52,7 +76,7 @@ func (self *instructions) parseFoldedInstrs(ps *parser.ParserBuffer) error {
 }
 
 func (self *instructions) parseOneInstr(ps *parser.ParserBuffer) error {
-	if ps.PeekToken().Type() != lexer.LParenType {
+	if !matchTokenType(ps.PeekToken(), lexer.LParenType) {
 		instr, err := parseInstr(ps)
 		if err != nil {
 			return err
@@ -76,8 +100,8 @@ func (self *instructions) parseOneInstr(ps *parser.ParserBuffer) error {
 			}
 			self.Instrs = append(self.Instrs, &End{Id: NoneOptionId()})
 		case *If:
-			if ps.PeekToken().Type() != lexer.LParenType {
-				return fmt.Errorf("expected (")
+			if !matchTokenType(ps.PeekToken(), lexer.LParenType) {
+				return ps.Unexpected("(")
 			}
 			if !matchKeyword(ps.Peek2Token(), "then") {
 				err := self.parseOneInstr(ps)
@@ -86,8 +110,8 @@ func (self *instructions) parseOneInstr(ps *parser.ParserBuffer) error {
 				}
 			}
 			self.Instrs = append(self.Instrs, val)
-			if ps.PeekToken().Type() != lexer.LParenType {
-				return fmt.Errorf("expected `(`")
+			if !matchTokenType(ps.PeekToken(), lexer.LParenType) {
+				return ps.Unexpected("(")
 			}
 			if matchKeyword(ps.Peek2Token(), "then") {
 				err = ps.Parens(func(ps *parser.ParserBuffer) error {
@@ -103,7 +127,7 @@ func (self *instructions) parseOneInstr(ps *parser.ParserBuffer) error {
 					return err
 				}
 			}
-			if ps.PeekToken().Type() == lexer.LParenType {
+			if matchTokenType(ps.PeekToken(), lexer.LParenType) {
 				before := len(self.Instrs)
 				self.Instrs = append(self.Instrs, &Else{})
 				if matchKeyword(ps.Peek2Token(), "else") {
@@ -125,6 +149,8 @@ func (self *instructions) parseOneInstr(ps *parser.ParserBuffer) error {
 				}
 			}
 			self.Instrs = append(self.Instrs, &End{})
+		case *Try:
+			return self.parseFoldedTry(ps, val)
 		default:
 			err := self.parseFoldedInstrs(ps)
 			if err != nil {
@@ -137,6 +163,66 @@ func (self *instructions) parseOneInstr(ps *parser.ParserBuffer) error {
 	})
 }
 
+// parseFoldedTry desugars `(try bt (do ...) (catch $e ...)* (catch_all ...)?)`
+// and `(try bt (do ...) (delegate $l))` into the flat instruction sequence.
+func (self *instructions) parseFoldedTry(ps *parser.ParserBuffer, try *Try) error {
+	self.Instrs = append(self.Instrs, try)
+	if !matchKeyword(ps.Peek2Token(), "do") {
+		return ps.Unexpected("(do")
+	}
+	err := ps.Parens(func(ps *parser.ParserBuffer) error {
+		_ = ps.ExpectKeywordMatch("do")
+		return self.parseFoldedInstrs(ps)
+	})
+	if err != nil {
+		return err
+	}
+
+	if matchKeyword(ps.Peek2Token(), "delegate") {
+		return ps.Parens(func(ps *parser.ParserBuffer) error {
+			instr, err := parseInstr(ps)
+			if err != nil {
+				return err
+			}
+			self.Instrs = append(self.Instrs, instr)
+			return nil
+		})
+	}
+
+	for matchKeyword(ps.Peek2Token(), "catch") {
+		err := ps.Parens(func(ps *parser.ParserBuffer) error {
+			instr, err := parseInstr(ps)
+			if err != nil {
+				return err
+			}
+			self.Instrs = append(self.Instrs, instr)
+			return self.parseFoldedInstrs(ps)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if matchKeyword(ps.Peek2Token(), "catch_all") {
+		err := ps.Parens(func(ps *parser.ParserBuffer) error {
+			instr, err := parseInstr(ps)
+			if err != nil {
+				return err
+			}
+			self.Instrs = append(self.Instrs, instr)
+			return self.parseFoldedInstrs(ps)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if !ps.Empty() {
+		return ps.Unexpected("(catch", "(catch_all", ")")
+	}
+	self.Instrs = append(self.Instrs, &End{Id: NoneOptionId()})
+
+	return nil
+}
+
 type BrTableIndices struct {
 	Labels  []Index
 	Default Index
@@ -186,6 +272,98 @@ func (self *CallIndirectInner) Parse(ps *parser.ParserBuffer) error {
 	return nil
 }
 
+// TableInitInner is the immediate of `table.init`, the table may be omitted
+// in the text format.
+type TableInitInner struct {
+	Table Index
+	Elem  Index
+}
+
+func (self *TableInitInner) Parse(ps *parser.ParserBuffer) error {
+	var first Index
+	err := first.Parse(ps)
+	if err != nil {
+		return err
+	}
+	var second OptionIndex
+	second.Parse(ps)
+	if second.IsSome() {
+		self.Table = first
+		self.Elem = second.ToIndex()
+	} else {
+		self.Table = NewNumIndex(0)
+		self.Elem = first
+	}
+
+	return nil
+}
+
+// TableCopyInner is the immediate of `table.copy`, both tables are 0 if
+// omitted in the text format.
+type TableCopyInner struct {
+	Dst Index
+	Src Index
+}
+
+func (self *TableCopyInner) Parse(ps *parser.ParserBuffer) error {
+	var dst OptionIndex
+	dst.Parse(ps)
+	if !dst.IsSome() {
+		self.Dst = NewNumIndex(0)
+		self.Src = NewNumIndex(0)
+		return nil
+	}
+	self.Dst = dst.ToIndex()
+
+	return self.Src.Parse(ps)
+}
+
+// MemoryInitInner is the immediate of `memory.init`, the memory may be
+// omitted in the text format.
+type MemoryInitInner struct {
+	Memory Index
+	Data   Index
+}
+
+func (self *MemoryInitInner) Parse(ps *parser.ParserBuffer) error {
+	var first Index
+	err := first.Parse(ps)
+	if err != nil {
+		return err
+	}
+	var second OptionIndex
+	second.Parse(ps)
+	if second.IsSome() {
+		self.Memory = first
+		self.Data = second.ToIndex()
+	} else {
+		self.Memory = NewNumIndex(0)
+		self.Data = first
+	}
+
+	return nil
+}
+
+// MemoryCopyInner is the immediate of `memory.copy`, both memories are 0 if
+// omitted in the text format.
+type MemoryCopyInner struct {
+	Dst Index
+	Src Index
+}
+
+func (self *MemoryCopyInner) Parse(ps *parser.ParserBuffer) error {
+	var dst OptionIndex
+	dst.Parse(ps)
+	if !dst.IsSome() {
+		self.Dst = NewNumIndex(0)
+		self.Src = NewNumIndex(0)
+		return nil
+	}
+	self.Dst = dst.ToIndex()
+
+	return self.Src.Parse(ps)
+}
+
 type SelectTypes struct {
 	Types []ValType
 }
@@ -0,0 +1,216 @@
+package ast
+
+import (
+	"fmt"
+
+	"github.com/ontio/wast-parser/lexer"
+	"github.com/ontio/wast-parser/parser"
+)
+
+// Features is the set of proposals of the parser, restated here for the
+// encoder.
+type Features = parser.Features
+
+const (
+	FeatureSignExt        = parser.FeatureSignExt
+	FeatureSatTrunc       = parser.FeatureSatTrunc
+	FeatureMultiValue     = parser.FeatureMultiValue
+	FeatureBulkMemory     = parser.FeatureBulkMemory
+	FeatureReferenceTypes = parser.FeatureReferenceTypes
+	FeatureSimd           = parser.FeatureSimd
+	FeatureThreads        = parser.FeatureThreads
+	FeatureTailCall       = parser.FeatureTailCall
+	FeatureExceptions     = parser.FeatureExceptions
+	FeatureMultiMemory    = parser.FeatureMultiMemory
+	FeatureMemory64       = parser.FeatureMemory64
+	AllFeatures           = parser.AllFeatures
+)
+
+type FeatureError = parser.FeatureError
+
+// requireInstrFeature fails if the instruction parsed at span needs a
+// proposal the parse does not accept.
+func requireInstrFeature(ps *parser.ParserBuffer, span lexer.Span, instr Instruction) error {
+	if f := instrFeature(instr); f != 0 {
+		if err := ps.Require(span, f, "instruction "+instr.String()); err != nil {
+			return err
+		}
+	}
+	if mem, ok := instr.(memoryInstr); ok {
+		arg, _ := mem.memArg()
+		if !arg.Memory.Isnum || arg.Memory.Num != 0 {
+			return ps.Require(span, FeatureMultiMemory, "instruction "+instr.String()+" on a memory other than 0")
+		}
+	}
+
+	return nil
+}
+
+// valTypeFeature returns the proposal a value type belongs to, 0 for the
+// MVP.
+func valTypeFeature(ty ValType) Features {
+	switch ty {
+	case V128:
+		return FeatureSimd
+	case Anyref, Funcref:
+		return FeatureReferenceTypes
+	}
+
+	return 0
+}
+
+// featureChecker collects the first use of a proposal outside features.
+type featureChecker struct {
+	features Features
+	err      error
+}
+
+func (self *featureChecker) require(f Features, what string, args ...interface{}) {
+	if self.err == nil && !self.features.Has(f) {
+		self.err = &FeatureError{Feature: f &^ self.features, What: fmt.Sprintf(what, args...)}
+	}
+}
+
+func (self *featureChecker) valType(ty ValType) {
+	if f := valTypeFeature(ty); f != 0 {
+		self.require(f, "value type %s", ty)
+	}
+}
+
+func (self *featureChecker) funcType(ty FunctionType) {
+	for _, param := range ty.Params {
+		self.valType(param.Val)
+	}
+	for _, result := range ty.Results {
+		self.valType(result)
+	}
+	if len(ty.Results) > 1 {
+		self.require(FeatureMultiValue, "multiple results")
+	}
+}
+
+func (self *featureChecker) memoryType(ty MemoryType) {
+	if ty.Shared {
+		self.require(FeatureThreads, "shared memory")
+	}
+	if ty.Is64 {
+		self.require(FeatureMemory64, "64-bit memory")
+	}
+}
+
+func (self *featureChecker) tableType(ty TableType) {
+	if ty.Elem != FuncRef {
+		self.require(FeatureReferenceTypes, "table type %s", ty.Elem)
+	}
+}
+
+func (self *featureChecker) expr(expr Expression) {
+	for _, instr := range expr.Instrs {
+		if f := instrFeature(instr); f != 0 {
+			self.require(f, "instruction %s", instr)
+		}
+		if mem, ok := instr.(memoryInstr); ok {
+			arg, _ := mem.memArg()
+			if !arg.Memory.Isnum || arg.Memory.Num != 0 {
+				self.require(FeatureMultiMemory, "instruction %s on a memory other than 0", instr)
+			}
+		}
+		var block *BlockType
+		switch inst := instr.(type) {
+		case *Block:
+			block = &inst.BlockType
+		case *Loop:
+			block = &inst.BlockType
+		case *If:
+			block = &inst.BlockType
+		case *Try:
+			block = &inst.BlockType
+		}
+		if block != nil && (len(block.Ty.Type.Params) != 0 || len(block.Ty.Type.Results) > 1) {
+			self.require(FeatureMultiValue, "block type with parameters or multiple results")
+		}
+		if sel, ok := instr.(*Select); ok {
+			for _, ty := range sel.SelectTypes.Types {
+				self.valType(ty)
+			}
+		}
+	}
+}
+
+// checkFeatures returns a FeatureError for the first construct of a module
+// which needs a proposal outside features.
+func checkFeatures(fields []ModuleField, features Features) error {
+	checker := &featureChecker{features: features}
+	var tables, memories int
+	for _, field := range fields {
+		switch val := field.(type) {
+		case Type:
+			checker.funcType(val.Func)
+		case Import:
+			switch item := val.Item.(type) {
+			case ImportFunc:
+				checker.funcType(item.TypeUse.Type)
+			case ImportTable:
+				checker.tableType(item.Table)
+				tables += 1
+			case ImportMemory:
+				checker.memoryType(item.Mem)
+				memories += 1
+			case ImportGlobal:
+				checker.valType(item.Global.Type)
+			case ImportTag:
+				checker.require(FeatureExceptions, "tag import")
+			}
+		case Func:
+			checker.funcType(val.Type.Type)
+			if inline, ok := val.Kind.(FuncKindInline); ok {
+				for _, local := range inline.Locals {
+					checker.valType(local.ValType)
+				}
+				checker.expr(inline.Expr)
+			}
+		case Table:
+			if normal, ok := val.Kind.(TableKindNormal); ok {
+				checker.tableType(normal.Type)
+			}
+			tables += 1
+		case Memory:
+			if normal, ok := val.Kind.(*MemoryKindNormal); ok {
+				checker.memoryType(normal.Type)
+			}
+			memories += 1
+		case Global:
+			checker.valType(val.ValType.Type)
+			if inline, ok := val.Kind.(GlobalKindInline); ok {
+				checker.expr(inline.Expr)
+			}
+		case Tag:
+			checker.require(FeatureExceptions, "tag")
+		case Elem:
+			switch kind := val.Kind.(type) {
+			case ElemKindActive:
+				checker.expr(kind.Offset)
+			default:
+				checker.require(FeatureBulkMemory, "passive or declared element segment")
+			}
+			if _, ok := val.Payload.(ElemPayloadExprs); ok {
+				checker.require(FeatureBulkMemory, "element expressions")
+			}
+		case Data:
+			switch kind := val.Kind.(type) {
+			case DataKindActive:
+				checker.expr(kind.Offset)
+			default:
+				checker.require(FeatureBulkMemory, "passive data segment")
+			}
+		}
+	}
+	if tables > 1 {
+		checker.require(FeatureReferenceTypes, "multiple tables")
+	}
+	if memories > 1 {
+		checker.require(FeatureMultiMemory, "multiple memories")
+	}
+
+	return checker.err
+}
@@ -51,15 +51,15 @@ func (self *Func) Parse(ps *parser.ParserBuffer) error {
 		err := ps.Parens(func(ps *parser.ParserBuffer) error {
 			err := ps.ExpectKeywordMatch("import")
 			if err != nil {
-				panic(err)
+				return err
 			}
 
-			module, err = ps.ExpectString()
+			module, err = ps.ExpectName()
 			if err != nil {
 				return err
 			}
 
-			name, err = ps.ExpectString()
+			name, err = ps.ExpectName()
 			if err != nil {
 				return err
 			}
@@ -87,7 +87,7 @@ func (self *Func) Parse(ps *parser.ParserBuffer) error {
 		err := ps.Parens(func(ps *parser.ParserBuffer) error {
 			err := ps.ExpectKeywordMatch("local")
 			if err != nil {
-				panic(err)
+				return err
 			}
 
 			if ps.Empty() {
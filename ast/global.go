@@ -47,13 +47,13 @@ func (self *Global) Parse(ps *parser.ParserBuffer) error {
 		err = ps.Parens(func(ps *parser.ParserBuffer) error {
 			err := ps.ExpectKeywordMatch("import")
 			if err != nil {
-				panic(err)
+				return err
 			}
-			imp.Module, err = ps.ExpectString()
+			imp.Module, err = ps.ExpectName()
 			if err != nil {
 				return err
 			}
-			imp.Field, err = ps.ExpectString()
+			imp.Field, err = ps.ExpectName()
 			return err
 		})
 		if err != nil {
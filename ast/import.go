@@ -1,8 +1,6 @@
 package ast
 
 import (
-	"fmt"
-
 	"github.com/ontio/wast-parser/parser"
 )
 
@@ -43,17 +41,23 @@ type ImportTable struct {
 
 func (self ImportTable) ImportType() string { return "table" }
 
+type ImportTag struct {
+	TypeUse TypeUse
+}
+
+func (self ImportTag) ImportType() string { return "tag" }
+
 func (self *Import) Parse(ps *parser.ParserBuffer) error {
 	err := ps.ExpectKeywordMatch("import")
 	if err != nil {
 		return err
 	}
 
-	self.Module, err = ps.ExpectString()
+	self.Module, err = ps.ExpectName()
 	if err != nil {
 		return err
 	}
-	self.Field, err = ps.ExpectString()
+	self.Field, err = ps.ExpectName()
 	if err != nil {
 		return err
 	}
@@ -94,8 +98,15 @@ func (self *Import) Parse(ps *parser.ParserBuffer) error {
 				return err
 			}
 			self.Item = ImportGlobal{Global: global}
+		case "tag":
+			var typeUse TypeUse
+			err := typeUse.Parse(ps)
+			if err != nil {
+				return err
+			}
+			self.Item = ImportTag{TypeUse: typeUse}
 		default:
-			return fmt.Errorf("parse import item error: unexpected keyword: %s", kw)
+			return ps.UnexpectedPrev("func", "table", "memory", "global", "tag")
 		}
 
 		return nil
@@ -1,7 +1,7 @@
 package ast
 
 import (
-	"fmt"
+	"strconv"
 
 	"github.com/ontio/wast-parser/parser"
 )
@@ -30,6 +30,19 @@ func (self *Block) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *Block) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.BlockType.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *Block) printInstrBody(p *printer) {
+	self.BlockType.print(p)
+
+}
+
 type If struct {
 	BlockType BlockType
 }
@@ -54,6 +67,19 @@ func (self *If) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *If) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.BlockType.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *If) printInstrBody(p *printer) {
+	self.BlockType.print(p)
+
+}
+
 type Else struct {
 	Id OptionId
 }
@@ -75,6 +101,16 @@ func (self *Else) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *Else) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *Else) printInstrBody(p *printer) {
+	self.Id.print(p)
+
+}
+
 type Loop struct {
 	BlockType BlockType
 }
@@ -99,6 +135,19 @@ func (self *Loop) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *Loop) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.BlockType.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *Loop) printInstrBody(p *printer) {
+	self.BlockType.print(p)
+
+}
+
 type End struct {
 	Id OptionId
 }
@@ -120,71 +169,58 @@ func (self *End) Encode(sink *ZeroCopySink) {
 
 }
 
-type Unreachable struct {
-}
-
-func (self *Unreachable) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *End) decodeInstrBody(source *ZeroCopySource) error {
 
 	return nil
 }
 
-func (self *Unreachable) String() string {
-	return "unreachable"
-}
-
-func (self *Unreachable) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x0}
-	sink.WriteBytes(inst)
+func (self *End) printInstrBody(p *printer) {
+	self.Id.print(p)
 
 }
 
-type Nop struct {
+type Try struct {
+	BlockType BlockType
 }
 
-func (self *Nop) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *Try) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.BlockType.Parse(ps)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *Nop) String() string {
-	return "nop"
+func (self *Try) String() string {
+	return "try"
 }
 
-func (self *Nop) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x1}
+func (self *Try) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x6}
 	sink.WriteBytes(inst)
+	self.BlockType.Encode(sink)
 
 }
 
-type Br struct {
-	Index Index
-}
-
-func (self *Br) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Index.Parse(ps)
-	if err != nil {
+func (self *Try) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.BlockType.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *Br) String() string {
-	return "br"
-}
-
-func (self *Br) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xc}
-	sink.WriteBytes(inst)
-	self.Index.Encode(sink)
+func (self *Try) printInstrBody(p *printer) {
+	self.BlockType.print(p)
 
 }
 
-type BrIf struct {
+type Catch struct {
 	Index Index
 }
 
-func (self *BrIf) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *Catch) parseInstrBody(ps *parser.ParserBuffer) error {
 	err := self.Index.Parse(ps)
 	if err != nil {
 		return err
@@ -193,64 +229,62 @@ func (self *BrIf) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *BrIf) String() string {
-	return "br_if"
+func (self *Catch) String() string {
+	return "catch"
 }
 
-func (self *BrIf) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xd}
+func (self *Catch) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x7}
 	sink.WriteBytes(inst)
 	self.Index.Encode(sink)
 
 }
 
-type BrTable struct {
-	Indices BrTableIndices
-}
-
-func (self *BrTable) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Indices.Parse(ps)
-	if err != nil {
+func (self *Catch) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *BrTable) String() string {
-	return "br_table"
-}
-
-func (self *BrTable) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xe}
-	sink.WriteBytes(inst)
-	self.Indices.Encode(sink)
+func (self *Catch) printInstrBody(p *printer) {
+	self.Index.print(p)
 
 }
 
-type Return struct {
+type CatchAll struct {
 }
 
-func (self *Return) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *CatchAll) parseInstrBody(ps *parser.ParserBuffer) error {
 
 	return nil
 }
 
-func (self *Return) String() string {
-	return "return"
+func (self *CatchAll) String() string {
+	return "catch_all"
 }
 
-func (self *Return) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xf}
+func (self *CatchAll) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x19}
 	sink.WriteBytes(inst)
 
 }
 
-type Call struct {
+func (self *CatchAll) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *CatchAll) printInstrBody(p *printer) {
+
+}
+
+type Delegate struct {
 	Index Index
 }
 
-func (self *Call) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *Delegate) parseInstrBody(ps *parser.ParserBuffer) error {
 	err := self.Index.Parse(ps)
 	if err != nil {
 		return err
@@ -259,46 +293,35 @@ func (self *Call) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *Call) String() string {
-	return "call"
+func (self *Delegate) String() string {
+	return "delegate"
 }
 
-func (self *Call) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x10}
+func (self *Delegate) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x18}
 	sink.WriteBytes(inst)
 	self.Index.Encode(sink)
 
 }
 
-type CallIndirect struct {
-	Impl CallIndirectInner
-}
-
-func (self *CallIndirect) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Impl.Parse(ps)
-	if err != nil {
+func (self *Delegate) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *CallIndirect) String() string {
-	return "call_indirect"
-}
-
-func (self *CallIndirect) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x11}
-	sink.WriteBytes(inst)
-	self.Impl.Encode(sink)
+func (self *Delegate) printInstrBody(p *printer) {
+	self.Index.print(p)
 
 }
 
-type ReturnCall struct {
+type Throw struct {
 	Index Index
 }
 
-func (self *ReturnCall) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *Throw) parseInstrBody(ps *parser.ParserBuffer) error {
 	err := self.Index.Parse(ps)
 	if err != nil {
 		return err
@@ -307,136 +330,126 @@ func (self *ReturnCall) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *ReturnCall) String() string {
-	return "return_call"
+func (self *Throw) String() string {
+	return "throw"
 }
 
-func (self *ReturnCall) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x12}
+func (self *Throw) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x8}
 	sink.WriteBytes(inst)
 	self.Index.Encode(sink)
 
 }
 
-type ReturnCallIndirect struct {
-	Impl CallIndirectInner
-}
-
-func (self *ReturnCallIndirect) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Impl.Parse(ps)
-	if err != nil {
+func (self *Throw) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *ReturnCallIndirect) String() string {
-	return "return_call_indirect"
-}
-
-func (self *ReturnCallIndirect) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x13}
-	sink.WriteBytes(inst)
-	self.Impl.Encode(sink)
+func (self *Throw) printInstrBody(p *printer) {
+	self.Index.print(p)
 
 }
 
-type Drop struct {
+type Rethrow struct {
+	Index Index
 }
 
-func (self *Drop) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *Rethrow) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Index.Parse(ps)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *Drop) String() string {
-	return "drop"
+func (self *Rethrow) String() string {
+	return "rethrow"
 }
 
-func (self *Drop) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x1a}
+func (self *Rethrow) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x9}
 	sink.WriteBytes(inst)
+	self.Index.Encode(sink)
 
 }
 
-type Select struct {
-	SelectTypes SelectTypes
-}
-
-func (self *Select) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.SelectTypes.Parse(ps)
-	if err != nil {
+func (self *Rethrow) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *Select) String() string {
-	return "select"
-}
-
-func (self *Select) Encode(sink *ZeroCopySink) {
-	inst := []byte{}
-	sink.WriteBytes(inst)
-	self.SelectTypes.Encode(sink)
+func (self *Rethrow) printInstrBody(p *printer) {
+	self.Index.print(p)
 
 }
 
-type LocalGet struct {
-	Index Index
+type Unreachable struct {
 }
 
-func (self *LocalGet) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Index.Parse(ps)
-	if err != nil {
-		return err
-	}
+func (self *Unreachable) parseInstrBody(ps *parser.ParserBuffer) error {
 
 	return nil
 }
 
-func (self *LocalGet) String() string {
-	return "local.get"
+func (self *Unreachable) String() string {
+	return "unreachable"
 }
 
-func (self *LocalGet) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x20}
+func (self *Unreachable) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x0}
 	sink.WriteBytes(inst)
-	self.Index.Encode(sink)
 
 }
 
-type LocalSet struct {
-	Index Index
+func (self *Unreachable) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
 }
 
-func (self *LocalSet) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Index.Parse(ps)
-	if err != nil {
-		return err
-	}
+func (self *Unreachable) printInstrBody(p *printer) {
+
+}
+
+type Nop struct {
+}
+
+func (self *Nop) parseInstrBody(ps *parser.ParserBuffer) error {
 
 	return nil
 }
 
-func (self *LocalSet) String() string {
-	return "local.set"
+func (self *Nop) String() string {
+	return "nop"
 }
 
-func (self *LocalSet) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x21}
+func (self *Nop) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x1}
 	sink.WriteBytes(inst)
-	self.Index.Encode(sink)
 
 }
 
-type LocalTee struct {
+func (self *Nop) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *Nop) printInstrBody(p *printer) {
+
+}
+
+type Br struct {
 	Index Index
 }
 
-func (self *LocalTee) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *Br) parseInstrBody(ps *parser.ParserBuffer) error {
 	err := self.Index.Parse(ps)
 	if err != nil {
 		return err
@@ -445,46 +458,35 @@ func (self *LocalTee) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *LocalTee) String() string {
-	return "local.tee"
+func (self *Br) String() string {
+	return "br"
 }
 
-func (self *LocalTee) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x22}
+func (self *Br) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xc}
 	sink.WriteBytes(inst)
 	self.Index.Encode(sink)
 
 }
 
-type GlobalGet struct {
-	Index Index
-}
-
-func (self *GlobalGet) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Index.Parse(ps)
-	if err != nil {
+func (self *Br) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *GlobalGet) String() string {
-	return "global.get"
-}
-
-func (self *GlobalGet) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x23}
-	sink.WriteBytes(inst)
-	self.Index.Encode(sink)
+func (self *Br) printInstrBody(p *printer) {
+	self.Index.print(p)
 
 }
 
-type GlobalSet struct {
+type BrIf struct {
 	Index Index
 }
 
-func (self *GlobalSet) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *BrIf) parseInstrBody(ps *parser.ParserBuffer) error {
 	err := self.Index.Parse(ps)
 	if err != nil {
 		return err
@@ -493,47 +495,36 @@ func (self *GlobalSet) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *GlobalSet) String() string {
-	return "global.set"
+func (self *BrIf) String() string {
+	return "br_if"
 }
 
-func (self *GlobalSet) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x24}
+func (self *BrIf) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xd}
 	sink.WriteBytes(inst)
 	self.Index.Encode(sink)
 
 }
 
-type TableGet struct {
-	Index Index
-}
-
-func (self *TableGet) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Index.Parse(ps)
-	if err != nil {
+func (self *BrIf) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *TableGet) String() string {
-	return "table.get"
-}
-
-func (self *TableGet) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x25}
-	sink.WriteBytes(inst)
-	self.Index.Encode(sink)
+func (self *BrIf) printInstrBody(p *printer) {
+	self.Index.print(p)
 
 }
 
-type TableSet struct {
-	Index Index
+type BrTable struct {
+	Indices BrTableIndices
 }
 
-func (self *TableSet) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Index.Parse(ps)
+func (self *BrTable) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Indices.Parse(ps)
 	if err != nil {
 		return err
 	}
@@ -541,95 +532,63 @@ func (self *TableSet) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *TableSet) String() string {
-	return "table.set"
+func (self *BrTable) String() string {
+	return "br_table"
 }
 
-func (self *TableSet) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x26}
+func (self *BrTable) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xe}
 	sink.WriteBytes(inst)
-	self.Index.Encode(sink)
-
-}
+	self.Indices.Encode(sink)
 
-type I32Load struct {
-	MemArg MemArg
 }
 
-func (self *I32Load) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
-	if err != nil {
+func (self *BrTable) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Indices.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I32Load) String() string {
-	return "i32.load"
-}
-
-func (self *I32Load) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x28}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+func (self *BrTable) printInstrBody(p *printer) {
+	self.Indices.print(p)
 
 }
 
-type I64Load struct {
-	MemArg MemArg
+type Return struct {
 }
 
-func (self *I64Load) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 8)
-	if err != nil {
-		return err
-	}
+func (self *Return) parseInstrBody(ps *parser.ParserBuffer) error {
 
 	return nil
 }
 
-func (self *I64Load) String() string {
-	return "i64.load"
+func (self *Return) String() string {
+	return "return"
 }
 
-func (self *I64Load) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x29}
+func (self *Return) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xf}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
 
-type F32Load struct {
-	MemArg MemArg
 }
 
-func (self *F32Load) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
-	if err != nil {
-		return err
-	}
+func (self *Return) decodeInstrBody(source *ZeroCopySource) error {
 
 	return nil
 }
 
-func (self *F32Load) String() string {
-	return "f32.load"
-}
-
-func (self *F32Load) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x2a}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+func (self *Return) printInstrBody(p *printer) {
 
 }
 
-type F64Load struct {
-	MemArg MemArg
+type Call struct {
+	Index Index
 }
 
-func (self *F64Load) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 8)
+func (self *Call) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Index.Parse(ps)
 	if err != nil {
 		return err
 	}
@@ -637,47 +596,36 @@ func (self *F64Load) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *F64Load) String() string {
-	return "f64.load"
+func (self *Call) String() string {
+	return "call"
 }
 
-func (self *F64Load) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x2b}
+func (self *Call) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x10}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	self.Index.Encode(sink)
 
-type I32Load8s struct {
-	MemArg MemArg
 }
 
-func (self *I32Load8s) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
-	if err != nil {
+func (self *Call) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I32Load8s) String() string {
-	return "i32.load8_s"
-}
-
-func (self *I32Load8s) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x2c}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+func (self *Call) printInstrBody(p *printer) {
+	self.Index.print(p)
 
 }
 
-type I32Load8u struct {
-	MemArg MemArg
+type CallIndirect struct {
+	Impl CallIndirectInner
 }
 
-func (self *I32Load8u) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
+func (self *CallIndirect) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Impl.Parse(ps)
 	if err != nil {
 		return err
 	}
@@ -685,47 +633,36 @@ func (self *I32Load8u) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I32Load8u) String() string {
-	return "i32.load8_u"
+func (self *CallIndirect) String() string {
+	return "call_indirect"
 }
 
-func (self *I32Load8u) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x2d}
+func (self *CallIndirect) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x11}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	self.Impl.Encode(sink)
 
-type I32Load16s struct {
-	MemArg MemArg
 }
 
-func (self *I32Load16s) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
-	if err != nil {
+func (self *CallIndirect) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Impl.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I32Load16s) String() string {
-	return "i32.load16_s"
-}
-
-func (self *I32Load16s) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x2e}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+func (self *CallIndirect) printInstrBody(p *printer) {
+	self.Impl.print(p)
 
 }
 
-type I32Load16u struct {
-	MemArg MemArg
+type ReturnCall struct {
+	Index Index
 }
 
-func (self *I32Load16u) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
+func (self *ReturnCall) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Index.Parse(ps)
 	if err != nil {
 		return err
 	}
@@ -733,47 +670,36 @@ func (self *I32Load16u) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I32Load16u) String() string {
-	return "i32.load16_u"
+func (self *ReturnCall) String() string {
+	return "return_call"
 }
 
-func (self *I32Load16u) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x2f}
+func (self *ReturnCall) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x12}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	self.Index.Encode(sink)
 
-type I64Load8s struct {
-	MemArg MemArg
 }
 
-func (self *I64Load8s) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
-	if err != nil {
+func (self *ReturnCall) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I64Load8s) String() string {
-	return "i64.load8_s"
-}
-
-func (self *I64Load8s) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x30}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+func (self *ReturnCall) printInstrBody(p *printer) {
+	self.Index.print(p)
 
 }
 
-type I64Load8u struct {
-	MemArg MemArg
+type ReturnCallIndirect struct {
+	Impl CallIndirectInner
 }
 
-func (self *I64Load8u) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
+func (self *ReturnCallIndirect) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Impl.Parse(ps)
 	if err != nil {
 		return err
 	}
@@ -781,71 +707,63 @@ func (self *I64Load8u) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I64Load8u) String() string {
-	return "i64.load8_u"
+func (self *ReturnCallIndirect) String() string {
+	return "return_call_indirect"
 }
 
-func (self *I64Load8u) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x31}
+func (self *ReturnCallIndirect) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x13}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	self.Impl.Encode(sink)
 
-type I64Load16s struct {
-	MemArg MemArg
 }
 
-func (self *I64Load16s) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
-	if err != nil {
+func (self *ReturnCallIndirect) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Impl.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I64Load16s) String() string {
-	return "i64.load16_s"
-}
-
-func (self *I64Load16s) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x32}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+func (self *ReturnCallIndirect) printInstrBody(p *printer) {
+	self.Impl.print(p)
 
 }
 
-type I64Load16u struct {
-	MemArg MemArg
+type Drop struct {
 }
 
-func (self *I64Load16u) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
-	if err != nil {
-		return err
-	}
+func (self *Drop) parseInstrBody(ps *parser.ParserBuffer) error {
 
 	return nil
 }
 
-func (self *I64Load16u) String() string {
-	return "i64.load16_u"
+func (self *Drop) String() string {
+	return "drop"
 }
 
-func (self *I64Load16u) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x33}
+func (self *Drop) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x1a}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
 
 }
 
-type I64Load32s struct {
-	MemArg MemArg
+func (self *Drop) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
 }
 
-func (self *I64Load32s) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
+func (self *Drop) printInstrBody(p *printer) {
+
+}
+
+type Select struct {
+	SelectTypes SelectTypes
+}
+
+func (self *Select) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.SelectTypes.Parse(ps)
 	if err != nil {
 		return err
 	}
@@ -853,47 +771,36 @@ func (self *I64Load32s) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I64Load32s) String() string {
-	return "i64.load32_s"
+func (self *Select) String() string {
+	return "select"
 }
 
-func (self *I64Load32s) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x34}
+func (self *Select) Encode(sink *ZeroCopySink) {
+	inst := []byte{}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	self.SelectTypes.Encode(sink)
 
-type I64Load32u struct {
-	MemArg MemArg
 }
 
-func (self *I64Load32u) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
-	if err != nil {
+func (self *Select) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.SelectTypes.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I64Load32u) String() string {
-	return "i64.load32_u"
-}
-
-func (self *I64Load32u) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x35}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+func (self *Select) printInstrBody(p *printer) {
+	self.SelectTypes.print(p)
 
 }
 
-type I32Store struct {
-	MemArg MemArg
+type LocalGet struct {
+	Index Index
 }
 
-func (self *I32Store) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
+func (self *LocalGet) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Index.Parse(ps)
 	if err != nil {
 		return err
 	}
@@ -901,47 +808,36 @@ func (self *I32Store) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I32Store) String() string {
-	return "i32.store"
+func (self *LocalGet) String() string {
+	return "local.get"
 }
 
-func (self *I32Store) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x36}
+func (self *LocalGet) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x20}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	self.Index.Encode(sink)
 
-type I64Store struct {
-	MemArg MemArg
 }
 
-func (self *I64Store) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 8)
-	if err != nil {
+func (self *LocalGet) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I64Store) String() string {
-	return "i64.store"
-}
-
-func (self *I64Store) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x37}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+func (self *LocalGet) printInstrBody(p *printer) {
+	self.Index.print(p)
 
 }
 
-type F32Store struct {
-	MemArg MemArg
+type LocalSet struct {
+	Index Index
 }
 
-func (self *F32Store) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
+func (self *LocalSet) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Index.Parse(ps)
 	if err != nil {
 		return err
 	}
@@ -949,47 +845,36 @@ func (self *F32Store) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *F32Store) String() string {
-	return "f32.store"
+func (self *LocalSet) String() string {
+	return "local.set"
 }
 
-func (self *F32Store) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x38}
+func (self *LocalSet) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x21}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	self.Index.Encode(sink)
 
-type F64Store struct {
-	MemArg MemArg
 }
 
-func (self *F64Store) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 8)
-	if err != nil {
+func (self *LocalSet) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *F64Store) String() string {
-	return "f64.store"
-}
-
-func (self *F64Store) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x39}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+func (self *LocalSet) printInstrBody(p *printer) {
+	self.Index.print(p)
 
 }
 
-type I32Store8 struct {
-	MemArg MemArg
+type LocalTee struct {
+	Index Index
 }
 
-func (self *I32Store8) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
+func (self *LocalTee) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Index.Parse(ps)
 	if err != nil {
 		return err
 	}
@@ -997,47 +882,36 @@ func (self *I32Store8) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I32Store8) String() string {
-	return "i32.store8"
+func (self *LocalTee) String() string {
+	return "local.tee"
 }
 
-func (self *I32Store8) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x3a}
+func (self *LocalTee) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x22}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	self.Index.Encode(sink)
 
-type I32Store16 struct {
-	MemArg MemArg
 }
 
-func (self *I32Store16) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
-	if err != nil {
+func (self *LocalTee) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I32Store16) String() string {
-	return "i32.store16"
-}
-
-func (self *I32Store16) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x3b}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+func (self *LocalTee) printInstrBody(p *printer) {
+	self.Index.print(p)
 
 }
 
-type I64Store8 struct {
-	MemArg MemArg
+type GlobalGet struct {
+	Index Index
 }
 
-func (self *I64Store8) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
+func (self *GlobalGet) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Index.Parse(ps)
 	if err != nil {
 		return err
 	}
@@ -1045,47 +919,36 @@ func (self *I64Store8) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I64Store8) String() string {
-	return "i64.store8"
+func (self *GlobalGet) String() string {
+	return "global.get"
 }
 
-func (self *I64Store8) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x3c}
+func (self *GlobalGet) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x23}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	self.Index.Encode(sink)
 
-type I64Store16 struct {
-	MemArg MemArg
 }
 
-func (self *I64Store16) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
-	if err != nil {
+func (self *GlobalGet) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I64Store16) String() string {
-	return "i64.store16"
-}
-
-func (self *I64Store16) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x3d}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+func (self *GlobalGet) printInstrBody(p *printer) {
+	self.Index.print(p)
 
 }
 
-type I64Store32 struct {
-	MemArg MemArg
+type GlobalSet struct {
+	Index Index
 }
 
-func (self *I64Store32) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
+func (self *GlobalSet) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Index.Parse(ps)
 	if err != nil {
 		return err
 	}
@@ -1093,119 +956,104 @@ func (self *I64Store32) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I64Store32) String() string {
-	return "i64.store32"
+func (self *GlobalSet) String() string {
+	return "global.set"
 }
 
-func (self *I64Store32) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x3e}
+func (self *GlobalSet) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x24}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	self.Index.Encode(sink)
 
-type MemorySize struct {
 }
 
-func (self *MemorySize) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *GlobalSet) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *MemorySize) String() string {
-	return "memory.size"
-}
-
-func (self *MemorySize) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x3f, 0x0}
-	sink.WriteBytes(inst)
+func (self *GlobalSet) printInstrBody(p *printer) {
+	self.Index.print(p)
 
 }
 
-type MemoryGrow struct {
+type TableGet struct {
+	Index Index
 }
 
-func (self *MemoryGrow) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *TableGet) parseInstrBody(ps *parser.ParserBuffer) error {
+	self.Index = parseDefaultIndex(ps)
 
 	return nil
 }
 
-func (self *MemoryGrow) String() string {
-	return "memory.grow"
+func (self *TableGet) String() string {
+	return "table.get"
 }
 
-func (self *MemoryGrow) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x40, 0x0}
+func (self *TableGet) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x25}
 	sink.WriteBytes(inst)
+	self.Index.Encode(sink)
 
 }
 
-type MemoryCopy struct {
-}
-
-func (self *MemoryCopy) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *TableGet) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *MemoryCopy) String() string {
-	return "memory.copy"
-}
-
-func (self *MemoryCopy) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfc, 0xa, 0x0, 0x0}
-	sink.WriteBytes(inst)
+func (self *TableGet) printInstrBody(p *printer) {
+	printDefaultIndex(p, self.Index)
 
 }
 
-type MemoryFill struct {
+type TableSet struct {
+	Index Index
 }
 
-func (self *MemoryFill) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *TableSet) parseInstrBody(ps *parser.ParserBuffer) error {
+	self.Index = parseDefaultIndex(ps)
 
 	return nil
 }
 
-func (self *MemoryFill) String() string {
-	return "memory.fill"
+func (self *TableSet) String() string {
+	return "table.set"
 }
 
-func (self *MemoryFill) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfc, 0xb, 0x0}
+func (self *TableSet) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x26}
 	sink.WriteBytes(inst)
+	self.Index.Encode(sink)
 
 }
 
-type DataDrop struct {
-	Index Index
-}
-
-func (self *DataDrop) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Index.Parse(ps)
-	if err != nil {
+func (self *TableSet) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *DataDrop) String() string {
-	return "data.drop"
-}
-
-func (self *DataDrop) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfc, 0x9}
-	sink.WriteBytes(inst)
-	self.Index.Encode(sink)
+func (self *TableSet) printInstrBody(p *printer) {
+	printDefaultIndex(p, self.Index)
 
 }
 
-type ElemDrop struct {
-	Index Index
+type I32Load struct {
+	MemArg MemArg
 }
 
-func (self *ElemDrop) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Index.Parse(ps)
+func (self *I32Load) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
 	if err != nil {
 		return err
 	}
@@ -1213,41 +1061,40 @@ func (self *ElemDrop) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *ElemDrop) String() string {
-	return "elem.drop"
+func (self *I32Load) String() string {
+	return "i32.load"
 }
 
-func (self *ElemDrop) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfc, 0xd}
+func (self *I32Load) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x28}
 	sink.WriteBytes(inst)
-	self.Index.Encode(sink)
-
-}
+	self.MemArg.Encode(sink)
 
-type TableCopy struct {
 }
 
-func (self *TableCopy) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32Load) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *TableCopy) String() string {
-	return "table.copy"
-}
+func (self *I32Load) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *TableCopy) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfc, 0xe, 0x0, 0x0}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32Load) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type TableFill struct {
-	Index Index
+type I64Load struct {
+	MemArg MemArg
 }
 
-func (self *TableFill) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Index.Parse(ps)
+func (self *I64Load) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 8)
 	if err != nil {
 		return err
 	}
@@ -1255,47 +1102,40 @@ func (self *TableFill) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *TableFill) String() string {
-	return "table.fill"
+func (self *I64Load) String() string {
+	return "i64.load"
 }
 
-func (self *TableFill) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfc, 0x11}
+func (self *I64Load) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x29}
 	sink.WriteBytes(inst)
-	self.Index.Encode(sink)
-
-}
+	self.MemArg.Encode(sink)
 
-type TableSize struct {
-	Index Index
 }
 
-func (self *TableSize) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Index.Parse(ps)
-	if err != nil {
+func (self *I64Load) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *TableSize) String() string {
-	return "table.size"
-}
+func (self *I64Load) printInstrBody(p *printer) {
+	self.MemArg.print(p, 8)
 
-func (self *TableSize) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfc, 0x10}
-	sink.WriteBytes(inst)
-	self.Index.Encode(sink)
+}
 
+func (self *I64Load) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 8
 }
 
-type TableGrow struct {
-	Index Index
+type F32Load struct {
+	MemArg MemArg
 }
 
-func (self *TableGrow) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Index.Parse(ps)
+func (self *F32Load) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
 	if err != nil {
 		return err
 	}
@@ -1303,84 +1143,81 @@ func (self *TableGrow) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *TableGrow) String() string {
-	return "table.grow"
+func (self *F32Load) String() string {
+	return "f32.load"
 }
 
-func (self *TableGrow) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfc, 0xf}
+func (self *F32Load) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x2a}
 	sink.WriteBytes(inst)
-	self.Index.Encode(sink)
-
-}
+	self.MemArg.Encode(sink)
 
-type RefNull struct {
 }
 
-func (self *RefNull) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *F32Load) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *RefNull) String() string {
-	return "ref.null"
-}
+func (self *F32Load) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *RefNull) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xd0}
-	sink.WriteBytes(inst)
+}
 
+func (self *F32Load) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type RefIsNull struct {
+type F64Load struct {
+	MemArg MemArg
 }
 
-func (self *RefIsNull) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *F64Load) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 8)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *RefIsNull) String() string {
-	return "ref.is_null"
+func (self *F64Load) String() string {
+	return "f64.load"
 }
 
-func (self *RefIsNull) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xd1}
+func (self *F64Load) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x2b}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type RefHost struct {
-	Val uint32
-}
-
-func (self *RefHost) parseInstrBody(ps *parser.ParserBuffer) error {
-	val, err := ps.ExpectUint32()
-	if err != nil {
+func (self *F64Load) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
-	self.Val = val
 
 	return nil
 }
 
-func (self *RefHost) String() string {
-	return "ref.host"
-}
+func (self *F64Load) printInstrBody(p *printer) {
+	self.MemArg.print(p, 8)
 
-func (self *RefHost) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xff}
-	sink.WriteBytes(inst)
-	sink.WriteInt32(self.Val)
+}
 
+func (self *F64Load) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 8
 }
 
-type RefFunc struct {
-	Index Index
+type I32Load8s struct {
+	MemArg MemArg
 }
 
-func (self *RefFunc) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Index.Parse(ps)
+func (self *I32Load8s) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
 	if err != nil {
 		return err
 	}
@@ -1388,97 +1225,81 @@ func (self *RefFunc) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *RefFunc) String() string {
-	return "ref.func"
+func (self *I32Load8s) String() string {
+	return "i32.load8_s"
 }
 
-func (self *RefFunc) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xd2}
+func (self *I32Load8s) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x2c}
 	sink.WriteBytes(inst)
-	self.Index.Encode(sink)
-
-}
+	self.MemArg.Encode(sink)
 
-type I32Const struct {
-	Val uint32
 }
 
-func (self *I32Const) parseInstrBody(ps *parser.ParserBuffer) error {
-	val, err := ps.ExpectUint32()
-	if err != nil {
+func (self *I32Load8s) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
-	self.Val = val
 
 	return nil
 }
 
-func (self *I32Const) String() string {
-	return "i32.const"
-}
+func (self *I32Load8s) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *I32Const) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x41}
-	sink.WriteBytes(inst)
-	sink.WriteInt32(self.Val)
+}
 
+func (self *I32Load8s) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type I64Const struct {
-	Val int64
+type I32Load8u struct {
+	MemArg MemArg
 }
 
-func (self *I64Const) parseInstrBody(ps *parser.ParserBuffer) error {
-	val, err := ps.ExpectInt64()
+func (self *I32Load8u) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
 	if err != nil {
 		return err
 	}
-	self.Val = val
 
 	return nil
 }
 
-func (self *I64Const) String() string {
-	return "i64.const"
+func (self *I32Load8u) String() string {
+	return "i32.load8_u"
 }
 
-func (self *I64Const) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x42}
+func (self *I32Load8u) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x2d}
 	sink.WriteBytes(inst)
-	sink.WriteInt64(self.Val)
-
-}
+	self.MemArg.Encode(sink)
 
-type F32Const struct {
-	Val Float32
 }
 
-func (self *F32Const) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Val.Parse(ps)
-	if err != nil {
+func (self *I32Load8u) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *F32Const) String() string {
-	return "f32.const"
-}
+func (self *I32Load8u) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *F32Const) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x43}
-	sink.WriteBytes(inst)
-	self.Val.Encode(sink)
+}
 
+func (self *I32Load8u) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type F64Const struct {
-	Val Float64
+type I32Load16s struct {
+	MemArg MemArg
 }
 
-func (self *F64Const) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.Val.Parse(ps)
+func (self *I32Load16s) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
 	if err != nil {
 		return err
 	}
@@ -1486,1035 +1307,2933 @@ func (self *F64Const) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *F64Const) String() string {
-	return "f64.const"
+func (self *I32Load16s) String() string {
+	return "i32.load16_s"
 }
 
-func (self *F64Const) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x44}
+func (self *I32Load16s) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x2e}
 	sink.WriteBytes(inst)
-	self.Val.Encode(sink)
-
-}
+	self.MemArg.Encode(sink)
 
-type I32Clz struct {
 }
 
-func (self *I32Clz) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32Load16s) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32Clz) String() string {
-	return "i32.clz"
-}
+func (self *I32Load16s) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *I32Clz) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x67}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32Load16s) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type I32Ctz struct {
+type I32Load16u struct {
+	MemArg MemArg
 }
 
-func (self *I32Ctz) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32Load16u) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32Ctz) String() string {
-	return "i32.ctz"
+func (self *I32Load16u) String() string {
+	return "i32.load16_u"
 }
 
-func (self *I32Ctz) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x68}
+func (self *I32Load16u) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x2f}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32Pocnt struct {
-}
-
-func (self *I32Pocnt) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32Load16u) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32Pocnt) String() string {
-	return "i32.popcnt"
-}
+func (self *I32Load16u) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *I32Pocnt) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x69}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32Load16u) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type I32Add struct {
+type I64Load8s struct {
+	MemArg MemArg
 }
 
-func (self *I32Add) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Load8s) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32Add) String() string {
-	return "i32.add"
+func (self *I64Load8s) String() string {
+	return "i64.load8_s"
 }
 
-func (self *I32Add) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x6a}
-	sink.WriteBytes(inst)
-
-}
+func (self *I64Load8s) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x30}
+	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
-type I32Sub struct {
 }
 
-func (self *I32Sub) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Load8s) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32Sub) String() string {
-	return "i32.sub"
-}
+func (self *I64Load8s) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *I32Sub) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x6b}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64Load8s) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type I32Mul struct {
+type I64Load8u struct {
+	MemArg MemArg
 }
 
-func (self *I32Mul) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Load8u) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32Mul) String() string {
-	return "i32.mul"
+func (self *I64Load8u) String() string {
+	return "i64.load8_u"
 }
 
-func (self *I32Mul) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x6c}
+func (self *I64Load8u) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x31}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32DivS struct {
-}
-
-func (self *I32DivS) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Load8u) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32DivS) String() string {
-	return "i32.div_s"
-}
+func (self *I64Load8u) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *I32DivS) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x6d}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64Load8u) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type I32DivU struct {
+type I64Load16s struct {
+	MemArg MemArg
 }
 
-func (self *I32DivU) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Load16s) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32DivU) String() string {
-	return "i32.div_u"
+func (self *I64Load16s) String() string {
+	return "i64.load16_s"
 }
 
-func (self *I32DivU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x6e}
+func (self *I64Load16s) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x32}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32RemS struct {
-}
-
-func (self *I32RemS) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Load16s) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32RemS) String() string {
-	return "i32.rem_s"
-}
+func (self *I64Load16s) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *I32RemS) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x6f}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64Load16s) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type I32RemU struct {
+type I64Load16u struct {
+	MemArg MemArg
 }
 
-func (self *I32RemU) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Load16u) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32RemU) String() string {
-	return "i32.rem_u"
+func (self *I64Load16u) String() string {
+	return "i64.load16_u"
 }
 
-func (self *I32RemU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x70}
+func (self *I64Load16u) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x33}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32And struct {
-}
-
-func (self *I32And) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Load16u) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32And) String() string {
-	return "i32.and"
-}
+func (self *I64Load16u) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *I32And) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x71}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64Load16u) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type I32Or struct {
+type I64Load32s struct {
+	MemArg MemArg
 }
 
-func (self *I32Or) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Load32s) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32Or) String() string {
-	return "i32.or"
+func (self *I64Load32s) String() string {
+	return "i64.load32_s"
 }
 
-func (self *I32Or) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x72}
+func (self *I64Load32s) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x34}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32Xor struct {
-}
-
-func (self *I32Xor) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Load32s) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32Xor) String() string {
-	return "i32.xor"
-}
+func (self *I64Load32s) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *I32Xor) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x73}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64Load32s) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type I32Shl struct {
+type I64Load32u struct {
+	MemArg MemArg
 }
 
-func (self *I32Shl) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Load32u) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32Shl) String() string {
-	return "i32.shl"
+func (self *I64Load32u) String() string {
+	return "i64.load32_u"
 }
 
-func (self *I32Shl) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x74}
+func (self *I64Load32u) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x35}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32ShrS struct {
-}
-
-func (self *I32ShrS) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Load32u) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32ShrS) String() string {
-	return "i32.shr_s"
-}
+func (self *I64Load32u) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *I32ShrS) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x75}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64Load32u) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type I32ShrU struct {
+type I32Store struct {
+	MemArg MemArg
 }
 
-func (self *I32ShrU) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32Store) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32ShrU) String() string {
-	return "i32.shr_u"
+func (self *I32Store) String() string {
+	return "i32.store"
 }
 
-func (self *I32ShrU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x76}
+func (self *I32Store) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x36}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32Rotl struct {
-}
-
-func (self *I32Rotl) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32Store) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32Rotl) String() string {
-	return "i32.rotl"
-}
+func (self *I32Store) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *I32Rotl) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x77}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32Store) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type I32Rotr struct {
+type I64Store struct {
+	MemArg MemArg
 }
 
-func (self *I32Rotr) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Store) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 8)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32Rotr) String() string {
-	return "i32.rotr"
+func (self *I64Store) String() string {
+	return "i64.store"
 }
 
-func (self *I32Rotr) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x78}
+func (self *I64Store) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x37}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64Clz struct {
-}
-
-func (self *I64Clz) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Store) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64Clz) String() string {
-	return "i64.clz"
-}
+func (self *I64Store) printInstrBody(p *printer) {
+	self.MemArg.print(p, 8)
 
-func (self *I64Clz) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x79}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64Store) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 8
 }
 
-type I64Ctz struct {
+type F32Store struct {
+	MemArg MemArg
 }
 
-func (self *I64Ctz) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *F32Store) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64Ctz) String() string {
-	return "i64.ctz"
+func (self *F32Store) String() string {
+	return "f32.store"
 }
 
-func (self *I64Ctz) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x7a}
+func (self *F32Store) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x38}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64Popcnt struct {
-}
-
-func (self *I64Popcnt) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *F32Store) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64Popcnt) String() string {
-	return "i64.popcnt"
-}
+func (self *F32Store) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *I64Popcnt) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x7b}
-	sink.WriteBytes(inst)
+}
 
+func (self *F32Store) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type I64Add struct {
+type F64Store struct {
+	MemArg MemArg
 }
 
-func (self *I64Add) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *F64Store) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 8)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64Add) String() string {
-	return "i64.add"
+func (self *F64Store) String() string {
+	return "f64.store"
 }
 
-func (self *I64Add) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x7c}
+func (self *F64Store) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x39}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64Sub struct {
-}
-
-func (self *I64Sub) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *F64Store) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64Sub) String() string {
-	return "i64.sub"
-}
+func (self *F64Store) printInstrBody(p *printer) {
+	self.MemArg.print(p, 8)
 
-func (self *I64Sub) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x7d}
-	sink.WriteBytes(inst)
+}
 
+func (self *F64Store) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 8
 }
 
-type I64Mul struct {
+type I32Store8 struct {
+	MemArg MemArg
 }
 
-func (self *I64Mul) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32Store8) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64Mul) String() string {
-	return "i64.mul"
+func (self *I32Store8) String() string {
+	return "i32.store8"
 }
 
-func (self *I64Mul) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x7e}
+func (self *I32Store8) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x3a}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64DivS struct {
-}
-
-func (self *I64DivS) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32Store8) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64DivS) String() string {
-	return "i64.div_s"
-}
+func (self *I32Store8) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *I64DivS) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x7f}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32Store8) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type I64DivU struct {
+type I32Store16 struct {
+	MemArg MemArg
 }
 
-func (self *I64DivU) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32Store16) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64DivU) String() string {
-	return "i64.div_u"
+func (self *I32Store16) String() string {
+	return "i32.store16"
 }
 
-func (self *I64DivU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x80}
+func (self *I32Store16) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x3b}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64RemS struct {
-}
-
-func (self *I64RemS) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32Store16) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64RemS) String() string {
-	return "i64.rem_s"
-}
+func (self *I32Store16) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *I64RemS) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x81}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32Store16) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type I64RemU struct {
+type I64Store8 struct {
+	MemArg MemArg
 }
 
-func (self *I64RemU) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Store8) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64RemU) String() string {
-	return "i64.rem_u"
+func (self *I64Store8) String() string {
+	return "i64.store8"
 }
 
-func (self *I64RemU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x82}
+func (self *I64Store8) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x3c}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64And struct {
-}
-
-func (self *I64And) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Store8) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64And) String() string {
-	return "i64.and"
-}
+func (self *I64Store8) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *I64And) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x83}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64Store8) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type I64Or struct {
+type I64Store16 struct {
+	MemArg MemArg
 }
 
-func (self *I64Or) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Store16) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64Or) String() string {
-	return "i64.or"
+func (self *I64Store16) String() string {
+	return "i64.store16"
 }
 
-func (self *I64Or) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x84}
+func (self *I64Store16) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x3d}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64Xor struct {
-}
-
-func (self *I64Xor) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Store16) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64Xor) String() string {
-	return "i64.xor"
-}
+func (self *I64Store16) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *I64Xor) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x85}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64Store16) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type I64Shl struct {
+type I64Store32 struct {
+	MemArg MemArg
 }
 
-func (self *I64Shl) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Store32) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64Shl) String() string {
-	return "i64.shl"
+func (self *I64Store32) String() string {
+	return "i64.store32"
 }
 
-func (self *I64Shl) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x86}
+func (self *I64Store32) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x3e}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64ShrS struct {
-}
-
-func (self *I64ShrS) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64Store32) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64ShrS) String() string {
-	return "i64.shr_s"
-}
+func (self *I64Store32) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *I64ShrS) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x87}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64Store32) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type I64ShrU struct {
+type MemorySize struct {
+	Mem Index
 }
 
-func (self *I64ShrU) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *MemorySize) parseInstrBody(ps *parser.ParserBuffer) error {
+	self.Mem = parseDefaultIndex(ps)
 
 	return nil
 }
 
-func (self *I64ShrU) String() string {
-	return "i64.shr_u"
+func (self *MemorySize) String() string {
+	return "memory.size"
 }
 
-func (self *I64ShrU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x88}
+func (self *MemorySize) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x3f}
 	sink.WriteBytes(inst)
+	encodeReservedIndex(sink, self.Mem)
 
 }
 
-type I64Rotl struct {
-}
-
-func (self *I64Rotl) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *MemorySize) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Mem.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64Rotl) String() string {
-	return "i64.rotl"
-}
-
-func (self *I64Rotl) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x89}
-	sink.WriteBytes(inst)
+func (self *MemorySize) printInstrBody(p *printer) {
+	printDefaultIndex(p, self.Mem)
 
 }
 
-type I64Rotr struct {
+type MemoryGrow struct {
+	Mem Index
 }
 
-func (self *I64Rotr) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *MemoryGrow) parseInstrBody(ps *parser.ParserBuffer) error {
+	self.Mem = parseDefaultIndex(ps)
 
 	return nil
 }
 
-func (self *I64Rotr) String() string {
-	return "i64.rotr"
+func (self *MemoryGrow) String() string {
+	return "memory.grow"
 }
 
-func (self *I64Rotr) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x8a}
+func (self *MemoryGrow) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x40}
 	sink.WriteBytes(inst)
+	encodeReservedIndex(sink, self.Mem)
 
 }
 
-type F32Abs struct {
-}
-
-func (self *F32Abs) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *MemoryGrow) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Mem.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Abs) String() string {
-	return "f32.abs"
-}
-
-func (self *F32Abs) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x8b}
-	sink.WriteBytes(inst)
+func (self *MemoryGrow) printInstrBody(p *printer) {
+	printDefaultIndex(p, self.Mem)
 
 }
 
-type F32Neg struct {
+type MemoryInit struct {
+	Impl MemoryInitInner
 }
 
-func (self *F32Neg) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *MemoryInit) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Impl.Parse(ps)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Neg) String() string {
-	return "f32.neg"
+func (self *MemoryInit) String() string {
+	return "memory.init"
 }
 
-func (self *F32Neg) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x8c}
+func (self *MemoryInit) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0x8}
 	sink.WriteBytes(inst)
+	self.Impl.Encode(sink)
 
 }
 
-type F32Ceil struct {
-}
-
-func (self *F32Ceil) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *MemoryInit) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Impl.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Ceil) String() string {
-	return "f32.ceil"
-}
-
-func (self *F32Ceil) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x8d}
-	sink.WriteBytes(inst)
+func (self *MemoryInit) printInstrBody(p *printer) {
+	self.Impl.print(p)
 
 }
 
-type F32Floor struct {
+type MemoryCopy struct {
+	Impl MemoryCopyInner
 }
 
-func (self *F32Floor) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *MemoryCopy) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Impl.Parse(ps)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Floor) String() string {
-	return "f32.floor"
+func (self *MemoryCopy) String() string {
+	return "memory.copy"
 }
 
-func (self *F32Floor) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x8e}
+func (self *MemoryCopy) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0xa}
 	sink.WriteBytes(inst)
+	self.Impl.Encode(sink)
 
 }
 
-type F32Trunc struct {
-}
-
-func (self *F32Trunc) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *MemoryCopy) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Impl.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Trunc) String() string {
-	return "f32.trunc"
-}
-
-func (self *F32Trunc) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x8f}
-	sink.WriteBytes(inst)
+func (self *MemoryCopy) printInstrBody(p *printer) {
+	self.Impl.print(p)
 
 }
 
-type F32Nearest struct {
+type MemoryFill struct {
+	Mem Index
 }
 
-func (self *F32Nearest) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *MemoryFill) parseInstrBody(ps *parser.ParserBuffer) error {
+	self.Mem = parseDefaultIndex(ps)
 
 	return nil
 }
 
-func (self *F32Nearest) String() string {
-	return "f32.nearest"
+func (self *MemoryFill) String() string {
+	return "memory.fill"
 }
 
-func (self *F32Nearest) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x90}
+func (self *MemoryFill) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0xb}
 	sink.WriteBytes(inst)
+	encodeReservedIndex(sink, self.Mem)
 
 }
 
-type F32Sqrt struct {
-}
-
-func (self *F32Sqrt) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *MemoryFill) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Mem.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Sqrt) String() string {
-	return "f32.sqrt"
-}
-
-func (self *F32Sqrt) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x91}
-	sink.WriteBytes(inst)
+func (self *MemoryFill) printInstrBody(p *printer) {
+	printDefaultIndex(p, self.Mem)
 
 }
 
-type F32Add struct {
+type DataDrop struct {
+	Index Index
 }
 
-func (self *F32Add) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *DataDrop) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Index.Parse(ps)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Add) String() string {
-	return "f32.add"
+func (self *DataDrop) String() string {
+	return "data.drop"
 }
 
-func (self *F32Add) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x92}
+func (self *DataDrop) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0x9}
 	sink.WriteBytes(inst)
+	self.Index.Encode(sink)
 
 }
 
-type F32Sub struct {
-}
-
-func (self *F32Sub) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *DataDrop) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Sub) String() string {
-	return "f32.sub"
-}
-
-func (self *F32Sub) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x93}
-	sink.WriteBytes(inst)
+func (self *DataDrop) printInstrBody(p *printer) {
+	self.Index.print(p)
 
 }
 
-type F32Mul struct {
+type ElemDrop struct {
+	Index Index
 }
 
-func (self *F32Mul) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *ElemDrop) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Index.Parse(ps)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Mul) String() string {
-	return "f32.mul"
+func (self *ElemDrop) String() string {
+	return "elem.drop"
 }
 
-func (self *F32Mul) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x94}
+func (self *ElemDrop) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0xd}
 	sink.WriteBytes(inst)
+	self.Index.Encode(sink)
 
 }
 
-type F32Div struct {
-}
-
-func (self *F32Div) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *ElemDrop) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Div) String() string {
-	return "f32.div"
-}
-
-func (self *F32Div) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x95}
-	sink.WriteBytes(inst)
+func (self *ElemDrop) printInstrBody(p *printer) {
+	self.Index.print(p)
 
 }
 
-type F32Min struct {
+type TableInit struct {
+	Impl TableInitInner
 }
 
-func (self *F32Min) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *TableInit) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Impl.Parse(ps)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Min) String() string {
-	return "f32.min"
+func (self *TableInit) String() string {
+	return "table.init"
 }
 
-func (self *F32Min) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x96}
+func (self *TableInit) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0xc}
 	sink.WriteBytes(inst)
+	self.Impl.Encode(sink)
 
 }
 
-type F32Max struct {
-}
-
-func (self *F32Max) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *TableInit) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Impl.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Max) String() string {
-	return "f32.max"
-}
-
-func (self *F32Max) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x97}
-	sink.WriteBytes(inst)
+func (self *TableInit) printInstrBody(p *printer) {
+	self.Impl.print(p)
 
 }
 
-type F32Copysign struct {
+type TableCopy struct {
+	Impl TableCopyInner
 }
 
-func (self *F32Copysign) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *TableCopy) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Impl.Parse(ps)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Copysign) String() string {
-	return "f32.copysign"
+func (self *TableCopy) String() string {
+	return "table.copy"
 }
 
-func (self *F32Copysign) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x98}
+func (self *TableCopy) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0xe}
 	sink.WriteBytes(inst)
+	self.Impl.Encode(sink)
 
 }
 
-type F64Abs struct {
-}
-
-func (self *F64Abs) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *TableCopy) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Impl.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Abs) String() string {
-	return "f64.abs"
-}
-
-func (self *F64Abs) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x99}
-	sink.WriteBytes(inst)
+func (self *TableCopy) printInstrBody(p *printer) {
+	self.Impl.print(p)
 
 }
 
-type F64Neg struct {
+type TableFill struct {
+	Index Index
 }
 
-func (self *F64Neg) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *TableFill) parseInstrBody(ps *parser.ParserBuffer) error {
+	self.Index = parseDefaultIndex(ps)
 
 	return nil
 }
 
-func (self *F64Neg) String() string {
-	return "f64.neg"
+func (self *TableFill) String() string {
+	return "table.fill"
 }
 
-func (self *F64Neg) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x9a}
+func (self *TableFill) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0x11}
 	sink.WriteBytes(inst)
+	self.Index.Encode(sink)
 
 }
 
-type F64Ceil struct {
-}
-
-func (self *F64Ceil) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *TableFill) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Ceil) String() string {
-	return "f64.ceil"
-}
-
-func (self *F64Ceil) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x9b}
-	sink.WriteBytes(inst)
+func (self *TableFill) printInstrBody(p *printer) {
+	printDefaultIndex(p, self.Index)
 
 }
 
-type F64Floor struct {
+type TableSize struct {
+	Index Index
 }
 
-func (self *F64Floor) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *TableSize) parseInstrBody(ps *parser.ParserBuffer) error {
+	self.Index = parseDefaultIndex(ps)
 
 	return nil
 }
 
-func (self *F64Floor) String() string {
-	return "f64.floor"
+func (self *TableSize) String() string {
+	return "table.size"
 }
 
-func (self *F64Floor) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x9c}
+func (self *TableSize) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0x10}
 	sink.WriteBytes(inst)
+	self.Index.Encode(sink)
 
 }
 
-type F64Trunc struct {
-}
-
-func (self *F64Trunc) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *TableSize) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Trunc) String() string {
-	return "f64.trunc"
-}
-
-func (self *F64Trunc) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x9d}
-	sink.WriteBytes(inst)
+func (self *TableSize) printInstrBody(p *printer) {
+	printDefaultIndex(p, self.Index)
 
 }
 
-type F64Nearest struct {
+type TableGrow struct {
+	Index Index
 }
 
-func (self *F64Nearest) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *TableGrow) parseInstrBody(ps *parser.ParserBuffer) error {
+	self.Index = parseDefaultIndex(ps)
 
 	return nil
 }
 
-func (self *F64Nearest) String() string {
-	return "f64.nearest"
+func (self *TableGrow) String() string {
+	return "table.grow"
 }
 
-func (self *F64Nearest) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x9e}
+func (self *TableGrow) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0xf}
 	sink.WriteBytes(inst)
+	self.Index.Encode(sink)
 
 }
 
-type F64Sqrt struct {
-}
-
-func (self *F64Sqrt) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *TableGrow) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Sqrt) String() string {
-	return "f64.sqrt"
+func (self *TableGrow) printInstrBody(p *printer) {
+	printDefaultIndex(p, self.Index)
+
+}
+
+type RefNull struct {
+	Type HeapType
+}
+
+func (self *RefNull) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Type.Parse(ps)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *RefNull) String() string {
+	return "ref.null"
+}
+
+func (self *RefNull) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xd0}
+	sink.WriteBytes(inst)
+	self.Type.Encode(sink)
+
+}
+
+func (self *RefNull) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Type.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *RefNull) printInstrBody(p *printer) {
+	self.Type.print(p)
+
+}
+
+type RefIsNull struct {
+}
+
+func (self *RefIsNull) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *RefIsNull) String() string {
+	return "ref.is_null"
+}
+
+func (self *RefIsNull) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xd1}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *RefIsNull) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *RefIsNull) printInstrBody(p *printer) {
+
+}
+
+type RefHost struct {
+	Val uint32
+}
+
+func (self *RefHost) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectI32()
+	if err != nil {
+		return err
+	}
+	self.Val = val
+
+	return nil
+}
+
+func (self *RefHost) String() string {
+	return "ref.host"
+}
+
+func (self *RefHost) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xff}
+	sink.WriteBytes(inst)
+	sink.WriteInt32(self.Val)
+
+}
+
+func (self *RefHost) decodeInstrBody(source *ZeroCopySource) error {
+	val, err := source.ReadInt32()
+	if err != nil {
+		return err
+	}
+	self.Val = uint32(val)
+
+	return nil
+}
+
+func (self *RefHost) printInstrBody(p *printer) {
+	p.word(strconv.Itoa(int(int32(self.Val))))
+
+}
+
+type RefFunc struct {
+	Index Index
+}
+
+func (self *RefFunc) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Index.Parse(ps)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *RefFunc) String() string {
+	return "ref.func"
+}
+
+func (self *RefFunc) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xd2}
+	sink.WriteBytes(inst)
+	self.Index.Encode(sink)
+
+}
+
+func (self *RefFunc) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Index.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *RefFunc) printInstrBody(p *printer) {
+	self.Index.print(p)
+
+}
+
+type I32Const struct {
+	Val uint32
+}
+
+func (self *I32Const) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectI32()
+	if err != nil {
+		return err
+	}
+	self.Val = val
+
+	return nil
+}
+
+func (self *I32Const) String() string {
+	return "i32.const"
+}
+
+func (self *I32Const) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x41}
+	sink.WriteBytes(inst)
+	sink.WriteInt32(self.Val)
+
+}
+
+func (self *I32Const) decodeInstrBody(source *ZeroCopySource) error {
+	val, err := source.ReadInt32()
+	if err != nil {
+		return err
+	}
+	self.Val = uint32(val)
+
+	return nil
+}
+
+func (self *I32Const) printInstrBody(p *printer) {
+	p.word(strconv.Itoa(int(int32(self.Val))))
+
+}
+
+type I64Const struct {
+	Val int64
+}
+
+func (self *I64Const) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectInt64()
+	if err != nil {
+		return err
+	}
+	self.Val = val
+
+	return nil
+}
+
+func (self *I64Const) String() string {
+	return "i64.const"
+}
+
+func (self *I64Const) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x42}
+	sink.WriteBytes(inst)
+	sink.WriteInt64(self.Val)
+
+}
+
+func (self *I64Const) decodeInstrBody(source *ZeroCopySource) error {
+	val, err := source.ReadInt64()
+	if err != nil {
+		return err
+	}
+	self.Val = val
+
+	return nil
+}
+
+func (self *I64Const) printInstrBody(p *printer) {
+	p.word(strconv.FormatInt(self.Val, 10))
+
+}
+
+type F32Const struct {
+	Val Float32
+}
+
+func (self *F32Const) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Val.Parse(ps)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *F32Const) String() string {
+	return "f32.const"
+}
+
+func (self *F32Const) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x43}
+	sink.WriteBytes(inst)
+	self.Val.Encode(sink)
+
+}
+
+func (self *F32Const) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Val.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *F32Const) printInstrBody(p *printer) {
+	self.Val.print(p)
+
+}
+
+type F64Const struct {
+	Val Float64
+}
+
+func (self *F64Const) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Val.Parse(ps)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *F64Const) String() string {
+	return "f64.const"
+}
+
+func (self *F64Const) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x44}
+	sink.WriteBytes(inst)
+	self.Val.Encode(sink)
+
+}
+
+func (self *F64Const) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Val.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *F64Const) printInstrBody(p *printer) {
+	self.Val.print(p)
+
+}
+
+type I32Clz struct {
+}
+
+func (self *I32Clz) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32Clz) String() string {
+	return "i32.clz"
+}
+
+func (self *I32Clz) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x67}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32Clz) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32Clz) printInstrBody(p *printer) {
+
+}
+
+type I32Ctz struct {
+}
+
+func (self *I32Ctz) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32Ctz) String() string {
+	return "i32.ctz"
+}
+
+func (self *I32Ctz) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x68}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32Ctz) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32Ctz) printInstrBody(p *printer) {
+
+}
+
+type I32Pocnt struct {
+}
+
+func (self *I32Pocnt) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32Pocnt) String() string {
+	return "i32.popcnt"
+}
+
+func (self *I32Pocnt) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x69}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32Pocnt) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32Pocnt) printInstrBody(p *printer) {
+
+}
+
+type I32Add struct {
+}
+
+func (self *I32Add) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32Add) String() string {
+	return "i32.add"
+}
+
+func (self *I32Add) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x6a}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32Add) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32Add) printInstrBody(p *printer) {
+
+}
+
+type I32Sub struct {
+}
+
+func (self *I32Sub) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32Sub) String() string {
+	return "i32.sub"
+}
+
+func (self *I32Sub) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x6b}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32Sub) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32Sub) printInstrBody(p *printer) {
+
+}
+
+type I32Mul struct {
+}
+
+func (self *I32Mul) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32Mul) String() string {
+	return "i32.mul"
+}
+
+func (self *I32Mul) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x6c}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32Mul) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32Mul) printInstrBody(p *printer) {
+
+}
+
+type I32DivS struct {
+}
+
+func (self *I32DivS) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32DivS) String() string {
+	return "i32.div_s"
+}
+
+func (self *I32DivS) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x6d}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32DivS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32DivS) printInstrBody(p *printer) {
+
+}
+
+type I32DivU struct {
+}
+
+func (self *I32DivU) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32DivU) String() string {
+	return "i32.div_u"
+}
+
+func (self *I32DivU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x6e}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32DivU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32DivU) printInstrBody(p *printer) {
+
+}
+
+type I32RemS struct {
+}
+
+func (self *I32RemS) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32RemS) String() string {
+	return "i32.rem_s"
+}
+
+func (self *I32RemS) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x6f}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32RemS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32RemS) printInstrBody(p *printer) {
+
+}
+
+type I32RemU struct {
+}
+
+func (self *I32RemU) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32RemU) String() string {
+	return "i32.rem_u"
+}
+
+func (self *I32RemU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x70}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32RemU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32RemU) printInstrBody(p *printer) {
+
+}
+
+type I32And struct {
+}
+
+func (self *I32And) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32And) String() string {
+	return "i32.and"
+}
+
+func (self *I32And) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x71}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32And) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32And) printInstrBody(p *printer) {
+
+}
+
+type I32Or struct {
+}
+
+func (self *I32Or) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32Or) String() string {
+	return "i32.or"
+}
+
+func (self *I32Or) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x72}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32Or) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32Or) printInstrBody(p *printer) {
+
+}
+
+type I32Xor struct {
+}
+
+func (self *I32Xor) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32Xor) String() string {
+	return "i32.xor"
+}
+
+func (self *I32Xor) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x73}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32Xor) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32Xor) printInstrBody(p *printer) {
+
+}
+
+type I32Shl struct {
+}
+
+func (self *I32Shl) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32Shl) String() string {
+	return "i32.shl"
+}
+
+func (self *I32Shl) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x74}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32Shl) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32Shl) printInstrBody(p *printer) {
+
+}
+
+type I32ShrS struct {
+}
+
+func (self *I32ShrS) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32ShrS) String() string {
+	return "i32.shr_s"
+}
+
+func (self *I32ShrS) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x75}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32ShrS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32ShrS) printInstrBody(p *printer) {
+
+}
+
+type I32ShrU struct {
+}
+
+func (self *I32ShrU) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32ShrU) String() string {
+	return "i32.shr_u"
+}
+
+func (self *I32ShrU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x76}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32ShrU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32ShrU) printInstrBody(p *printer) {
+
+}
+
+type I32Rotl struct {
+}
+
+func (self *I32Rotl) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32Rotl) String() string {
+	return "i32.rotl"
+}
+
+func (self *I32Rotl) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x77}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32Rotl) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32Rotl) printInstrBody(p *printer) {
+
+}
+
+type I32Rotr struct {
+}
+
+func (self *I32Rotr) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32Rotr) String() string {
+	return "i32.rotr"
+}
+
+func (self *I32Rotr) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x78}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32Rotr) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32Rotr) printInstrBody(p *printer) {
+
+}
+
+type I64Clz struct {
+}
+
+func (self *I64Clz) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Clz) String() string {
+	return "i64.clz"
+}
+
+func (self *I64Clz) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x79}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Clz) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Clz) printInstrBody(p *printer) {
+
+}
+
+type I64Ctz struct {
+}
+
+func (self *I64Ctz) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Ctz) String() string {
+	return "i64.ctz"
+}
+
+func (self *I64Ctz) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x7a}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Ctz) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Ctz) printInstrBody(p *printer) {
+
+}
+
+type I64Popcnt struct {
+}
+
+func (self *I64Popcnt) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Popcnt) String() string {
+	return "i64.popcnt"
+}
+
+func (self *I64Popcnt) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x7b}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Popcnt) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Popcnt) printInstrBody(p *printer) {
+
+}
+
+type I64Add struct {
+}
+
+func (self *I64Add) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Add) String() string {
+	return "i64.add"
+}
+
+func (self *I64Add) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x7c}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Add) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Add) printInstrBody(p *printer) {
+
+}
+
+type I64Sub struct {
+}
+
+func (self *I64Sub) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Sub) String() string {
+	return "i64.sub"
+}
+
+func (self *I64Sub) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x7d}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Sub) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Sub) printInstrBody(p *printer) {
+
+}
+
+type I64Mul struct {
+}
+
+func (self *I64Mul) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Mul) String() string {
+	return "i64.mul"
+}
+
+func (self *I64Mul) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x7e}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Mul) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Mul) printInstrBody(p *printer) {
+
+}
+
+type I64DivS struct {
+}
+
+func (self *I64DivS) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64DivS) String() string {
+	return "i64.div_s"
+}
+
+func (self *I64DivS) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x7f}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64DivS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64DivS) printInstrBody(p *printer) {
+
+}
+
+type I64DivU struct {
+}
+
+func (self *I64DivU) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64DivU) String() string {
+	return "i64.div_u"
+}
+
+func (self *I64DivU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x80}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64DivU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64DivU) printInstrBody(p *printer) {
+
+}
+
+type I64RemS struct {
+}
+
+func (self *I64RemS) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64RemS) String() string {
+	return "i64.rem_s"
+}
+
+func (self *I64RemS) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x81}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64RemS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64RemS) printInstrBody(p *printer) {
+
+}
+
+type I64RemU struct {
+}
+
+func (self *I64RemU) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64RemU) String() string {
+	return "i64.rem_u"
+}
+
+func (self *I64RemU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x82}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64RemU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64RemU) printInstrBody(p *printer) {
+
+}
+
+type I64And struct {
+}
+
+func (self *I64And) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64And) String() string {
+	return "i64.and"
+}
+
+func (self *I64And) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x83}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64And) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64And) printInstrBody(p *printer) {
+
+}
+
+type I64Or struct {
+}
+
+func (self *I64Or) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Or) String() string {
+	return "i64.or"
+}
+
+func (self *I64Or) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x84}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Or) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Or) printInstrBody(p *printer) {
+
+}
+
+type I64Xor struct {
+}
+
+func (self *I64Xor) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Xor) String() string {
+	return "i64.xor"
+}
+
+func (self *I64Xor) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x85}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Xor) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Xor) printInstrBody(p *printer) {
+
+}
+
+type I64Shl struct {
+}
+
+func (self *I64Shl) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Shl) String() string {
+	return "i64.shl"
+}
+
+func (self *I64Shl) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x86}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Shl) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Shl) printInstrBody(p *printer) {
+
+}
+
+type I64ShrS struct {
+}
+
+func (self *I64ShrS) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64ShrS) String() string {
+	return "i64.shr_s"
+}
+
+func (self *I64ShrS) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x87}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64ShrS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64ShrS) printInstrBody(p *printer) {
+
+}
+
+type I64ShrU struct {
+}
+
+func (self *I64ShrU) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64ShrU) String() string {
+	return "i64.shr_u"
+}
+
+func (self *I64ShrU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x88}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64ShrU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64ShrU) printInstrBody(p *printer) {
+
+}
+
+type I64Rotl struct {
+}
+
+func (self *I64Rotl) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Rotl) String() string {
+	return "i64.rotl"
+}
+
+func (self *I64Rotl) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x89}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Rotl) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Rotl) printInstrBody(p *printer) {
+
+}
+
+type I64Rotr struct {
+}
+
+func (self *I64Rotr) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Rotr) String() string {
+	return "i64.rotr"
+}
+
+func (self *I64Rotr) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x8a}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Rotr) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Rotr) printInstrBody(p *printer) {
+
+}
+
+type F32Abs struct {
+}
+
+func (self *F32Abs) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Abs) String() string {
+	return "f32.abs"
+}
+
+func (self *F32Abs) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x8b}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Abs) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Abs) printInstrBody(p *printer) {
+
+}
+
+type F32Neg struct {
+}
+
+func (self *F32Neg) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Neg) String() string {
+	return "f32.neg"
+}
+
+func (self *F32Neg) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x8c}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Neg) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Neg) printInstrBody(p *printer) {
+
+}
+
+type F32Ceil struct {
+}
+
+func (self *F32Ceil) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Ceil) String() string {
+	return "f32.ceil"
+}
+
+func (self *F32Ceil) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x8d}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Ceil) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Ceil) printInstrBody(p *printer) {
+
+}
+
+type F32Floor struct {
+}
+
+func (self *F32Floor) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Floor) String() string {
+	return "f32.floor"
+}
+
+func (self *F32Floor) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x8e}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Floor) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Floor) printInstrBody(p *printer) {
+
+}
+
+type F32Trunc struct {
+}
+
+func (self *F32Trunc) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Trunc) String() string {
+	return "f32.trunc"
+}
+
+func (self *F32Trunc) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x8f}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Trunc) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Trunc) printInstrBody(p *printer) {
+
+}
+
+type F32Nearest struct {
+}
+
+func (self *F32Nearest) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Nearest) String() string {
+	return "f32.nearest"
+}
+
+func (self *F32Nearest) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x90}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Nearest) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Nearest) printInstrBody(p *printer) {
+
+}
+
+type F32Sqrt struct {
+}
+
+func (self *F32Sqrt) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Sqrt) String() string {
+	return "f32.sqrt"
+}
+
+func (self *F32Sqrt) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x91}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Sqrt) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Sqrt) printInstrBody(p *printer) {
+
+}
+
+type F32Add struct {
+}
+
+func (self *F32Add) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Add) String() string {
+	return "f32.add"
+}
+
+func (self *F32Add) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x92}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Add) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Add) printInstrBody(p *printer) {
+
+}
+
+type F32Sub struct {
+}
+
+func (self *F32Sub) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Sub) String() string {
+	return "f32.sub"
+}
+
+func (self *F32Sub) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x93}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Sub) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Sub) printInstrBody(p *printer) {
+
+}
+
+type F32Mul struct {
+}
+
+func (self *F32Mul) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Mul) String() string {
+	return "f32.mul"
+}
+
+func (self *F32Mul) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x94}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Mul) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Mul) printInstrBody(p *printer) {
+
+}
+
+type F32Div struct {
+}
+
+func (self *F32Div) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Div) String() string {
+	return "f32.div"
+}
+
+func (self *F32Div) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x95}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Div) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Div) printInstrBody(p *printer) {
+
+}
+
+type F32Min struct {
+}
+
+func (self *F32Min) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Min) String() string {
+	return "f32.min"
+}
+
+func (self *F32Min) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x96}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Min) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Min) printInstrBody(p *printer) {
+
+}
+
+type F32Max struct {
+}
+
+func (self *F32Max) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Max) String() string {
+	return "f32.max"
+}
+
+func (self *F32Max) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x97}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Max) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Max) printInstrBody(p *printer) {
+
+}
+
+type F32Copysign struct {
+}
+
+func (self *F32Copysign) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Copysign) String() string {
+	return "f32.copysign"
+}
+
+func (self *F32Copysign) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x98}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Copysign) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Copysign) printInstrBody(p *printer) {
+
+}
+
+type F64Abs struct {
+}
+
+func (self *F64Abs) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Abs) String() string {
+	return "f64.abs"
+}
+
+func (self *F64Abs) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x99}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Abs) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Abs) printInstrBody(p *printer) {
+
+}
+
+type F64Neg struct {
+}
+
+func (self *F64Neg) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Neg) String() string {
+	return "f64.neg"
+}
+
+func (self *F64Neg) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x9a}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Neg) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Neg) printInstrBody(p *printer) {
+
+}
+
+type F64Ceil struct {
+}
+
+func (self *F64Ceil) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Ceil) String() string {
+	return "f64.ceil"
+}
+
+func (self *F64Ceil) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x9b}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Ceil) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Ceil) printInstrBody(p *printer) {
+
+}
+
+type F64Floor struct {
+}
+
+func (self *F64Floor) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Floor) String() string {
+	return "f64.floor"
+}
+
+func (self *F64Floor) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x9c}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Floor) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Floor) printInstrBody(p *printer) {
+
+}
+
+type F64Trunc struct {
+}
+
+func (self *F64Trunc) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Trunc) String() string {
+	return "f64.trunc"
+}
+
+func (self *F64Trunc) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x9d}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Trunc) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Trunc) printInstrBody(p *printer) {
+
+}
+
+type F64Nearest struct {
+}
+
+func (self *F64Nearest) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Nearest) String() string {
+	return "f64.nearest"
+}
+
+func (self *F64Nearest) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x9e}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Nearest) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Nearest) printInstrBody(p *printer) {
+
+}
+
+type F64Sqrt struct {
+}
+
+func (self *F64Sqrt) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Sqrt) String() string {
+	return "f64.sqrt"
 }
 
 func (self *F64Sqrt) Encode(sink *ZeroCopySink) {
@@ -2523,1433 +4242,4312 @@ func (self *F64Sqrt) Encode(sink *ZeroCopySink) {
 
 }
 
-type F64Add struct {
+func (self *F64Sqrt) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Sqrt) printInstrBody(p *printer) {
+
+}
+
+type F64Add struct {
+}
+
+func (self *F64Add) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Add) String() string {
+	return "f64.add"
+}
+
+func (self *F64Add) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xa0}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Add) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Add) printInstrBody(p *printer) {
+
+}
+
+type F64Sub struct {
+}
+
+func (self *F64Sub) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Sub) String() string {
+	return "f64.sub"
+}
+
+func (self *F64Sub) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xa1}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Sub) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Sub) printInstrBody(p *printer) {
+
+}
+
+type F64Mul struct {
+}
+
+func (self *F64Mul) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Mul) String() string {
+	return "f64.mul"
+}
+
+func (self *F64Mul) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xa2}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Mul) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Mul) printInstrBody(p *printer) {
+
+}
+
+type F64Div struct {
+}
+
+func (self *F64Div) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Div) String() string {
+	return "f64.div"
+}
+
+func (self *F64Div) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xa3}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Div) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Div) printInstrBody(p *printer) {
+
+}
+
+type F64Min struct {
+}
+
+func (self *F64Min) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Min) String() string {
+	return "f64.min"
+}
+
+func (self *F64Min) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xa4}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Min) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Min) printInstrBody(p *printer) {
+
+}
+
+type F64Max struct {
+}
+
+func (self *F64Max) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Max) String() string {
+	return "f64.max"
+}
+
+func (self *F64Max) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xa5}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Max) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Max) printInstrBody(p *printer) {
+
+}
+
+type F64Copysign struct {
+}
+
+func (self *F64Copysign) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Copysign) String() string {
+	return "f64.copysign"
+}
+
+func (self *F64Copysign) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xa6}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Copysign) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Copysign) printInstrBody(p *printer) {
+
+}
+
+type I32Eqz struct {
+}
+
+func (self *I32Eqz) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32Eqz) String() string {
+	return "i32.eqz"
+}
+
+func (self *I32Eqz) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x45}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32Eqz) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32Eqz) printInstrBody(p *printer) {
+
+}
+
+type I32Eq struct {
+}
+
+func (self *I32Eq) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32Eq) String() string {
+	return "i32.eq"
+}
+
+func (self *I32Eq) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x46}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32Eq) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32Eq) printInstrBody(p *printer) {
+
+}
+
+type I32Ne struct {
+}
+
+func (self *I32Ne) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32Ne) String() string {
+	return "i32.ne"
+}
+
+func (self *I32Ne) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x47}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32Ne) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32Ne) printInstrBody(p *printer) {
+
+}
+
+type I32LtS struct {
+}
+
+func (self *I32LtS) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32LtS) String() string {
+	return "i32.lt_s"
+}
+
+func (self *I32LtS) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x48}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32LtS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32LtS) printInstrBody(p *printer) {
+
+}
+
+type I32LtU struct {
+}
+
+func (self *I32LtU) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32LtU) String() string {
+	return "i32.lt_u"
+}
+
+func (self *I32LtU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x49}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32LtU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32LtU) printInstrBody(p *printer) {
+
+}
+
+type I32GtS struct {
+}
+
+func (self *I32GtS) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32GtS) String() string {
+	return "i32.gt_s"
+}
+
+func (self *I32GtS) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x4a}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32GtS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32GtS) printInstrBody(p *printer) {
+
+}
+
+type I32GtU struct {
+}
+
+func (self *I32GtU) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32GtU) String() string {
+	return "i32.gt_u"
+}
+
+func (self *I32GtU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x4b}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32GtU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32GtU) printInstrBody(p *printer) {
+
+}
+
+type I32LeS struct {
+}
+
+func (self *I32LeS) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32LeS) String() string {
+	return "i32.le_s"
+}
+
+func (self *I32LeS) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x4c}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32LeS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32LeS) printInstrBody(p *printer) {
+
+}
+
+type I32LeU struct {
+}
+
+func (self *I32LeU) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32LeU) String() string {
+	return "i32.le_u"
+}
+
+func (self *I32LeU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x4d}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32LeU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32LeU) printInstrBody(p *printer) {
+
+}
+
+type I32GeS struct {
+}
+
+func (self *I32GeS) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32GeS) String() string {
+	return "i32.ge_s"
+}
+
+func (self *I32GeS) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x4e}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32GeS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32GeS) printInstrBody(p *printer) {
+
+}
+
+type I32GeU struct {
+}
+
+func (self *I32GeU) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32GeU) String() string {
+	return "i32.ge_u"
+}
+
+func (self *I32GeU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x4f}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32GeU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32GeU) printInstrBody(p *printer) {
+
+}
+
+type I64Eqz struct {
+}
+
+func (self *I64Eqz) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Eqz) String() string {
+	return "i64.eqz"
+}
+
+func (self *I64Eqz) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x50}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Eqz) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Eqz) printInstrBody(p *printer) {
+
+}
+
+type I64Eq struct {
+}
+
+func (self *I64Eq) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Eq) String() string {
+	return "i64.eq"
+}
+
+func (self *I64Eq) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x51}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Eq) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Eq) printInstrBody(p *printer) {
+
+}
+
+type I64Ne struct {
+}
+
+func (self *I64Ne) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Ne) String() string {
+	return "i64.ne"
+}
+
+func (self *I64Ne) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x52}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Ne) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Ne) printInstrBody(p *printer) {
+
+}
+
+type I64LtS struct {
+}
+
+func (self *I64LtS) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64LtS) String() string {
+	return "i64.lt_s"
+}
+
+func (self *I64LtS) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x53}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64LtS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64LtS) printInstrBody(p *printer) {
+
+}
+
+type I64LtU struct {
+}
+
+func (self *I64LtU) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64LtU) String() string {
+	return "i64.lt_u"
+}
+
+func (self *I64LtU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x54}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64LtU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64LtU) printInstrBody(p *printer) {
+
+}
+
+type I64GtS struct {
+}
+
+func (self *I64GtS) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64GtS) String() string {
+	return "i64.gt_s"
+}
+
+func (self *I64GtS) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x55}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64GtS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64GtS) printInstrBody(p *printer) {
+
+}
+
+type I64GtU struct {
+}
+
+func (self *I64GtU) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64GtU) String() string {
+	return "i64.gt_u"
+}
+
+func (self *I64GtU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x56}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64GtU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64GtU) printInstrBody(p *printer) {
+
+}
+
+type I64LeS struct {
+}
+
+func (self *I64LeS) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64LeS) String() string {
+	return "i64.le_s"
+}
+
+func (self *I64LeS) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x57}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64LeS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64LeS) printInstrBody(p *printer) {
+
+}
+
+type I64LeU struct {
+}
+
+func (self *I64LeU) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64LeU) String() string {
+	return "i64.le_u"
+}
+
+func (self *I64LeU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x58}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64LeU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64LeU) printInstrBody(p *printer) {
+
+}
+
+type I64GeS struct {
+}
+
+func (self *I64GeS) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64GeS) String() string {
+	return "i64.ge_s"
+}
+
+func (self *I64GeS) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x59}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64GeS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64GeS) printInstrBody(p *printer) {
+
+}
+
+type I64GeU struct {
+}
+
+func (self *I64GeU) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64GeU) String() string {
+	return "i64.ge_u"
+}
+
+func (self *I64GeU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x5a}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64GeU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64GeU) printInstrBody(p *printer) {
+
+}
+
+type F32Eq struct {
+}
+
+func (self *F32Eq) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Eq) String() string {
+	return "f32.eq"
+}
+
+func (self *F32Eq) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x5b}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Eq) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Eq) printInstrBody(p *printer) {
+
+}
+
+type F32Ne struct {
+}
+
+func (self *F32Ne) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Ne) String() string {
+	return "f32.ne"
+}
+
+func (self *F32Ne) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x5c}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Ne) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Ne) printInstrBody(p *printer) {
+
+}
+
+type F32Lt struct {
+}
+
+func (self *F32Lt) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Lt) String() string {
+	return "f32.lt"
+}
+
+func (self *F32Lt) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x5d}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Lt) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Lt) printInstrBody(p *printer) {
+
+}
+
+type F32Gt struct {
+}
+
+func (self *F32Gt) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Gt) String() string {
+	return "f32.gt"
+}
+
+func (self *F32Gt) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x5e}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Gt) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Gt) printInstrBody(p *printer) {
+
+}
+
+type F32Le struct {
+}
+
+func (self *F32Le) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Le) String() string {
+	return "f32.le"
+}
+
+func (self *F32Le) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x5f}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Le) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Le) printInstrBody(p *printer) {
+
+}
+
+type F32Ge struct {
+}
+
+func (self *F32Ge) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32Ge) String() string {
+	return "f32.ge"
+}
+
+func (self *F32Ge) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x60}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32Ge) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32Ge) printInstrBody(p *printer) {
+
+}
+
+type F64Eq struct {
+}
+
+func (self *F64Eq) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Eq) String() string {
+	return "f64.eq"
+}
+
+func (self *F64Eq) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x61}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Eq) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Eq) printInstrBody(p *printer) {
+
+}
+
+type F64Ne struct {
+}
+
+func (self *F64Ne) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Ne) String() string {
+	return "f64.ne"
+}
+
+func (self *F64Ne) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x62}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Ne) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Ne) printInstrBody(p *printer) {
+
+}
+
+type F64Lt struct {
+}
+
+func (self *F64Lt) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Lt) String() string {
+	return "f64.lt"
+}
+
+func (self *F64Lt) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x63}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Lt) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Lt) printInstrBody(p *printer) {
+
+}
+
+type F64Gt struct {
+}
+
+func (self *F64Gt) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Gt) String() string {
+	return "f64.gt"
+}
+
+func (self *F64Gt) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x64}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Gt) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Gt) printInstrBody(p *printer) {
+
+}
+
+type F64Le struct {
+}
+
+func (self *F64Le) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Le) String() string {
+	return "f64.le"
+}
+
+func (self *F64Le) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x65}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Le) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Le) printInstrBody(p *printer) {
+
+}
+
+type F64Ge struct {
+}
+
+func (self *F64Ge) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64Ge) String() string {
+	return "f64.ge"
+}
+
+func (self *F64Ge) Encode(sink *ZeroCopySink) {
+	inst := []byte{0x66}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64Ge) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64Ge) printInstrBody(p *printer) {
+
+}
+
+type I32WrapI64 struct {
+}
+
+func (self *I32WrapI64) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32WrapI64) String() string {
+	return "i32.wrap_i64"
+}
+
+func (self *I32WrapI64) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xa7}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32WrapI64) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32WrapI64) printInstrBody(p *printer) {
+
+}
+
+type I32TruncF32S struct {
+}
+
+func (self *I32TruncF32S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32TruncF32S) String() string {
+	return "i32.trunc_f32_s"
+}
+
+func (self *I32TruncF32S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xa8}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32TruncF32S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32TruncF32S) printInstrBody(p *printer) {
+
+}
+
+type I32TruncF32U struct {
+}
+
+func (self *I32TruncF32U) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32TruncF32U) String() string {
+	return "i32.trunc_f32_u"
+}
+
+func (self *I32TruncF32U) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xa9}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32TruncF32U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32TruncF32U) printInstrBody(p *printer) {
+
+}
+
+type I32TruncF64S struct {
+}
+
+func (self *I32TruncF64S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32TruncF64S) String() string {
+	return "i32.trunc_f64_s"
+}
+
+func (self *I32TruncF64S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xaa}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32TruncF64S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32TruncF64S) printInstrBody(p *printer) {
+
+}
+
+type I32TruncF64U struct {
+}
+
+func (self *I32TruncF64U) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32TruncF64U) String() string {
+	return "i32.trunc_f64_u"
+}
+
+func (self *I32TruncF64U) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xab}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32TruncF64U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32TruncF64U) printInstrBody(p *printer) {
+
+}
+
+type I64ExtendI32S struct {
+}
+
+func (self *I64ExtendI32S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64ExtendI32S) String() string {
+	return "i64.extend_i32_s"
+}
+
+func (self *I64ExtendI32S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xac}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64ExtendI32S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64ExtendI32S) printInstrBody(p *printer) {
+
+}
+
+type I64ExtendI32U struct {
+}
+
+func (self *I64ExtendI32U) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64ExtendI32U) String() string {
+	return "i64.extend_i32_u"
+}
+
+func (self *I64ExtendI32U) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xad}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64ExtendI32U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64ExtendI32U) printInstrBody(p *printer) {
+
+}
+
+type I64TruncF32S struct {
+}
+
+func (self *I64TruncF32S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64TruncF32S) String() string {
+	return "i64.trunc_f32_s"
+}
+
+func (self *I64TruncF32S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xae}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64TruncF32S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64TruncF32S) printInstrBody(p *printer) {
+
+}
+
+type I64TruncF32U struct {
+}
+
+func (self *I64TruncF32U) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64TruncF32U) String() string {
+	return "i64.trunc_f32_u"
+}
+
+func (self *I64TruncF32U) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xaf}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64TruncF32U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64TruncF32U) printInstrBody(p *printer) {
+
+}
+
+type I64TruncF64S struct {
+}
+
+func (self *I64TruncF64S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64TruncF64S) String() string {
+	return "i64.trunc_f64_s"
+}
+
+func (self *I64TruncF64S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xb0}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64TruncF64S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64TruncF64S) printInstrBody(p *printer) {
+
+}
+
+type I64TruncF64U struct {
+}
+
+func (self *I64TruncF64U) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64TruncF64U) String() string {
+	return "i64.trunc_f64_u"
+}
+
+func (self *I64TruncF64U) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xb1}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64TruncF64U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64TruncF64U) printInstrBody(p *printer) {
+
+}
+
+type F32ConvertI32S struct {
+}
+
+func (self *F32ConvertI32S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32ConvertI32S) String() string {
+	return "f32.convert_i32_s"
+}
+
+func (self *F32ConvertI32S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xb2}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32ConvertI32S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32ConvertI32S) printInstrBody(p *printer) {
+
+}
+
+type F32ConvertI32U struct {
+}
+
+func (self *F32ConvertI32U) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32ConvertI32U) String() string {
+	return "f32.convert_i32_u"
+}
+
+func (self *F32ConvertI32U) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xb3}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32ConvertI32U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32ConvertI32U) printInstrBody(p *printer) {
+
+}
+
+type F32ConvertI64S struct {
+}
+
+func (self *F32ConvertI64S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32ConvertI64S) String() string {
+	return "f32.convert_i64_s"
+}
+
+func (self *F32ConvertI64S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xb4}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32ConvertI64S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32ConvertI64S) printInstrBody(p *printer) {
+
+}
+
+type F32ConvertI64U struct {
+}
+
+func (self *F32ConvertI64U) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32ConvertI64U) String() string {
+	return "f32.convert_i64_u"
+}
+
+func (self *F32ConvertI64U) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xb5}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32ConvertI64U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32ConvertI64U) printInstrBody(p *printer) {
+
+}
+
+type F32DemoteF64 struct {
+}
+
+func (self *F32DemoteF64) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32DemoteF64) String() string {
+	return "f32.demote_f64"
+}
+
+func (self *F32DemoteF64) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xb6}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32DemoteF64) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32DemoteF64) printInstrBody(p *printer) {
+
+}
+
+type F64ConvertI32S struct {
+}
+
+func (self *F64ConvertI32S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64ConvertI32S) String() string {
+	return "f64.convert_i32_s"
+}
+
+func (self *F64ConvertI32S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xb7}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64ConvertI32S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64ConvertI32S) printInstrBody(p *printer) {
+
+}
+
+type F64ConvertI32U struct {
+}
+
+func (self *F64ConvertI32U) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64ConvertI32U) String() string {
+	return "f64.convert_i32_u"
+}
+
+func (self *F64ConvertI32U) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xb8}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64ConvertI32U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64ConvertI32U) printInstrBody(p *printer) {
+
+}
+
+type F64ConvertI64S struct {
+}
+
+func (self *F64ConvertI64S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64ConvertI64S) String() string {
+	return "f64.convert_i64_s"
+}
+
+func (self *F64ConvertI64S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xb9}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64ConvertI64S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64ConvertI64S) printInstrBody(p *printer) {
+
+}
+
+type F64ConvertI64U struct {
+}
+
+func (self *F64ConvertI64U) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64ConvertI64U) String() string {
+	return "f64.convert_i64_u"
+}
+
+func (self *F64ConvertI64U) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xba}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64ConvertI64U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64ConvertI64U) printInstrBody(p *printer) {
+
+}
+
+type F64PromoteF32 struct {
+}
+
+func (self *F64PromoteF32) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64PromoteF32) String() string {
+	return "f64.promote_f32"
+}
+
+func (self *F64PromoteF32) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xbb}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64PromoteF32) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64PromoteF32) printInstrBody(p *printer) {
+
+}
+
+type I32ReinterpretF32 struct {
+}
+
+func (self *I32ReinterpretF32) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32ReinterpretF32) String() string {
+	return "i32.reinterpret_f32"
+}
+
+func (self *I32ReinterpretF32) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xbc}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32ReinterpretF32) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32ReinterpretF32) printInstrBody(p *printer) {
+
+}
+
+type I64ReinterpretF64 struct {
+}
+
+func (self *I64ReinterpretF64) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64ReinterpretF64) String() string {
+	return "i64.reinterpret_f64"
+}
+
+func (self *I64ReinterpretF64) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xbd}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64ReinterpretF64) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64ReinterpretF64) printInstrBody(p *printer) {
+
+}
+
+type F32ReinterpretI32 struct {
+}
+
+func (self *F32ReinterpretI32) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F32ReinterpretI32) String() string {
+	return "f32.reinterpret_i32"
+}
+
+func (self *F32ReinterpretI32) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xbe}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32ReinterpretI32) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32ReinterpretI32) printInstrBody(p *printer) {
+
+}
+
+type F64ReinterpretI64 struct {
+}
+
+func (self *F64ReinterpretI64) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *F64ReinterpretI64) String() string {
+	return "f64.reinterpret_i64"
+}
+
+func (self *F64ReinterpretI64) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xbf}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F64ReinterpretI64) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64ReinterpretI64) printInstrBody(p *printer) {
+
+}
+
+type I32TruncSatF32S struct {
+}
+
+func (self *I32TruncSatF32S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32TruncSatF32S) String() string {
+	return "i32.trunc_sat_f32_s"
+}
+
+func (self *I32TruncSatF32S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0x0}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32TruncSatF32S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32TruncSatF32S) printInstrBody(p *printer) {
+
+}
+
+type I32TruncSatF32U struct {
+}
+
+func (self *I32TruncSatF32U) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32TruncSatF32U) String() string {
+	return "i32.trunc_sat_f32_u"
+}
+
+func (self *I32TruncSatF32U) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0x1}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32TruncSatF32U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32TruncSatF32U) printInstrBody(p *printer) {
+
+}
+
+type I32TruncSatF64S struct {
+}
+
+func (self *I32TruncSatF64S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32TruncSatF64S) String() string {
+	return "i32.trunc_sat_f64_s"
+}
+
+func (self *I32TruncSatF64S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0x2}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32TruncSatF64S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32TruncSatF64S) printInstrBody(p *printer) {
+
+}
+
+type I32TruncSatF64U struct {
+}
+
+func (self *I32TruncSatF64U) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32TruncSatF64U) String() string {
+	return "i32.trunc_sat_f64_u"
+}
+
+func (self *I32TruncSatF64U) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0x3}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32TruncSatF64U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32TruncSatF64U) printInstrBody(p *printer) {
+
+}
+
+type I64TruncSatF32S struct {
+}
+
+func (self *I64TruncSatF32S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64TruncSatF32S) String() string {
+	return "i64.trunc_sat_f32_s"
+}
+
+func (self *I64TruncSatF32S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0x4}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64TruncSatF32S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64TruncSatF32S) printInstrBody(p *printer) {
+
+}
+
+type I64TruncSatF32U struct {
+}
+
+func (self *I64TruncSatF32U) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64TruncSatF32U) String() string {
+	return "i64.trunc_sat_f32_u"
+}
+
+func (self *I64TruncSatF32U) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0x5}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64TruncSatF32U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64TruncSatF32U) printInstrBody(p *printer) {
+
+}
+
+type I64TruncSatF64S struct {
+}
+
+func (self *I64TruncSatF64S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64TruncSatF64S) String() string {
+	return "i64.trunc_sat_f64_s"
+}
+
+func (self *I64TruncSatF64S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0x6}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64TruncSatF64S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64TruncSatF64S) printInstrBody(p *printer) {
+
+}
+
+type I64TruncSatF64U struct {
+}
+
+func (self *I64TruncSatF64U) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64TruncSatF64U) String() string {
+	return "i64.trunc_sat_f64_u"
+}
+
+func (self *I64TruncSatF64U) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfc, 0x7}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64TruncSatF64U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64TruncSatF64U) printInstrBody(p *printer) {
+
+}
+
+type I32Extend8S struct {
+}
+
+func (self *I32Extend8S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32Extend8S) String() string {
+	return "i32.extend8_s"
+}
+
+func (self *I32Extend8S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xc0}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32Extend8S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32Extend8S) printInstrBody(p *printer) {
+
+}
+
+type I32Extend16S struct {
+}
+
+func (self *I32Extend16S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I32Extend16S) String() string {
+	return "i32.extend16_s"
+}
+
+func (self *I32Extend16S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xc1}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I32Extend16S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32Extend16S) printInstrBody(p *printer) {
+
+}
+
+type I64Extend8S struct {
+}
+
+func (self *I64Extend8S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Extend8S) String() string {
+	return "i64.extend8_s"
+}
+
+func (self *I64Extend8S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xc2}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Extend8S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Extend8S) printInstrBody(p *printer) {
+
+}
+
+type I64Extend16S struct {
+}
+
+func (self *I64Extend16S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Extend16S) String() string {
+	return "i64.extend16_s"
+}
+
+func (self *I64Extend16S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xc3}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Extend16S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Extend16S) printInstrBody(p *printer) {
+
+}
+
+type I64Extend32S struct {
+}
+
+func (self *I64Extend32S) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *I64Extend32S) String() string {
+	return "i64.extend32_s"
+}
+
+func (self *I64Extend32S) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xc4}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I64Extend32S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64Extend32S) printInstrBody(p *printer) {
+
+}
+
+type AtomicNotify struct {
+	MemArg MemArg
+}
+
+func (self *AtomicNotify) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *AtomicNotify) String() string {
+	return "atomic.notify"
+}
+
+func (self *AtomicNotify) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x0}
+	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
+
+}
+
+func (self *AtomicNotify) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *AtomicNotify) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
+
+}
+
+func (self *AtomicNotify) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
+}
+
+type I32AtomicWait struct {
+	MemArg MemArg
+}
+
+func (self *I32AtomicWait) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I32AtomicWait) String() string {
+	return "i32.atomic.wait"
+}
+
+func (self *I32AtomicWait) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x1}
+	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
+
+}
+
+func (self *I32AtomicWait) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I32AtomicWait) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
+
+}
+
+func (self *I32AtomicWait) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
+}
+
+type I64AtomicWait struct {
+	MemArg MemArg
+}
+
+func (self *I64AtomicWait) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 8)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I64AtomicWait) String() string {
+	return "i64.atomic.wait"
+}
+
+func (self *I64AtomicWait) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x2}
+	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
+
+}
+
+func (self *I64AtomicWait) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I64AtomicWait) printInstrBody(p *printer) {
+	self.MemArg.print(p, 8)
+
+}
+
+func (self *I64AtomicWait) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 8
+}
+
+type AtomicFence struct {
+}
+
+func (self *AtomicFence) parseInstrBody(ps *parser.ParserBuffer) error {
+
+	return nil
+}
+
+func (self *AtomicFence) String() string {
+	return "atomic.fence"
+}
+
+func (self *AtomicFence) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x3}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *AtomicFence) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *AtomicFence) printInstrBody(p *printer) {
+
+}
+
+type I32AtomicLoad struct {
+	MemArg MemArg
+}
+
+func (self *I32AtomicLoad) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I32AtomicLoad) String() string {
+	return "i32.atomic.load"
+}
+
+func (self *I32AtomicLoad) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x10}
+	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
+
+}
+
+func (self *I32AtomicLoad) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I32AtomicLoad) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
+
+}
+
+func (self *I32AtomicLoad) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
+}
+
+type I64AtomicLoad struct {
+	MemArg MemArg
+}
+
+func (self *I64AtomicLoad) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 8)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I64AtomicLoad) String() string {
+	return "i64.atomic.load"
+}
+
+func (self *I64AtomicLoad) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x11}
+	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
+
+}
+
+func (self *I64AtomicLoad) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I64AtomicLoad) printInstrBody(p *printer) {
+	self.MemArg.print(p, 8)
+
+}
+
+func (self *I64AtomicLoad) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 8
+}
+
+type I32AtomicLoad8u struct {
+	MemArg MemArg
+}
+
+func (self *I32AtomicLoad8u) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I32AtomicLoad8u) String() string {
+	return "i32.atomic.load8_u"
+}
+
+func (self *I32AtomicLoad8u) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x12}
+	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
+
+}
+
+func (self *I32AtomicLoad8u) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I32AtomicLoad8u) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
+
+}
+
+func (self *I32AtomicLoad8u) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
+}
+
+type I32AtomicLoad16u struct {
+	MemArg MemArg
+}
+
+func (self *I32AtomicLoad16u) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I32AtomicLoad16u) String() string {
+	return "i32.atomic.load16_u"
+}
+
+func (self *I32AtomicLoad16u) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x13}
+	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
+
+}
+
+func (self *I32AtomicLoad16u) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I32AtomicLoad16u) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
+
+}
+
+func (self *I32AtomicLoad16u) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
+}
+
+type I64AtomicLoad8u struct {
+	MemArg MemArg
+}
+
+func (self *I64AtomicLoad8u) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I64AtomicLoad8u) String() string {
+	return "i64.atomic.load8_u"
+}
+
+func (self *I64AtomicLoad8u) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x14}
+	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
+
+}
+
+func (self *I64AtomicLoad8u) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
 }
 
-func (self *F64Add) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicLoad8u) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
+
+}
+
+func (self *I64AtomicLoad8u) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
+}
+
+type I64AtomicLoad16u struct {
+	MemArg MemArg
+}
+
+func (self *I64AtomicLoad16u) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Add) String() string {
-	return "f64.add"
+func (self *I64AtomicLoad16u) String() string {
+	return "i64.atomic.load16_u"
 }
 
-func (self *F64Add) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xa0}
+func (self *I64AtomicLoad16u) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x15}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F64Sub struct {
+func (self *I64AtomicLoad16u) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
 }
 
-func (self *F64Sub) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicLoad16u) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
+
+}
+
+func (self *I64AtomicLoad16u) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
+}
+
+type I64AtomicLoad32u struct {
+	MemArg MemArg
+}
+
+func (self *I64AtomicLoad32u) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Sub) String() string {
-	return "f64.sub"
+func (self *I64AtomicLoad32u) String() string {
+	return "i64.atomic.load32_u"
 }
 
-func (self *F64Sub) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xa1}
+func (self *I64AtomicLoad32u) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x16}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F64Mul struct {
+func (self *I64AtomicLoad32u) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
 }
 
-func (self *F64Mul) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicLoad32u) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
+
+}
+
+func (self *I64AtomicLoad32u) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
+}
+
+type I32AtomicStore struct {
+	MemArg MemArg
+}
+
+func (self *I32AtomicStore) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Mul) String() string {
-	return "f64.mul"
+func (self *I32AtomicStore) String() string {
+	return "i32.atomic.store"
 }
 
-func (self *F64Mul) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xa2}
+func (self *I32AtomicStore) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x17}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F64Div struct {
+func (self *I32AtomicStore) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
 }
 
-func (self *F64Div) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicStore) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
+
+}
+
+func (self *I32AtomicStore) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
+}
+
+type I64AtomicStore struct {
+	MemArg MemArg
+}
+
+func (self *I64AtomicStore) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 8)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Div) String() string {
-	return "f64.div"
+func (self *I64AtomicStore) String() string {
+	return "i64.atomic.store"
 }
 
-func (self *F64Div) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xa3}
+func (self *I64AtomicStore) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x18}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F64Min struct {
+func (self *I64AtomicStore) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
 }
 
-func (self *F64Min) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicStore) printInstrBody(p *printer) {
+	self.MemArg.print(p, 8)
+
+}
+
+func (self *I64AtomicStore) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 8
+}
+
+type I32AtomicStore8 struct {
+	MemArg MemArg
+}
+
+func (self *I32AtomicStore8) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Min) String() string {
-	return "f64.min"
+func (self *I32AtomicStore8) String() string {
+	return "i32.atomic.store8"
 }
 
-func (self *F64Min) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xa4}
+func (self *I32AtomicStore8) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x19}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F64Max struct {
+func (self *I32AtomicStore8) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
 }
 
-func (self *F64Max) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicStore8) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
+
+}
+
+func (self *I32AtomicStore8) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
+}
+
+type I32AtomicStore16 struct {
+	MemArg MemArg
+}
+
+func (self *I32AtomicStore16) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Max) String() string {
-	return "f64.max"
+func (self *I32AtomicStore16) String() string {
+	return "i32.atomic.store16"
 }
 
-func (self *F64Max) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xa5}
+func (self *I32AtomicStore16) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x1a}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F64Copysign struct {
+func (self *I32AtomicStore16) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
 }
 
-func (self *F64Copysign) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicStore16) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
+
+}
+
+func (self *I32AtomicStore16) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
+}
+
+type I64AtomicStore8 struct {
+	MemArg MemArg
+}
+
+func (self *I64AtomicStore8) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Copysign) String() string {
-	return "f64.copysign"
+func (self *I64AtomicStore8) String() string {
+	return "i64.atomic.store8"
+}
+
+func (self *I64AtomicStore8) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x1b}
+	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
+
+}
+
+func (self *I64AtomicStore8) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I64AtomicStore8) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
+
+}
+
+func (self *I64AtomicStore8) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
+}
+
+type I64AtomicStore16 struct {
+	MemArg MemArg
+}
+
+func (self *I64AtomicStore16) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I64AtomicStore16) String() string {
+	return "i64.atomic.store16"
+}
+
+func (self *I64AtomicStore16) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x1c}
+	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
+
+}
+
+func (self *I64AtomicStore16) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
 }
 
-func (self *F64Copysign) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xa6}
-	sink.WriteBytes(inst)
+func (self *I64AtomicStore16) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
 }
 
-type I32Eqz struct {
+func (self *I64AtomicStore16) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-func (self *I32Eqz) parseInstrBody(ps *parser.ParserBuffer) error {
+type I64AtomicStore32 struct {
+	MemArg MemArg
+}
+
+func (self *I64AtomicStore32) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32Eqz) String() string {
-	return "i32.eqz"
+func (self *I64AtomicStore32) String() string {
+	return "i64.atomic.store32"
 }
 
-func (self *I32Eqz) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x45}
+func (self *I64AtomicStore32) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x1d}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32Eq struct {
-}
-
-func (self *I32Eq) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicStore32) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32Eq) String() string {
-	return "i32.eq"
-}
+func (self *I64AtomicStore32) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *I32Eq) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x46}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicStore32) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type I32Ne struct {
+type I32AtomicRmwAdd struct {
+	MemArg MemArg
 }
 
-func (self *I32Ne) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmwAdd) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32Ne) String() string {
-	return "i32.ne"
+func (self *I32AtomicRmwAdd) String() string {
+	return "i32.atomic.rmw.add"
 }
 
-func (self *I32Ne) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x47}
+func (self *I32AtomicRmwAdd) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x1e}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32LtS struct {
-}
-
-func (self *I32LtS) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmwAdd) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32LtS) String() string {
-	return "i32.lt_s"
-}
+func (self *I32AtomicRmwAdd) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *I32LtS) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x48}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32AtomicRmwAdd) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type I32LtU struct {
+type I64AtomicRmwAdd struct {
+	MemArg MemArg
 }
 
-func (self *I32LtU) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmwAdd) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 8)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32LtU) String() string {
-	return "i32.lt_u"
+func (self *I64AtomicRmwAdd) String() string {
+	return "i64.atomic.rmw.add"
 }
 
-func (self *I32LtU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x49}
+func (self *I64AtomicRmwAdd) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x1f}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32GtS struct {
-}
-
-func (self *I32GtS) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmwAdd) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32GtS) String() string {
-	return "i32.gt_s"
-}
+func (self *I64AtomicRmwAdd) printInstrBody(p *printer) {
+	self.MemArg.print(p, 8)
 
-func (self *I32GtS) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x4a}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmwAdd) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 8
 }
 
-type I32GtU struct {
+type I32AtomicRmw8AddU struct {
+	MemArg MemArg
 }
 
-func (self *I32GtU) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw8AddU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32GtU) String() string {
-	return "i32.gt_u"
+func (self *I32AtomicRmw8AddU) String() string {
+	return "i32.atomic.rmw8.add_u"
 }
 
-func (self *I32GtU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x4b}
+func (self *I32AtomicRmw8AddU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x20}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32LeS struct {
-}
-
-func (self *I32LeS) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw8AddU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32LeS) String() string {
-	return "i32.le_s"
-}
+func (self *I32AtomicRmw8AddU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *I32LeS) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x4c}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32AtomicRmw8AddU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type I32LeU struct {
+type I32AtomicRmw16AddU struct {
+	MemArg MemArg
 }
 
-func (self *I32LeU) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw16AddU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32LeU) String() string {
-	return "i32.le_u"
+func (self *I32AtomicRmw16AddU) String() string {
+	return "i32.atomic.rmw16.add_u"
 }
 
-func (self *I32LeU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x4d}
+func (self *I32AtomicRmw16AddU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x21}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32GeS struct {
-}
-
-func (self *I32GeS) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw16AddU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32GeS) String() string {
-	return "i32.ge_s"
-}
+func (self *I32AtomicRmw16AddU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *I32GeS) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x4e}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32AtomicRmw16AddU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type I32GeU struct {
+type I64AtomicRmw8AddU struct {
+	MemArg MemArg
 }
 
-func (self *I32GeU) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw8AddU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32GeU) String() string {
-	return "i32.ge_u"
+func (self *I64AtomicRmw8AddU) String() string {
+	return "i64.atomic.rmw8.add_u"
 }
 
-func (self *I32GeU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x4f}
+func (self *I64AtomicRmw8AddU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x22}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64Eqz struct {
-}
-
-func (self *I64Eqz) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw8AddU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64Eqz) String() string {
-	return "i64.eqz"
-}
+func (self *I64AtomicRmw8AddU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *I64Eqz) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x50}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmw8AddU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type I64Eq struct {
+type I64AtomicRmw16AddU struct {
+	MemArg MemArg
 }
 
-func (self *I64Eq) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw16AddU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64Eq) String() string {
-	return "i64.eq"
+func (self *I64AtomicRmw16AddU) String() string {
+	return "i64.atomic.rmw16.add_u"
 }
 
-func (self *I64Eq) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x51}
+func (self *I64AtomicRmw16AddU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x23}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64Ne struct {
-}
-
-func (self *I64Ne) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw16AddU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64Ne) String() string {
-	return "i64.ne"
-}
+func (self *I64AtomicRmw16AddU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *I64Ne) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x52}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmw16AddU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type I64LtS struct {
+type I64AtomicRmw32AddU struct {
+	MemArg MemArg
 }
 
-func (self *I64LtS) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw32AddU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64LtS) String() string {
-	return "i64.lt_s"
+func (self *I64AtomicRmw32AddU) String() string {
+	return "i64.atomic.rmw32.add_u"
 }
 
-func (self *I64LtS) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x53}
+func (self *I64AtomicRmw32AddU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x24}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64LtU struct {
-}
-
-func (self *I64LtU) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw32AddU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64LtU) String() string {
-	return "i64.lt_u"
-}
+func (self *I64AtomicRmw32AddU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *I64LtU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x54}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmw32AddU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type I64GtS struct {
+type I32AtomicRmwSub struct {
+	MemArg MemArg
 }
 
-func (self *I64GtS) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmwSub) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64GtS) String() string {
-	return "i64.gt_s"
+func (self *I32AtomicRmwSub) String() string {
+	return "i32.atomic.rmw.sub"
 }
 
-func (self *I64GtS) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x55}
+func (self *I32AtomicRmwSub) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x25}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64GtU struct {
-}
-
-func (self *I64GtU) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmwSub) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64GtU) String() string {
-	return "i64.gt_u"
-}
+func (self *I32AtomicRmwSub) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *I64GtU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x56}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32AtomicRmwSub) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type I64LeS struct {
+type I64AtomicRmwSub struct {
+	MemArg MemArg
 }
 
-func (self *I64LeS) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmwSub) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 8)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64LeS) String() string {
-	return "i64.le_s"
+func (self *I64AtomicRmwSub) String() string {
+	return "i64.atomic.rmw.sub"
 }
 
-func (self *I64LeS) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x57}
+func (self *I64AtomicRmwSub) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x26}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64LeU struct {
-}
-
-func (self *I64LeU) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmwSub) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64LeU) String() string {
-	return "i64.le_u"
-}
+func (self *I64AtomicRmwSub) printInstrBody(p *printer) {
+	self.MemArg.print(p, 8)
 
-func (self *I64LeU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x58}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmwSub) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 8
 }
 
-type I64GeS struct {
+type I32AtomicRmw8SubU struct {
+	MemArg MemArg
 }
 
-func (self *I64GeS) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw8SubU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64GeS) String() string {
-	return "i64.ge_s"
+func (self *I32AtomicRmw8SubU) String() string {
+	return "i32.atomic.rmw8.sub_u"
 }
 
-func (self *I64GeS) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x59}
+func (self *I32AtomicRmw8SubU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x27}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64GeU struct {
-}
-
-func (self *I64GeU) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw8SubU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64GeU) String() string {
-	return "i64.ge_u"
-}
+func (self *I32AtomicRmw8SubU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *I64GeU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x5a}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32AtomicRmw8SubU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type F32Eq struct {
+type I32AtomicRmw16SubU struct {
+	MemArg MemArg
 }
 
-func (self *F32Eq) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw16SubU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Eq) String() string {
-	return "f32.eq"
+func (self *I32AtomicRmw16SubU) String() string {
+	return "i32.atomic.rmw16.sub_u"
 }
 
-func (self *F32Eq) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x5b}
+func (self *I32AtomicRmw16SubU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x28}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F32Ne struct {
-}
-
-func (self *F32Ne) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw16SubU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Ne) String() string {
-	return "f32.ne"
-}
+func (self *I32AtomicRmw16SubU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *F32Ne) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x5c}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32AtomicRmw16SubU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type F32Lt struct {
+type I64AtomicRmw8SubU struct {
+	MemArg MemArg
 }
 
-func (self *F32Lt) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw8SubU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Lt) String() string {
-	return "f32.lt"
+func (self *I64AtomicRmw8SubU) String() string {
+	return "i64.atomic.rmw8.sub_u"
 }
 
-func (self *F32Lt) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x5d}
+func (self *I64AtomicRmw8SubU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x29}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F32Gt struct {
-}
-
-func (self *F32Gt) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw8SubU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Gt) String() string {
-	return "f32.gt"
-}
+func (self *I64AtomicRmw8SubU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *F32Gt) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x5e}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmw8SubU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type F32Le struct {
+type I64AtomicRmw16SubU struct {
+	MemArg MemArg
 }
 
-func (self *F32Le) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw16SubU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Le) String() string {
-	return "f32.le"
+func (self *I64AtomicRmw16SubU) String() string {
+	return "i64.atomic.rmw16.sub_u"
 }
 
-func (self *F32Le) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x5f}
+func (self *I64AtomicRmw16SubU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x2a}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F32Ge struct {
-}
-
-func (self *F32Ge) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw16SubU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32Ge) String() string {
-	return "f32.ge"
-}
+func (self *I64AtomicRmw16SubU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *F32Ge) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x60}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmw16SubU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type F64Eq struct {
+type I64AtomicRmw32SubU struct {
+	MemArg MemArg
 }
 
-func (self *F64Eq) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw32SubU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Eq) String() string {
-	return "f64.eq"
+func (self *I64AtomicRmw32SubU) String() string {
+	return "i64.atomic.rmw32.sub_u"
 }
 
-func (self *F64Eq) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x61}
+func (self *I64AtomicRmw32SubU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x2b}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F64Ne struct {
-}
-
-func (self *F64Ne) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw32SubU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Ne) String() string {
-	return "f64.ne"
-}
+func (self *I64AtomicRmw32SubU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *F64Ne) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x62}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmw32SubU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type F64Lt struct {
+type I32AtomicRmwAnd struct {
+	MemArg MemArg
 }
 
-func (self *F64Lt) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmwAnd) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Lt) String() string {
-	return "f64.lt"
+func (self *I32AtomicRmwAnd) String() string {
+	return "i32.atomic.rmw.and"
 }
 
-func (self *F64Lt) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x63}
+func (self *I32AtomicRmwAnd) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x2c}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F64Gt struct {
-}
-
-func (self *F64Gt) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmwAnd) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Gt) String() string {
-	return "f64.gt"
-}
+func (self *I32AtomicRmwAnd) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *F64Gt) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x64}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32AtomicRmwAnd) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type F64Le struct {
+type I64AtomicRmwAnd struct {
+	MemArg MemArg
 }
 
-func (self *F64Le) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmwAnd) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 8)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Le) String() string {
-	return "f64.le"
+func (self *I64AtomicRmwAnd) String() string {
+	return "i64.atomic.rmw.and"
 }
 
-func (self *F64Le) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x65}
+func (self *I64AtomicRmwAnd) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x2d}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F64Ge struct {
-}
-
-func (self *F64Ge) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmwAnd) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64Ge) String() string {
-	return "f64.ge"
-}
+func (self *I64AtomicRmwAnd) printInstrBody(p *printer) {
+	self.MemArg.print(p, 8)
 
-func (self *F64Ge) Encode(sink *ZeroCopySink) {
-	inst := []byte{0x66}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmwAnd) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 8
 }
 
-type I32WrapI64 struct {
+type I32AtomicRmw8AndU struct {
+	MemArg MemArg
 }
 
-func (self *I32WrapI64) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw8AndU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32WrapI64) String() string {
-	return "i32.wrap_i64"
+func (self *I32AtomicRmw8AndU) String() string {
+	return "i32.atomic.rmw8.and_u"
 }
 
-func (self *I32WrapI64) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xa7}
+func (self *I32AtomicRmw8AndU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x2e}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32TruncF32S struct {
-}
-
-func (self *I32TruncF32S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw8AndU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32TruncF32S) String() string {
-	return "i32.trunc_f32_s"
-}
+func (self *I32AtomicRmw8AndU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *I32TruncF32S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xa8}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32AtomicRmw8AndU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type I32TruncF32U struct {
+type I32AtomicRmw16AndU struct {
+	MemArg MemArg
 }
 
-func (self *I32TruncF32U) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw16AndU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32TruncF32U) String() string {
-	return "i32.trunc_f32_u"
+func (self *I32AtomicRmw16AndU) String() string {
+	return "i32.atomic.rmw16.and_u"
 }
 
-func (self *I32TruncF32U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xa9}
+func (self *I32AtomicRmw16AndU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x2f}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32TruncF64S struct {
-}
-
-func (self *I32TruncF64S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw16AndU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32TruncF64S) String() string {
-	return "i32.trunc_f64_s"
-}
+func (self *I32AtomicRmw16AndU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *I32TruncF64S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xaa}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32AtomicRmw16AndU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type I32TruncF64U struct {
+type I64AtomicRmw8AndU struct {
+	MemArg MemArg
 }
 
-func (self *I32TruncF64U) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw8AndU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32TruncF64U) String() string {
-	return "i32.trunc_f64_u"
+func (self *I64AtomicRmw8AndU) String() string {
+	return "i64.atomic.rmw8.and_u"
 }
 
-func (self *I32TruncF64U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xab}
+func (self *I64AtomicRmw8AndU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x30}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64ExtendI32S struct {
-}
-
-func (self *I64ExtendI32S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw8AndU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64ExtendI32S) String() string {
-	return "i64.extend_i32_s"
-}
+func (self *I64AtomicRmw8AndU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *I64ExtendI32S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xac}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmw8AndU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type I64ExtendI32U struct {
+type I64AtomicRmw16AndU struct {
+	MemArg MemArg
 }
 
-func (self *I64ExtendI32U) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw16AndU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64ExtendI32U) String() string {
-	return "i64.extend_i32_u"
+func (self *I64AtomicRmw16AndU) String() string {
+	return "i64.atomic.rmw16.and_u"
 }
 
-func (self *I64ExtendI32U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xad}
+func (self *I64AtomicRmw16AndU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x31}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64TruncF32S struct {
-}
-
-func (self *I64TruncF32S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw16AndU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64TruncF32S) String() string {
-	return "i64.trunc_f32_s"
-}
+func (self *I64AtomicRmw16AndU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *I64TruncF32S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xae}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmw16AndU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type I64TruncF32U struct {
+type I64AtomicRmw32AndU struct {
+	MemArg MemArg
 }
 
-func (self *I64TruncF32U) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw32AndU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64TruncF32U) String() string {
-	return "i64.trunc_f32_u"
+func (self *I64AtomicRmw32AndU) String() string {
+	return "i64.atomic.rmw32.and_u"
 }
 
-func (self *I64TruncF32U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xaf}
+func (self *I64AtomicRmw32AndU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x32}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64TruncF64S struct {
-}
-
-func (self *I64TruncF64S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw32AndU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64TruncF64S) String() string {
-	return "i64.trunc_f64_s"
-}
+func (self *I64AtomicRmw32AndU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *I64TruncF64S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xb0}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmw32AndU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type I64TruncF64U struct {
+type I32AtomicRmwOr struct {
+	MemArg MemArg
 }
 
-func (self *I64TruncF64U) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmwOr) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64TruncF64U) String() string {
-	return "i64.trunc_f64_u"
+func (self *I32AtomicRmwOr) String() string {
+	return "i32.atomic.rmw.or"
 }
 
-func (self *I64TruncF64U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xb1}
+func (self *I32AtomicRmwOr) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x33}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F32ConvertI32S struct {
-}
-
-func (self *F32ConvertI32S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmwOr) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32ConvertI32S) String() string {
-	return "f32.convert_i32_s"
-}
+func (self *I32AtomicRmwOr) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *F32ConvertI32S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xb2}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32AtomicRmwOr) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type F32ConvertI32U struct {
+type I64AtomicRmwOr struct {
+	MemArg MemArg
 }
 
-func (self *F32ConvertI32U) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmwOr) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 8)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32ConvertI32U) String() string {
-	return "f32.convert_i32_u"
+func (self *I64AtomicRmwOr) String() string {
+	return "i64.atomic.rmw.or"
 }
 
-func (self *F32ConvertI32U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xb3}
+func (self *I64AtomicRmwOr) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x34}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F32ConvertI64S struct {
-}
-
-func (self *F32ConvertI64S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmwOr) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32ConvertI64S) String() string {
-	return "f32.convert_i64_s"
-}
+func (self *I64AtomicRmwOr) printInstrBody(p *printer) {
+	self.MemArg.print(p, 8)
 
-func (self *F32ConvertI64S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xb4}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmwOr) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 8
 }
 
-type F32ConvertI64U struct {
+type I32AtomicRmw8OrU struct {
+	MemArg MemArg
 }
 
-func (self *F32ConvertI64U) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw8OrU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32ConvertI64U) String() string {
-	return "f32.convert_i64_u"
-}
-
-func (self *F32ConvertI64U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xb5}
-	sink.WriteBytes(inst)
-
+func (self *I32AtomicRmw8OrU) String() string {
+	return "i32.atomic.rmw8.or_u"
 }
 
-type F32DemoteF64 struct {
+func (self *I32AtomicRmw8OrU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x35}
+	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
+
 }
 
-func (self *F32DemoteF64) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw8OrU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32DemoteF64) String() string {
-	return "f32.demote_f64"
-}
+func (self *I32AtomicRmw8OrU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *F32DemoteF64) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xb6}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32AtomicRmw8OrU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type F64ConvertI32S struct {
+type I32AtomicRmw16OrU struct {
+	MemArg MemArg
 }
 
-func (self *F64ConvertI32S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw16OrU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64ConvertI32S) String() string {
-	return "f64.convert_i32_s"
+func (self *I32AtomicRmw16OrU) String() string {
+	return "i32.atomic.rmw16.or_u"
 }
 
-func (self *F64ConvertI32S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xb7}
+func (self *I32AtomicRmw16OrU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x36}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F64ConvertI32U struct {
-}
-
-func (self *F64ConvertI32U) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw16OrU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64ConvertI32U) String() string {
-	return "f64.convert_i32_u"
-}
+func (self *I32AtomicRmw16OrU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *F64ConvertI32U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xb8}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32AtomicRmw16OrU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type F64ConvertI64S struct {
+type I64AtomicRmw8OrU struct {
+	MemArg MemArg
 }
 
-func (self *F64ConvertI64S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw8OrU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64ConvertI64S) String() string {
-	return "f64.convert_i64_s"
+func (self *I64AtomicRmw8OrU) String() string {
+	return "i64.atomic.rmw8.or_u"
 }
 
-func (self *F64ConvertI64S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xb9}
+func (self *I64AtomicRmw8OrU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x37}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F64ConvertI64U struct {
-}
-
-func (self *F64ConvertI64U) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw8OrU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64ConvertI64U) String() string {
-	return "f64.convert_i64_u"
-}
+func (self *I64AtomicRmw8OrU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *F64ConvertI64U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xba}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmw8OrU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type F64PromoteF32 struct {
+type I64AtomicRmw16OrU struct {
+	MemArg MemArg
 }
 
-func (self *F64PromoteF32) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw16OrU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64PromoteF32) String() string {
-	return "f64.promote_f32"
+func (self *I64AtomicRmw16OrU) String() string {
+	return "i64.atomic.rmw16.or_u"
 }
 
-func (self *F64PromoteF32) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xbb}
+func (self *I64AtomicRmw16OrU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x38}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32ReinterpretF32 struct {
-}
-
-func (self *I32ReinterpretF32) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw16OrU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32ReinterpretF32) String() string {
-	return "i32.reinterpret_f32"
-}
+func (self *I64AtomicRmw16OrU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *I32ReinterpretF32) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xbc}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmw16OrU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type I64ReinterpretF64 struct {
+type I64AtomicRmw32OrU struct {
+	MemArg MemArg
 }
 
-func (self *I64ReinterpretF64) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw32OrU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64ReinterpretF64) String() string {
-	return "i64.reinterpret_f64"
+func (self *I64AtomicRmw32OrU) String() string {
+	return "i64.atomic.rmw32.or_u"
 }
 
-func (self *I64ReinterpretF64) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xbd}
+func (self *I64AtomicRmw32OrU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x39}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type F32ReinterpretI32 struct {
-}
-
-func (self *F32ReinterpretI32) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw32OrU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F32ReinterpretI32) String() string {
-	return "f32.reinterpret_i32"
-}
+func (self *I64AtomicRmw32OrU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *F32ReinterpretI32) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xbe}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmw32OrU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type F64ReinterpretI64 struct {
+type I32AtomicRmwXor struct {
+	MemArg MemArg
 }
 
-func (self *F64ReinterpretI64) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmwXor) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *F64ReinterpretI64) String() string {
-	return "f64.reinterpret_i64"
+func (self *I32AtomicRmwXor) String() string {
+	return "i32.atomic.rmw.xor"
 }
 
-func (self *F64ReinterpretI64) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xbf}
+func (self *I32AtomicRmwXor) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x3a}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32TruncSatF32S struct {
-}
-
-func (self *I32TruncSatF32S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmwXor) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32TruncSatF32S) String() string {
-	return "i32.trunc_sat_f32_s"
-}
+func (self *I32AtomicRmwXor) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *I32TruncSatF32S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfc, 0x0}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32AtomicRmwXor) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type I32TruncSatF32U struct {
+type I64AtomicRmwXor struct {
+	MemArg MemArg
 }
 
-func (self *I32TruncSatF32U) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmwXor) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 8)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32TruncSatF32U) String() string {
-	return "i32.trunc_sat_f32_u"
+func (self *I64AtomicRmwXor) String() string {
+	return "i64.atomic.rmw.xor"
 }
 
-func (self *I32TruncSatF32U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfc, 0x1}
+func (self *I64AtomicRmwXor) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x3b}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32TruncSatF64S struct {
-}
-
-func (self *I32TruncSatF64S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmwXor) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32TruncSatF64S) String() string {
-	return "i32.trunc_sat_f64_s"
-}
+func (self *I64AtomicRmwXor) printInstrBody(p *printer) {
+	self.MemArg.print(p, 8)
 
-func (self *I32TruncSatF64S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfc, 0x2}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmwXor) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 8
 }
 
-type I32TruncSatF64U struct {
+type I32AtomicRmw8XorU struct {
+	MemArg MemArg
 }
 
-func (self *I32TruncSatF64U) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw8XorU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32TruncSatF64U) String() string {
-	return "i32.trunc_sat_f64_u"
+func (self *I32AtomicRmw8XorU) String() string {
+	return "i32.atomic.rmw8.xor_u"
 }
 
-func (self *I32TruncSatF64U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfc, 0x3}
+func (self *I32AtomicRmw8XorU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x3c}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64TruncSatF32S struct {
-}
-
-func (self *I64TruncSatF32S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw8XorU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64TruncSatF32S) String() string {
-	return "i64.trunc_sat_f32_s"
-}
+func (self *I32AtomicRmw8XorU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *I64TruncSatF32S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfc, 0x4}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32AtomicRmw8XorU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type I64TruncSatF32U struct {
+type I32AtomicRmw16XorU struct {
+	MemArg MemArg
 }
 
-func (self *I64TruncSatF32U) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw16XorU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64TruncSatF32U) String() string {
-	return "i64.trunc_sat_f32_u"
+func (self *I32AtomicRmw16XorU) String() string {
+	return "i32.atomic.rmw16.xor_u"
 }
 
-func (self *I64TruncSatF32U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfc, 0x5}
+func (self *I32AtomicRmw16XorU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x3d}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64TruncSatF64S struct {
-}
-
-func (self *I64TruncSatF64S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmw16XorU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64TruncSatF64S) String() string {
-	return "i64.trunc_sat_f64_s"
-}
+func (self *I32AtomicRmw16XorU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *I64TruncSatF64S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfc, 0x6}
-	sink.WriteBytes(inst)
+}
 
+func (self *I32AtomicRmw16XorU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type I64TruncSatF64U struct {
+type I64AtomicRmw8XorU struct {
+	MemArg MemArg
 }
 
-func (self *I64TruncSatF64U) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw8XorU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64TruncSatF64U) String() string {
-	return "i64.trunc_sat_f64_u"
+func (self *I64AtomicRmw8XorU) String() string {
+	return "i64.atomic.rmw8.xor_u"
 }
 
-func (self *I64TruncSatF64U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfc, 0x7}
+func (self *I64AtomicRmw8XorU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x3e}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I32Extend8S struct {
-}
-
-func (self *I32Extend8S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw8XorU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32Extend8S) String() string {
-	return "i32.extend8_s"
-}
+func (self *I64AtomicRmw8XorU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *I32Extend8S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xc0}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmw8XorU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type I32Extend16S struct {
+type I64AtomicRmw16XorU struct {
+	MemArg MemArg
 }
 
-func (self *I32Extend16S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw16XorU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I32Extend16S) String() string {
-	return "i32.extend16_s"
+func (self *I64AtomicRmw16XorU) String() string {
+	return "i64.atomic.rmw16.xor_u"
 }
 
-func (self *I32Extend16S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xc1}
+func (self *I64AtomicRmw16XorU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x3f}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64Extend8S struct {
-}
-
-func (self *I64Extend8S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw16XorU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64Extend8S) String() string {
-	return "i64.extend8_s"
-}
+func (self *I64AtomicRmw16XorU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *I64Extend8S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xc2}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmw16XorU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type I64Extend16S struct {
+type I64AtomicRmw32XorU struct {
+	MemArg MemArg
 }
 
-func (self *I64Extend16S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw32XorU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64Extend16S) String() string {
-	return "i64.extend16_s"
+func (self *I64AtomicRmw32XorU) String() string {
+	return "i64.atomic.rmw32.xor_u"
 }
 
-func (self *I64Extend16S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xc3}
+func (self *I64AtomicRmw32XorU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x40}
 	sink.WriteBytes(inst)
+	self.MemArg.Encode(sink)
 
 }
 
-type I64Extend32S struct {
-}
-
-func (self *I64Extend32S) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw32XorU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *I64Extend32S) String() string {
-	return "i64.extend32_s"
-}
+func (self *I64AtomicRmw32XorU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *I64Extend32S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xc4}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmw32XorU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type AtomicNotify struct {
+type I32AtomicRmwXchg struct {
 	MemArg MemArg
 }
 
-func (self *AtomicNotify) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I32AtomicRmwXchg) parseInstrBody(ps *parser.ParserBuffer) error {
 	err := self.MemArg.Parse(ps, 4)
 	if err != nil {
 		return err
@@ -3958,46 +8556,39 @@ func (self *AtomicNotify) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *AtomicNotify) String() string {
-	return "atomic.notify"
+func (self *I32AtomicRmwXchg) String() string {
+	return "i32.atomic.rmw.xchg"
 }
 
-func (self *AtomicNotify) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x0}
+func (self *I32AtomicRmwXchg) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x41}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
-type I32AtomicWait struct {
-	MemArg MemArg
-}
-
-func (self *I32AtomicWait) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
-	if err != nil {
+func (self *I32AtomicRmwXchg) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I32AtomicWait) String() string {
-	return "i32.atomic.wait"
-}
+func (self *I32AtomicRmwXchg) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *I32AtomicWait) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x1}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I32AtomicRmwXchg) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type I64AtomicWait struct {
+type I64AtomicRmwXchg struct {
 	MemArg MemArg
 }
 
-func (self *I64AtomicWait) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmwXchg) parseInstrBody(ps *parser.ParserBuffer) error {
 	err := self.MemArg.Parse(ps, 8)
 	if err != nil {
 		return err
@@ -4006,41 +8597,40 @@ func (self *I64AtomicWait) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I64AtomicWait) String() string {
-	return "i64.atomic.wait"
+func (self *I64AtomicRmwXchg) String() string {
+	return "i64.atomic.rmw.xchg"
 }
 
-func (self *I64AtomicWait) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x2}
+func (self *I64AtomicRmwXchg) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x42}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
-type AtomicFence struct {
-}
-
-func (self *AtomicFence) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmwXchg) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (self *AtomicFence) String() string {
-	return "atomic.fence"
-}
+func (self *I64AtomicRmwXchg) printInstrBody(p *printer) {
+	self.MemArg.print(p, 8)
 
-func (self *AtomicFence) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x3}
-	sink.WriteBytes(inst)
+}
 
+func (self *I64AtomicRmwXchg) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 8
 }
 
-type I32AtomicLoad struct {
+type I32AtomicRmw8XchgU struct {
 	MemArg MemArg
 }
 
-func (self *I32AtomicLoad) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
+func (self *I32AtomicRmw8XchgU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
 	if err != nil {
 		return err
 	}
@@ -4048,47 +8638,40 @@ func (self *I32AtomicLoad) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I32AtomicLoad) String() string {
-	return "i32.atomic.load"
+func (self *I32AtomicRmw8XchgU) String() string {
+	return "i32.atomic.rmw8.xchg_u"
 }
 
-func (self *I32AtomicLoad) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x10}
+func (self *I32AtomicRmw8XchgU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x43}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
-type I64AtomicLoad struct {
-	MemArg MemArg
-}
-
-func (self *I64AtomicLoad) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 8)
-	if err != nil {
+func (self *I32AtomicRmw8XchgU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I64AtomicLoad) String() string {
-	return "i64.atomic.load"
-}
+func (self *I32AtomicRmw8XchgU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *I64AtomicLoad) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x11}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I32AtomicRmw8XchgU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type I32AtomicLoad8u struct {
+type I32AtomicRmw16XchgU struct {
 	MemArg MemArg
 }
 
-func (self *I32AtomicLoad8u) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
+func (self *I32AtomicRmw16XchgU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
 	if err != nil {
 		return err
 	}
@@ -4096,46 +8679,39 @@ func (self *I32AtomicLoad8u) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I32AtomicLoad8u) String() string {
-	return "i32.atomic.load8_u"
+func (self *I32AtomicRmw16XchgU) String() string {
+	return "i32.atomic.rmw16.xchg_u"
 }
 
-func (self *I32AtomicLoad8u) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x12}
+func (self *I32AtomicRmw16XchgU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x44}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
-type I32AtomicLoad16u struct {
-	MemArg MemArg
-}
-
-func (self *I32AtomicLoad16u) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
-	if err != nil {
+func (self *I32AtomicRmw16XchgU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I32AtomicLoad16u) String() string {
-	return "i32.atomic.load16_u"
-}
+func (self *I32AtomicRmw16XchgU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *I32AtomicLoad16u) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x13}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I32AtomicRmw16XchgU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type I64AtomicLoad8u struct {
+type I64AtomicRmw8XchgU struct {
 	MemArg MemArg
 }
 
-func (self *I64AtomicLoad8u) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw8XchgU) parseInstrBody(ps *parser.ParserBuffer) error {
 	err := self.MemArg.Parse(ps, 1)
 	if err != nil {
 		return err
@@ -4144,22 +8720,39 @@ func (self *I64AtomicLoad8u) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I64AtomicLoad8u) String() string {
-	return "i64.atomic.load8_u"
+func (self *I64AtomicRmw8XchgU) String() string {
+	return "i64.atomic.rmw8.xchg_u"
 }
 
-func (self *I64AtomicLoad8u) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x14}
+func (self *I64AtomicRmw8XchgU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x45}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
-type I64AtomicLoad16u struct {
+func (self *I64AtomicRmw8XchgU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I64AtomicRmw8XchgU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
+
+}
+
+func (self *I64AtomicRmw8XchgU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
+}
+
+type I64AtomicRmw16XchgU struct {
 	MemArg MemArg
 }
 
-func (self *I64AtomicLoad16u) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw16XchgU) parseInstrBody(ps *parser.ParserBuffer) error {
 	err := self.MemArg.Parse(ps, 2)
 	if err != nil {
 		return err
@@ -4168,22 +8761,39 @@ func (self *I64AtomicLoad16u) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I64AtomicLoad16u) String() string {
-	return "i64.atomic.load16_u"
+func (self *I64AtomicRmw16XchgU) String() string {
+	return "i64.atomic.rmw16.xchg_u"
 }
 
-func (self *I64AtomicLoad16u) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x15}
+func (self *I64AtomicRmw16XchgU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x46}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
-type I64AtomicLoad32u struct {
+func (self *I64AtomicRmw16XchgU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I64AtomicRmw16XchgU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
+
+}
+
+func (self *I64AtomicRmw16XchgU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
+}
+
+type I64AtomicRmw32XchgU struct {
 	MemArg MemArg
 }
 
-func (self *I64AtomicLoad32u) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw32XchgU) parseInstrBody(ps *parser.ParserBuffer) error {
 	err := self.MemArg.Parse(ps, 4)
 	if err != nil {
 		return err
@@ -4192,47 +8802,40 @@ func (self *I64AtomicLoad32u) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I64AtomicLoad32u) String() string {
-	return "i64.atomic.load32_u"
+func (self *I64AtomicRmw32XchgU) String() string {
+	return "i64.atomic.rmw32.xchg_u"
 }
 
-func (self *I64AtomicLoad32u) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x16}
+func (self *I64AtomicRmw32XchgU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x47}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
-type I32AtomicStore struct {
-	MemArg MemArg
-}
-
-func (self *I32AtomicStore) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
-	if err != nil {
+func (self *I64AtomicRmw32XchgU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I32AtomicStore) String() string {
-	return "i32.atomic.store"
-}
+func (self *I64AtomicRmw32XchgU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *I32AtomicStore) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x17}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I64AtomicRmw32XchgU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type I64AtomicStore struct {
+type I32AtomicRmwCmpxchg struct {
 	MemArg MemArg
 }
 
-func (self *I64AtomicStore) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 8)
+func (self *I32AtomicRmwCmpxchg) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 4)
 	if err != nil {
 		return err
 	}
@@ -4240,47 +8843,40 @@ func (self *I64AtomicStore) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I64AtomicStore) String() string {
-	return "i64.atomic.store"
+func (self *I32AtomicRmwCmpxchg) String() string {
+	return "i32.atomic.rmw.cmpxchg"
 }
 
-func (self *I64AtomicStore) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x18}
+func (self *I32AtomicRmwCmpxchg) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x48}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
-type I32AtomicStore8 struct {
-	MemArg MemArg
-}
-
-func (self *I32AtomicStore8) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
-	if err != nil {
+func (self *I32AtomicRmwCmpxchg) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I32AtomicStore8) String() string {
-	return "i32.atomic.store8"
-}
+func (self *I32AtomicRmwCmpxchg) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *I32AtomicStore8) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x19}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I32AtomicRmwCmpxchg) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type I32AtomicStore16 struct {
+type I64AtomicRmwCmpxchg struct {
 	MemArg MemArg
 }
 
-func (self *I32AtomicStore16) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
+func (self *I64AtomicRmwCmpxchg) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 8)
 	if err != nil {
 		return err
 	}
@@ -4288,47 +8884,40 @@ func (self *I32AtomicStore16) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I32AtomicStore16) String() string {
-	return "i32.atomic.store16"
+func (self *I64AtomicRmwCmpxchg) String() string {
+	return "i64.atomic.rmw.cmpxchg"
 }
 
-func (self *I32AtomicStore16) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x1a}
+func (self *I64AtomicRmwCmpxchg) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x49}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
-type I64AtomicStore8 struct {
-	MemArg MemArg
-}
-
-func (self *I64AtomicStore8) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
-	if err != nil {
+func (self *I64AtomicRmwCmpxchg) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I64AtomicStore8) String() string {
-	return "i64.atomic.store8"
-}
+func (self *I64AtomicRmwCmpxchg) printInstrBody(p *printer) {
+	self.MemArg.print(p, 8)
 
-func (self *I64AtomicStore8) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x1b}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I64AtomicRmwCmpxchg) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 8
 }
 
-type I64AtomicStore16 struct {
+type I32AtomicRmw8CmpxchgU struct {
 	MemArg MemArg
 }
 
-func (self *I64AtomicStore16) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
+func (self *I32AtomicRmw8CmpxchgU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 1)
 	if err != nil {
 		return err
 	}
@@ -4336,47 +8925,40 @@ func (self *I64AtomicStore16) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I64AtomicStore16) String() string {
-	return "i64.atomic.store16"
+func (self *I32AtomicRmw8CmpxchgU) String() string {
+	return "i32.atomic.rmw8.cmpxchg_u"
 }
 
-func (self *I64AtomicStore16) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x1c}
+func (self *I32AtomicRmw8CmpxchgU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x4a}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
-type I64AtomicStore32 struct {
-	MemArg MemArg
-}
-
-func (self *I64AtomicStore32) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
-	if err != nil {
+func (self *I32AtomicRmw8CmpxchgU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I64AtomicStore32) String() string {
-	return "i64.atomic.store32"
-}
+func (self *I32AtomicRmw8CmpxchgU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *I64AtomicStore32) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x1d}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I32AtomicRmw8CmpxchgU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type I32AtomicRmwAdd struct {
+type I32AtomicRmw16CmpxchgU struct {
 	MemArg MemArg
 }
 
-func (self *I32AtomicRmwAdd) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
+func (self *I32AtomicRmw16CmpxchgU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
 	if err != nil {
 		return err
 	}
@@ -4384,46 +8966,39 @@ func (self *I32AtomicRmwAdd) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I32AtomicRmwAdd) String() string {
-	return "i32.atomic.rmw.add"
+func (self *I32AtomicRmw16CmpxchgU) String() string {
+	return "i32.atomic.rmw16.cmpxchg_u"
 }
 
-func (self *I32AtomicRmwAdd) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x1e}
+func (self *I32AtomicRmw16CmpxchgU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x4b}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
-type I64AtomicRmwAdd struct {
-	MemArg MemArg
-}
-
-func (self *I64AtomicRmwAdd) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 8)
-	if err != nil {
+func (self *I32AtomicRmw16CmpxchgU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I64AtomicRmwAdd) String() string {
-	return "i64.atomic.rmw.add"
-}
+func (self *I32AtomicRmw16CmpxchgU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *I64AtomicRmwAdd) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x1f}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I32AtomicRmw16CmpxchgU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type I32AtomicRmw8AddU struct {
+type I64AtomicRmw8CmpxchgU struct {
 	MemArg MemArg
 }
 
-func (self *I32AtomicRmw8AddU) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw8CmpxchgU) parseInstrBody(ps *parser.ParserBuffer) error {
 	err := self.MemArg.Parse(ps, 1)
 	if err != nil {
 		return err
@@ -4432,47 +9007,40 @@ func (self *I32AtomicRmw8AddU) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I32AtomicRmw8AddU) String() string {
-	return "i32.atomic.rmw8.add_u"
+func (self *I64AtomicRmw8CmpxchgU) String() string {
+	return "i64.atomic.rmw8.cmpxchg_u"
 }
 
-func (self *I32AtomicRmw8AddU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x20}
+func (self *I64AtomicRmw8CmpxchgU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x4c}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
-type I32AtomicRmw16AddU struct {
-	MemArg MemArg
-}
-
-func (self *I32AtomicRmw16AddU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
-	if err != nil {
+func (self *I64AtomicRmw8CmpxchgU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I32AtomicRmw16AddU) String() string {
-	return "i32.atomic.rmw16.add_u"
-}
+func (self *I64AtomicRmw8CmpxchgU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
 
-func (self *I32AtomicRmw16AddU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x21}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I64AtomicRmw8CmpxchgU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
 }
 
-type I64AtomicRmw8AddU struct {
+type I64AtomicRmw16CmpxchgU struct {
 	MemArg MemArg
 }
 
-func (self *I64AtomicRmw8AddU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
+func (self *I64AtomicRmw16CmpxchgU) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 2)
 	if err != nil {
 		return err
 	}
@@ -4480,46 +9048,39 @@ func (self *I64AtomicRmw8AddU) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I64AtomicRmw8AddU) String() string {
-	return "i64.atomic.rmw8.add_u"
+func (self *I64AtomicRmw16CmpxchgU) String() string {
+	return "i64.atomic.rmw16.cmpxchg_u"
 }
 
-func (self *I64AtomicRmw8AddU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x22}
+func (self *I64AtomicRmw16CmpxchgU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x4d}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
-type I64AtomicRmw16AddU struct {
-	MemArg MemArg
-}
-
-func (self *I64AtomicRmw16AddU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
-	if err != nil {
+func (self *I64AtomicRmw16CmpxchgU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I64AtomicRmw16AddU) String() string {
-	return "i64.atomic.rmw16.add_u"
-}
+func (self *I64AtomicRmw16CmpxchgU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
 
-func (self *I64AtomicRmw16AddU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x23}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I64AtomicRmw16CmpxchgU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
 }
 
-type I64AtomicRmw32AddU struct {
+type I64AtomicRmw32CmpxchgU struct {
 	MemArg MemArg
 }
 
-func (self *I64AtomicRmw32AddU) parseInstrBody(ps *parser.ParserBuffer) error {
+func (self *I64AtomicRmw32CmpxchgU) parseInstrBody(ps *parser.ParserBuffer) error {
 	err := self.MemArg.Parse(ps, 4)
 	if err != nil {
 		return err
@@ -4528,47 +9089,40 @@ func (self *I64AtomicRmw32AddU) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I64AtomicRmw32AddU) String() string {
-	return "i64.atomic.rmw32.add_u"
+func (self *I64AtomicRmw32CmpxchgU) String() string {
+	return "i64.atomic.rmw32.cmpxchg_u"
 }
 
-func (self *I64AtomicRmw32AddU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x24}
+func (self *I64AtomicRmw32CmpxchgU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfe, 0x4e}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
-type I32AtomicRmwSub struct {
-	MemArg MemArg
-}
-
-func (self *I32AtomicRmwSub) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
-	if err != nil {
+func (self *I64AtomicRmw32CmpxchgU) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I32AtomicRmwSub) String() string {
-	return "i32.atomic.rmw.sub"
-}
+func (self *I64AtomicRmw32CmpxchgU) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
 
-func (self *I32AtomicRmwSub) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x25}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I64AtomicRmw32CmpxchgU) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
 }
 
-type I64AtomicRmwSub struct {
+type V128Load struct {
 	MemArg MemArg
 }
 
-func (self *I64AtomicRmwSub) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 8)
+func (self *V128Load) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 16)
 	if err != nil {
 		return err
 	}
@@ -4576,47 +9130,40 @@ func (self *I64AtomicRmwSub) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I64AtomicRmwSub) String() string {
-	return "i64.atomic.rmw.sub"
+func (self *V128Load) String() string {
+	return "v128.load"
 }
 
-func (self *I64AtomicRmwSub) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x26}
+func (self *V128Load) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x0}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
-type I32AtomicRmw8SubU struct {
-	MemArg MemArg
-}
-
-func (self *I32AtomicRmw8SubU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
-	if err != nil {
+func (self *V128Load) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I32AtomicRmw8SubU) String() string {
-	return "i32.atomic.rmw8.sub_u"
-}
+func (self *V128Load) printInstrBody(p *printer) {
+	self.MemArg.print(p, 16)
 
-func (self *I32AtomicRmw8SubU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x27}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *V128Load) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 16
 }
 
-type I32AtomicRmw16SubU struct {
+type V128Store struct {
 	MemArg MemArg
 }
 
-func (self *I32AtomicRmw16SubU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
+func (self *V128Store) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.MemArg.Parse(ps, 16)
 	if err != nil {
 		return err
 	}
@@ -4624,47 +9171,40 @@ func (self *I32AtomicRmw16SubU) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I32AtomicRmw16SubU) String() string {
-	return "i32.atomic.rmw16.sub_u"
+func (self *V128Store) String() string {
+	return "v128.store"
 }
 
-func (self *I32AtomicRmw16SubU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x28}
+func (self *V128Store) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x1}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
-type I64AtomicRmw8SubU struct {
-	MemArg MemArg
-}
-
-func (self *I64AtomicRmw8SubU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
-	if err != nil {
+func (self *V128Store) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I64AtomicRmw8SubU) String() string {
-	return "i64.atomic.rmw8.sub_u"
-}
+func (self *V128Store) printInstrBody(p *printer) {
+	self.MemArg.print(p, 16)
 
-func (self *I64AtomicRmw8SubU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x29}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *V128Store) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 16
 }
 
-type I64AtomicRmw16SubU struct {
-	MemArg MemArg
+type V128Const struct {
+	Val V128Bits
 }
 
-func (self *I64AtomicRmw16SubU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
+func (self *V128Const) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Val.Parse(ps)
 	if err != nil {
 		return err
 	}
@@ -4672,927 +9212,806 @@ func (self *I64AtomicRmw16SubU) parseInstrBody(ps *parser.ParserBuffer) error {
 	return nil
 }
 
-func (self *I64AtomicRmw16SubU) String() string {
-	return "i64.atomic.rmw16.sub_u"
+func (self *V128Const) String() string {
+	return "v128.const"
 }
 
-func (self *I64AtomicRmw16SubU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x2a}
+func (self *V128Const) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x2}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	self.Val.Encode(sink)
 
-type I64AtomicRmw32SubU struct {
-	MemArg MemArg
 }
 
-func (self *I64AtomicRmw32SubU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
-	if err != nil {
+func (self *V128Const) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Val.Decode(source); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (self *I64AtomicRmw32SubU) String() string {
-	return "i64.atomic.rmw32.sub_u"
-}
-
-func (self *I64AtomicRmw32SubU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x2b}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+func (self *V128Const) printInstrBody(p *printer) {
+	self.Val.print(p)
 
 }
 
-type I32AtomicRmwAnd struct {
-	MemArg MemArg
+type I8x16Splat struct {
 }
 
-func (self *I32AtomicRmwAnd) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
-	if err != nil {
-		return err
-	}
+func (self *I8x16Splat) parseInstrBody(ps *parser.ParserBuffer) error {
 
 	return nil
 }
 
-func (self *I32AtomicRmwAnd) String() string {
-	return "i32.atomic.rmw.and"
+func (self *I8x16Splat) String() string {
+	return "i8x16.splat"
 }
 
-func (self *I32AtomicRmwAnd) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x2c}
+func (self *I8x16Splat) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x4}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
 
 }
 
-type I64AtomicRmwAnd struct {
-	MemArg MemArg
+func (self *I8x16Splat) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
 }
 
-func (self *I64AtomicRmwAnd) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 8)
+func (self *I8x16Splat) printInstrBody(p *printer) {
+
+}
+
+type I8x16ExtractLaneS struct {
+	Lane uint8
+}
+
+func (self *I8x16ExtractLaneS) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectUint8()
 	if err != nil {
 		return err
 	}
+	self.Lane = val
 
 	return nil
 }
 
-func (self *I64AtomicRmwAnd) String() string {
-	return "i64.atomic.rmw.and"
+func (self *I8x16ExtractLaneS) String() string {
+	return "i8x16.extract_lane_s"
 }
 
-func (self *I64AtomicRmwAnd) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x2d}
+func (self *I8x16ExtractLaneS) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x5}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	sink.WriteByte(self.Lane)
 
-type I32AtomicRmw8AndU struct {
-	MemArg MemArg
 }
 
-func (self *I32AtomicRmw8AndU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
+func (self *I8x16ExtractLaneS) decodeInstrBody(source *ZeroCopySource) error {
+	lane, err := source.ReadByte()
 	if err != nil {
 		return err
 	}
+	self.Lane = lane
 
 	return nil
 }
 
-func (self *I32AtomicRmw8AndU) String() string {
-	return "i32.atomic.rmw8.and_u"
-}
+func (self *I8x16ExtractLaneS) printInstrBody(p *printer) {
+	p.word(strconv.Itoa(int(self.Lane)))
 
-func (self *I32AtomicRmw8AndU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x2e}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I8x16ExtractLaneS) lane() (uint8, uint8) {
+	return self.Lane, 16
 }
 
-type I32AtomicRmw16AndU struct {
-	MemArg MemArg
+type I8x16ExtractLaneU struct {
+	Lane uint8
 }
 
-func (self *I32AtomicRmw16AndU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
+func (self *I8x16ExtractLaneU) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectUint8()
 	if err != nil {
 		return err
 	}
+	self.Lane = val
 
 	return nil
 }
 
-func (self *I32AtomicRmw16AndU) String() string {
-	return "i32.atomic.rmw16.and_u"
+func (self *I8x16ExtractLaneU) String() string {
+	return "i8x16.extract_lane_u"
 }
 
-func (self *I32AtomicRmw16AndU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x2f}
+func (self *I8x16ExtractLaneU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x6}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	sink.WriteByte(self.Lane)
 
-type I64AtomicRmw8AndU struct {
-	MemArg MemArg
 }
 
-func (self *I64AtomicRmw8AndU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
+func (self *I8x16ExtractLaneU) decodeInstrBody(source *ZeroCopySource) error {
+	lane, err := source.ReadByte()
 	if err != nil {
 		return err
 	}
+	self.Lane = lane
 
 	return nil
 }
 
-func (self *I64AtomicRmw8AndU) String() string {
-	return "i64.atomic.rmw8.and_u"
-}
+func (self *I8x16ExtractLaneU) printInstrBody(p *printer) {
+	p.word(strconv.Itoa(int(self.Lane)))
 
-func (self *I64AtomicRmw8AndU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x30}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I8x16ExtractLaneU) lane() (uint8, uint8) {
+	return self.Lane, 16
 }
 
-type I64AtomicRmw16AndU struct {
-	MemArg MemArg
+type I8x16ReplaceLane struct {
+	Lane uint8
 }
 
-func (self *I64AtomicRmw16AndU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
+func (self *I8x16ReplaceLane) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectUint8()
 	if err != nil {
 		return err
 	}
+	self.Lane = val
 
 	return nil
 }
 
-func (self *I64AtomicRmw16AndU) String() string {
-	return "i64.atomic.rmw16.and_u"
+func (self *I8x16ReplaceLane) String() string {
+	return "i8x16.replace_lane"
 }
 
-func (self *I64AtomicRmw16AndU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x31}
+func (self *I8x16ReplaceLane) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x7}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	sink.WriteByte(self.Lane)
 
-type I64AtomicRmw32AndU struct {
-	MemArg MemArg
 }
 
-func (self *I64AtomicRmw32AndU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
+func (self *I8x16ReplaceLane) decodeInstrBody(source *ZeroCopySource) error {
+	lane, err := source.ReadByte()
 	if err != nil {
 		return err
 	}
+	self.Lane = lane
 
 	return nil
 }
 
-func (self *I64AtomicRmw32AndU) String() string {
-	return "i64.atomic.rmw32.and_u"
-}
+func (self *I8x16ReplaceLane) printInstrBody(p *printer) {
+	p.word(strconv.Itoa(int(self.Lane)))
 
-func (self *I64AtomicRmw32AndU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x32}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I8x16ReplaceLane) lane() (uint8, uint8) {
+	return self.Lane, 16
 }
 
-type I32AtomicRmwOr struct {
-	MemArg MemArg
+type I16x8Splat struct {
 }
 
-func (self *I32AtomicRmwOr) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
-	if err != nil {
-		return err
-	}
+func (self *I16x8Splat) parseInstrBody(ps *parser.ParserBuffer) error {
 
 	return nil
 }
 
-func (self *I32AtomicRmwOr) String() string {
-	return "i32.atomic.rmw.or"
+func (self *I16x8Splat) String() string {
+	return "i16x8.splat"
 }
 
-func (self *I32AtomicRmwOr) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x33}
+func (self *I16x8Splat) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x8}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
 
 }
 
-type I64AtomicRmwOr struct {
-	MemArg MemArg
+func (self *I16x8Splat) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
 }
 
-func (self *I64AtomicRmwOr) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 8)
+func (self *I16x8Splat) printInstrBody(p *printer) {
+
+}
+
+type I16x8ExtractLaneS struct {
+	Lane uint8
+}
+
+func (self *I16x8ExtractLaneS) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectUint8()
 	if err != nil {
 		return err
 	}
+	self.Lane = val
 
 	return nil
 }
 
-func (self *I64AtomicRmwOr) String() string {
-	return "i64.atomic.rmw.or"
+func (self *I16x8ExtractLaneS) String() string {
+	return "i16x8.extract_lane_s"
 }
 
-func (self *I64AtomicRmwOr) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x34}
+func (self *I16x8ExtractLaneS) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x9}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	sink.WriteByte(self.Lane)
 
-type I32AtomicRmw8OrU struct {
-	MemArg MemArg
 }
 
-func (self *I32AtomicRmw8OrU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
+func (self *I16x8ExtractLaneS) decodeInstrBody(source *ZeroCopySource) error {
+	lane, err := source.ReadByte()
 	if err != nil {
 		return err
 	}
+	self.Lane = lane
 
 	return nil
 }
 
-func (self *I32AtomicRmw8OrU) String() string {
-	return "i32.atomic.rmw8.or_u"
-}
+func (self *I16x8ExtractLaneS) printInstrBody(p *printer) {
+	p.word(strconv.Itoa(int(self.Lane)))
 
-func (self *I32AtomicRmw8OrU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x35}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I16x8ExtractLaneS) lane() (uint8, uint8) {
+	return self.Lane, 8
 }
 
-type I32AtomicRmw16OrU struct {
-	MemArg MemArg
+type I16x8ExtractLaneU struct {
+	Lane uint8
 }
 
-func (self *I32AtomicRmw16OrU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
+func (self *I16x8ExtractLaneU) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectUint8()
 	if err != nil {
 		return err
 	}
+	self.Lane = val
 
 	return nil
 }
 
-func (self *I32AtomicRmw16OrU) String() string {
-	return "i32.atomic.rmw16.or_u"
+func (self *I16x8ExtractLaneU) String() string {
+	return "i16x8.extract_lane_u"
 }
 
-func (self *I32AtomicRmw16OrU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x36}
+func (self *I16x8ExtractLaneU) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0xa}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	sink.WriteByte(self.Lane)
 
-type I64AtomicRmw8OrU struct {
-	MemArg MemArg
 }
 
-func (self *I64AtomicRmw8OrU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
+func (self *I16x8ExtractLaneU) decodeInstrBody(source *ZeroCopySource) error {
+	lane, err := source.ReadByte()
 	if err != nil {
 		return err
 	}
+	self.Lane = lane
 
 	return nil
 }
 
-func (self *I64AtomicRmw8OrU) String() string {
-	return "i64.atomic.rmw8.or_u"
-}
+func (self *I16x8ExtractLaneU) printInstrBody(p *printer) {
+	p.word(strconv.Itoa(int(self.Lane)))
 
-func (self *I64AtomicRmw8OrU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x37}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I16x8ExtractLaneU) lane() (uint8, uint8) {
+	return self.Lane, 8
 }
 
-type I64AtomicRmw16OrU struct {
-	MemArg MemArg
+type I16x8ReplaceLane struct {
+	Lane uint8
 }
 
-func (self *I64AtomicRmw16OrU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
+func (self *I16x8ReplaceLane) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectUint8()
 	if err != nil {
 		return err
 	}
+	self.Lane = val
 
 	return nil
 }
 
-func (self *I64AtomicRmw16OrU) String() string {
-	return "i64.atomic.rmw16.or_u"
+func (self *I16x8ReplaceLane) String() string {
+	return "i16x8.replace_lane"
 }
 
-func (self *I64AtomicRmw16OrU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x38}
+func (self *I16x8ReplaceLane) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0xb}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	sink.WriteByte(self.Lane)
 
-type I64AtomicRmw32OrU struct {
-	MemArg MemArg
 }
 
-func (self *I64AtomicRmw32OrU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
+func (self *I16x8ReplaceLane) decodeInstrBody(source *ZeroCopySource) error {
+	lane, err := source.ReadByte()
 	if err != nil {
 		return err
 	}
+	self.Lane = lane
 
 	return nil
 }
 
-func (self *I64AtomicRmw32OrU) String() string {
-	return "i64.atomic.rmw32.or_u"
-}
+func (self *I16x8ReplaceLane) printInstrBody(p *printer) {
+	p.word(strconv.Itoa(int(self.Lane)))
 
-func (self *I64AtomicRmw32OrU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x39}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I16x8ReplaceLane) lane() (uint8, uint8) {
+	return self.Lane, 8
 }
 
-type I32AtomicRmwXor struct {
-	MemArg MemArg
+type I32x4Splat struct {
 }
 
-func (self *I32AtomicRmwXor) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
-	if err != nil {
-		return err
-	}
+func (self *I32x4Splat) parseInstrBody(ps *parser.ParserBuffer) error {
 
 	return nil
 }
 
-func (self *I32AtomicRmwXor) String() string {
-	return "i32.atomic.rmw.xor"
+func (self *I32x4Splat) String() string {
+	return "i32x4.splat"
 }
 
-func (self *I32AtomicRmwXor) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x3a}
+func (self *I32x4Splat) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0xc}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
 
-type I64AtomicRmwXor struct {
-	MemArg MemArg
 }
 
-func (self *I64AtomicRmwXor) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 8)
-	if err != nil {
-		return err
-	}
+func (self *I32x4Splat) decodeInstrBody(source *ZeroCopySource) error {
 
 	return nil
 }
 
-func (self *I64AtomicRmwXor) String() string {
-	return "i64.atomic.rmw.xor"
-}
-
-func (self *I64AtomicRmwXor) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x3b}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+func (self *I32x4Splat) printInstrBody(p *printer) {
 
 }
 
-type I32AtomicRmw8XorU struct {
-	MemArg MemArg
+type I32x4ExtractLane struct {
+	Lane uint8
 }
 
-func (self *I32AtomicRmw8XorU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
+func (self *I32x4ExtractLane) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectUint8()
 	if err != nil {
 		return err
 	}
+	self.Lane = val
 
 	return nil
 }
 
-func (self *I32AtomicRmw8XorU) String() string {
-	return "i32.atomic.rmw8.xor_u"
+func (self *I32x4ExtractLane) String() string {
+	return "i32x4.extract_lane"
 }
 
-func (self *I32AtomicRmw8XorU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x3c}
+func (self *I32x4ExtractLane) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0xd}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	sink.WriteByte(self.Lane)
 
-type I32AtomicRmw16XorU struct {
-	MemArg MemArg
 }
 
-func (self *I32AtomicRmw16XorU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
+func (self *I32x4ExtractLane) decodeInstrBody(source *ZeroCopySource) error {
+	lane, err := source.ReadByte()
 	if err != nil {
 		return err
 	}
+	self.Lane = lane
 
 	return nil
 }
 
-func (self *I32AtomicRmw16XorU) String() string {
-	return "i32.atomic.rmw16.xor_u"
-}
+func (self *I32x4ExtractLane) printInstrBody(p *printer) {
+	p.word(strconv.Itoa(int(self.Lane)))
 
-func (self *I32AtomicRmw16XorU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x3d}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I32x4ExtractLane) lane() (uint8, uint8) {
+	return self.Lane, 4
 }
 
-type I64AtomicRmw8XorU struct {
-	MemArg MemArg
+type I32x4ReplaceLane struct {
+	Lane uint8
 }
 
-func (self *I64AtomicRmw8XorU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
+func (self *I32x4ReplaceLane) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectUint8()
 	if err != nil {
 		return err
 	}
+	self.Lane = val
 
 	return nil
 }
 
-func (self *I64AtomicRmw8XorU) String() string {
-	return "i64.atomic.rmw8.xor_u"
+func (self *I32x4ReplaceLane) String() string {
+	return "i32x4.replace_lane"
 }
 
-func (self *I64AtomicRmw8XorU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x3e}
+func (self *I32x4ReplaceLane) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0xe}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+	sink.WriteByte(self.Lane)
 
 }
 
-type I64AtomicRmw16XorU struct {
-	MemArg MemArg
-}
-
-func (self *I64AtomicRmw16XorU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
+func (self *I32x4ReplaceLane) decodeInstrBody(source *ZeroCopySource) error {
+	lane, err := source.ReadByte()
 	if err != nil {
 		return err
 	}
+	self.Lane = lane
 
 	return nil
 }
 
-func (self *I64AtomicRmw16XorU) String() string {
-	return "i64.atomic.rmw16.xor_u"
-}
+func (self *I32x4ReplaceLane) printInstrBody(p *printer) {
+	p.word(strconv.Itoa(int(self.Lane)))
 
-func (self *I64AtomicRmw16XorU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x3f}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I32x4ReplaceLane) lane() (uint8, uint8) {
+	return self.Lane, 4
 }
 
-type I64AtomicRmw32XorU struct {
-	MemArg MemArg
+type I64x2Splat struct {
 }
 
-func (self *I64AtomicRmw32XorU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
-	if err != nil {
-		return err
-	}
+func (self *I64x2Splat) parseInstrBody(ps *parser.ParserBuffer) error {
 
 	return nil
 }
 
-func (self *I64AtomicRmw32XorU) String() string {
-	return "i64.atomic.rmw32.xor_u"
+func (self *I64x2Splat) String() string {
+	return "i64x2.splat"
 }
 
-func (self *I64AtomicRmw32XorU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x40}
+func (self *I64x2Splat) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0xf}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
 
-type I32AtomicRmwXchg struct {
-	MemArg MemArg
 }
 
-func (self *I32AtomicRmwXchg) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
-	if err != nil {
-		return err
-	}
+func (self *I64x2Splat) decodeInstrBody(source *ZeroCopySource) error {
 
 	return nil
 }
 
-func (self *I32AtomicRmwXchg) String() string {
-	return "i32.atomic.rmw.xchg"
-}
-
-func (self *I32AtomicRmwXchg) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x41}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+func (self *I64x2Splat) printInstrBody(p *printer) {
 
 }
 
-type I64AtomicRmwXchg struct {
-	MemArg MemArg
+type I64x2ExtractLane struct {
+	Lane uint8
 }
 
-func (self *I64AtomicRmwXchg) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 8)
+func (self *I64x2ExtractLane) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectUint8()
 	if err != nil {
 		return err
 	}
+	self.Lane = val
 
 	return nil
 }
 
-func (self *I64AtomicRmwXchg) String() string {
-	return "i64.atomic.rmw.xchg"
+func (self *I64x2ExtractLane) String() string {
+	return "i64x2.extract_lane"
 }
 
-func (self *I64AtomicRmwXchg) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x42}
+func (self *I64x2ExtractLane) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x10}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+	sink.WriteByte(self.Lane)
 
 }
 
-type I32AtomicRmw8XchgU struct {
-	MemArg MemArg
-}
-
-func (self *I32AtomicRmw8XchgU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
+func (self *I64x2ExtractLane) decodeInstrBody(source *ZeroCopySource) error {
+	lane, err := source.ReadByte()
 	if err != nil {
 		return err
 	}
+	self.Lane = lane
 
 	return nil
 }
 
-func (self *I32AtomicRmw8XchgU) String() string {
-	return "i32.atomic.rmw8.xchg_u"
-}
+func (self *I64x2ExtractLane) printInstrBody(p *printer) {
+	p.word(strconv.Itoa(int(self.Lane)))
 
-func (self *I32AtomicRmw8XchgU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x43}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I64x2ExtractLane) lane() (uint8, uint8) {
+	return self.Lane, 2
 }
 
-type I32AtomicRmw16XchgU struct {
-	MemArg MemArg
+type I64x2ReplaceLane struct {
+	Lane uint8
 }
 
-func (self *I32AtomicRmw16XchgU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
+func (self *I64x2ReplaceLane) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectUint8()
 	if err != nil {
 		return err
 	}
+	self.Lane = val
 
 	return nil
 }
 
-func (self *I32AtomicRmw16XchgU) String() string {
-	return "i32.atomic.rmw16.xchg_u"
+func (self *I64x2ReplaceLane) String() string {
+	return "i64x2.replace_lane"
 }
 
-func (self *I32AtomicRmw16XchgU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x44}
+func (self *I64x2ReplaceLane) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x11}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	sink.WriteByte(self.Lane)
 
-type I64AtomicRmw8XchgU struct {
-	MemArg MemArg
 }
 
-func (self *I64AtomicRmw8XchgU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
+func (self *I64x2ReplaceLane) decodeInstrBody(source *ZeroCopySource) error {
+	lane, err := source.ReadByte()
 	if err != nil {
 		return err
 	}
+	self.Lane = lane
 
 	return nil
 }
 
-func (self *I64AtomicRmw8XchgU) String() string {
-	return "i64.atomic.rmw8.xchg_u"
-}
+func (self *I64x2ReplaceLane) printInstrBody(p *printer) {
+	p.word(strconv.Itoa(int(self.Lane)))
 
-func (self *I64AtomicRmw8XchgU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x45}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *I64x2ReplaceLane) lane() (uint8, uint8) {
+	return self.Lane, 2
 }
 
-type I64AtomicRmw16XchgU struct {
-	MemArg MemArg
+type F32x4Splat struct {
 }
 
-func (self *I64AtomicRmw16XchgU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
-	if err != nil {
-		return err
-	}
+func (self *F32x4Splat) parseInstrBody(ps *parser.ParserBuffer) error {
 
 	return nil
 }
 
-func (self *I64AtomicRmw16XchgU) String() string {
-	return "i64.atomic.rmw16.xchg_u"
+func (self *F32x4Splat) String() string {
+	return "f32x4.splat"
 }
 
-func (self *I64AtomicRmw16XchgU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x46}
+func (self *F32x4Splat) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x12}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
 
-type I64AtomicRmw32XchgU struct {
-	MemArg MemArg
 }
 
-func (self *I64AtomicRmw32XchgU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
-	if err != nil {
-		return err
-	}
+func (self *F32x4Splat) decodeInstrBody(source *ZeroCopySource) error {
 
 	return nil
 }
 
-func (self *I64AtomicRmw32XchgU) String() string {
-	return "i64.atomic.rmw32.xchg_u"
-}
-
-func (self *I64AtomicRmw32XchgU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x47}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+func (self *F32x4Splat) printInstrBody(p *printer) {
 
 }
 
-type I32AtomicRmwCmpxchg struct {
-	MemArg MemArg
+type F32x4ExtractLane struct {
+	Lane uint8
 }
 
-func (self *I32AtomicRmwCmpxchg) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
+func (self *F32x4ExtractLane) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectUint8()
 	if err != nil {
 		return err
 	}
+	self.Lane = val
 
 	return nil
 }
 
-func (self *I32AtomicRmwCmpxchg) String() string {
-	return "i32.atomic.rmw.cmpxchg"
+func (self *F32x4ExtractLane) String() string {
+	return "f32x4.extract_lane"
 }
 
-func (self *I32AtomicRmwCmpxchg) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x48}
+func (self *F32x4ExtractLane) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x13}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	sink.WriteByte(self.Lane)
 
-type I64AtomicRmwCmpxchg struct {
-	MemArg MemArg
 }
 
-func (self *I64AtomicRmwCmpxchg) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 8)
+func (self *F32x4ExtractLane) decodeInstrBody(source *ZeroCopySource) error {
+	lane, err := source.ReadByte()
 	if err != nil {
 		return err
 	}
+	self.Lane = lane
 
 	return nil
 }
 
-func (self *I64AtomicRmwCmpxchg) String() string {
-	return "i64.atomic.rmw.cmpxchg"
-}
+func (self *F32x4ExtractLane) printInstrBody(p *printer) {
+	p.word(strconv.Itoa(int(self.Lane)))
 
-func (self *I64AtomicRmwCmpxchg) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x49}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *F32x4ExtractLane) lane() (uint8, uint8) {
+	return self.Lane, 4
 }
 
-type I32AtomicRmw8CmpxchgU struct {
-	MemArg MemArg
+type F32x4ReplaceLane struct {
+	Lane uint8
 }
 
-func (self *I32AtomicRmw8CmpxchgU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
+func (self *F32x4ReplaceLane) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectUint8()
 	if err != nil {
 		return err
 	}
+	self.Lane = val
 
 	return nil
 }
 
-func (self *I32AtomicRmw8CmpxchgU) String() string {
-	return "i32.atomic.rmw8.cmpxchg_u"
+func (self *F32x4ReplaceLane) String() string {
+	return "f32x4.replace_lane"
 }
 
-func (self *I32AtomicRmw8CmpxchgU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x4a}
+func (self *F32x4ReplaceLane) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x14}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
-
-}
+	sink.WriteByte(self.Lane)
 
-type I32AtomicRmw16CmpxchgU struct {
-	MemArg MemArg
 }
 
-func (self *I32AtomicRmw16CmpxchgU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
+func (self *F32x4ReplaceLane) decodeInstrBody(source *ZeroCopySource) error {
+	lane, err := source.ReadByte()
 	if err != nil {
 		return err
 	}
+	self.Lane = lane
 
 	return nil
 }
 
-func (self *I32AtomicRmw16CmpxchgU) String() string {
-	return "i32.atomic.rmw16.cmpxchg_u"
-}
+func (self *F32x4ReplaceLane) printInstrBody(p *printer) {
+	p.word(strconv.Itoa(int(self.Lane)))
 
-func (self *I32AtomicRmw16CmpxchgU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x4b}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *F32x4ReplaceLane) lane() (uint8, uint8) {
+	return self.Lane, 4
 }
 
-type I64AtomicRmw8CmpxchgU struct {
-	MemArg MemArg
+type F64x2Splat struct {
 }
 
-func (self *I64AtomicRmw8CmpxchgU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 1)
-	if err != nil {
-		return err
-	}
+func (self *F64x2Splat) parseInstrBody(ps *parser.ParserBuffer) error {
 
 	return nil
 }
 
-func (self *I64AtomicRmw8CmpxchgU) String() string {
-	return "i64.atomic.rmw8.cmpxchg_u"
+func (self *F64x2Splat) String() string {
+	return "f64x2.splat"
 }
 
-func (self *I64AtomicRmw8CmpxchgU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x4c}
+func (self *F64x2Splat) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x15}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
 
 }
 
-type I64AtomicRmw16CmpxchgU struct {
-	MemArg MemArg
+func (self *F64x2Splat) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
 }
 
-func (self *I64AtomicRmw16CmpxchgU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 2)
+func (self *F64x2Splat) printInstrBody(p *printer) {
+
+}
+
+type F64x2ExtractLane struct {
+	Lane uint8
+}
+
+func (self *F64x2ExtractLane) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectUint8()
 	if err != nil {
 		return err
 	}
+	self.Lane = val
 
 	return nil
 }
 
-func (self *I64AtomicRmw16CmpxchgU) String() string {
-	return "i64.atomic.rmw16.cmpxchg_u"
+func (self *F64x2ExtractLane) String() string {
+	return "f64x2.extract_lane"
 }
 
-func (self *I64AtomicRmw16CmpxchgU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x4d}
+func (self *F64x2ExtractLane) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x16}
 	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+	sink.WriteByte(self.Lane)
 
 }
 
-type I64AtomicRmw32CmpxchgU struct {
-	MemArg MemArg
-}
-
-func (self *I64AtomicRmw32CmpxchgU) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 4)
+func (self *F64x2ExtractLane) decodeInstrBody(source *ZeroCopySource) error {
+	lane, err := source.ReadByte()
 	if err != nil {
 		return err
 	}
+	self.Lane = lane
 
 	return nil
 }
 
-func (self *I64AtomicRmw32CmpxchgU) String() string {
-	return "i64.atomic.rmw32.cmpxchg_u"
-}
+func (self *F64x2ExtractLane) printInstrBody(p *printer) {
+	p.word(strconv.Itoa(int(self.Lane)))
 
-func (self *I64AtomicRmw32CmpxchgU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfe, 0x4e}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *F64x2ExtractLane) lane() (uint8, uint8) {
+	return self.Lane, 2
 }
 
-type V128Load struct {
-	MemArg MemArg
+type F64x2ReplaceLane struct {
+	Lane uint8
 }
 
-func (self *V128Load) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 16)
+func (self *F64x2ReplaceLane) parseInstrBody(ps *parser.ParserBuffer) error {
+	val, err := ps.ExpectUint8()
 	if err != nil {
 		return err
 	}
+	self.Lane = val
 
 	return nil
-}
-
-func (self *V128Load) String() string {
-	return "v128.load"
-}
-
-func (self *V128Load) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x0}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *F64x2ReplaceLane) String() string {
+	return "f64x2.replace_lane"
 }
 
-type V128Store struct {
-	MemArg MemArg
+func (self *F64x2ReplaceLane) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x17}
+	sink.WriteBytes(inst)
+	sink.WriteByte(self.Lane)
+
 }
 
-func (self *V128Store) parseInstrBody(ps *parser.ParserBuffer) error {
-	err := self.MemArg.Parse(ps, 16)
+func (self *F64x2ReplaceLane) decodeInstrBody(source *ZeroCopySource) error {
+	lane, err := source.ReadByte()
 	if err != nil {
 		return err
 	}
+	self.Lane = lane
 
 	return nil
 }
 
-func (self *V128Store) String() string {
-	return "v128.store"
-}
+func (self *F64x2ReplaceLane) printInstrBody(p *printer) {
+	p.word(strconv.Itoa(int(self.Lane)))
 
-func (self *V128Store) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x1}
-	sink.WriteBytes(inst)
-	self.MemArg.Encode(sink)
+}
 
+func (self *F64x2ReplaceLane) lane() (uint8, uint8) {
+	return self.Lane, 2
 }
 
 type I8x16Eq struct {
@@ -5613,6 +10032,15 @@ func (self *I8x16Eq) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16Eq) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16Eq) printInstrBody(p *printer) {
+
+}
+
 type I8x16Ne struct {
 }
 
@@ -5631,6 +10059,15 @@ func (self *I8x16Ne) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16Ne) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16Ne) printInstrBody(p *printer) {
+
+}
+
 type I8x16LtS struct {
 }
 
@@ -5649,6 +10086,15 @@ func (self *I8x16LtS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16LtS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16LtS) printInstrBody(p *printer) {
+
+}
+
 type I8x16LtU struct {
 }
 
@@ -5667,6 +10113,15 @@ func (self *I8x16LtU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16LtU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16LtU) printInstrBody(p *printer) {
+
+}
+
 type I8x16GtS struct {
 }
 
@@ -5685,6 +10140,15 @@ func (self *I8x16GtS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16GtS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16GtS) printInstrBody(p *printer) {
+
+}
+
 type I8x16GtU struct {
 }
 
@@ -5703,6 +10167,15 @@ func (self *I8x16GtU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16GtU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16GtU) printInstrBody(p *printer) {
+
+}
+
 type I8x16LeS struct {
 }
 
@@ -5721,6 +10194,15 @@ func (self *I8x16LeS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16LeS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16LeS) printInstrBody(p *printer) {
+
+}
+
 type I8x16LeU struct {
 }
 
@@ -5739,6 +10221,15 @@ func (self *I8x16LeU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16LeU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16LeU) printInstrBody(p *printer) {
+
+}
+
 type I8x16GeS struct {
 }
 
@@ -5757,6 +10248,15 @@ func (self *I8x16GeS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16GeS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16GeS) printInstrBody(p *printer) {
+
+}
+
 type I8x16GeU struct {
 }
 
@@ -5775,6 +10275,15 @@ func (self *I8x16GeU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16GeU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16GeU) printInstrBody(p *printer) {
+
+}
+
 type I16x8Eq struct {
 }
 
@@ -5793,6 +10302,15 @@ func (self *I16x8Eq) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8Eq) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8Eq) printInstrBody(p *printer) {
+
+}
+
 type I16x8Ne struct {
 }
 
@@ -5811,6 +10329,15 @@ func (self *I16x8Ne) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8Ne) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8Ne) printInstrBody(p *printer) {
+
+}
+
 type I16x8LtS struct {
 }
 
@@ -5829,6 +10356,15 @@ func (self *I16x8LtS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8LtS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8LtS) printInstrBody(p *printer) {
+
+}
+
 type I16x8LtU struct {
 }
 
@@ -5847,6 +10383,15 @@ func (self *I16x8LtU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8LtU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8LtU) printInstrBody(p *printer) {
+
+}
+
 type I16x8GtS struct {
 }
 
@@ -5865,6 +10410,15 @@ func (self *I16x8GtS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8GtS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8GtS) printInstrBody(p *printer) {
+
+}
+
 type I16x8GtU struct {
 }
 
@@ -5883,6 +10437,15 @@ func (self *I16x8GtU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8GtU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8GtU) printInstrBody(p *printer) {
+
+}
+
 type I16x8LeS struct {
 }
 
@@ -5901,6 +10464,15 @@ func (self *I16x8LeS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8LeS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8LeS) printInstrBody(p *printer) {
+
+}
+
 type I16x8LeU struct {
 }
 
@@ -5919,6 +10491,15 @@ func (self *I16x8LeU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8LeU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8LeU) printInstrBody(p *printer) {
+
+}
+
 type I16x8GeS struct {
 }
 
@@ -5937,6 +10518,15 @@ func (self *I16x8GeS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8GeS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8GeS) printInstrBody(p *printer) {
+
+}
+
 type I16x8GeU struct {
 }
 
@@ -5955,6 +10545,15 @@ func (self *I16x8GeU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8GeU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8GeU) printInstrBody(p *printer) {
+
+}
+
 type I32x4Eq struct {
 }
 
@@ -5973,6 +10572,15 @@ func (self *I32x4Eq) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4Eq) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4Eq) printInstrBody(p *printer) {
+
+}
+
 type I32x4Ne struct {
 }
 
@@ -5991,6 +10599,15 @@ func (self *I32x4Ne) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4Ne) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4Ne) printInstrBody(p *printer) {
+
+}
+
 type I32x4LtS struct {
 }
 
@@ -6009,6 +10626,15 @@ func (self *I32x4LtS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4LtS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4LtS) printInstrBody(p *printer) {
+
+}
+
 type I32x4LtU struct {
 }
 
@@ -6027,6 +10653,15 @@ func (self *I32x4LtU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4LtU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4LtU) printInstrBody(p *printer) {
+
+}
+
 type I32x4GtS struct {
 }
 
@@ -6045,6 +10680,15 @@ func (self *I32x4GtS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4GtS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4GtS) printInstrBody(p *printer) {
+
+}
+
 type I32x4GtU struct {
 }
 
@@ -6063,6 +10707,15 @@ func (self *I32x4GtU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4GtU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4GtU) printInstrBody(p *printer) {
+
+}
+
 type I32x4LeS struct {
 }
 
@@ -6081,6 +10734,15 @@ func (self *I32x4LeS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4LeS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4LeS) printInstrBody(p *printer) {
+
+}
+
 type I32x4LeU struct {
 }
 
@@ -6099,6 +10761,15 @@ func (self *I32x4LeU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4LeU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4LeU) printInstrBody(p *printer) {
+
+}
+
 type I32x4GeS struct {
 }
 
@@ -6117,6 +10788,15 @@ func (self *I32x4GeS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4GeS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4GeS) printInstrBody(p *printer) {
+
+}
+
 type I32x4GeU struct {
 }
 
@@ -6135,6 +10815,15 @@ func (self *I32x4GeU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4GeU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4GeU) printInstrBody(p *printer) {
+
+}
+
 type F32x4Eq struct {
 }
 
@@ -6153,6 +10842,15 @@ func (self *F32x4Eq) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *F32x4Eq) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4Eq) printInstrBody(p *printer) {
+
+}
+
 type F32x4Ne struct {
 }
 
@@ -6171,6 +10869,15 @@ func (self *F32x4Ne) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *F32x4Ne) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4Ne) printInstrBody(p *printer) {
+
+}
+
 type F32x4Lt struct {
 }
 
@@ -6189,6 +10896,15 @@ func (self *F32x4Lt) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *F32x4Lt) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4Lt) printInstrBody(p *printer) {
+
+}
+
 type F32x4Gt struct {
 }
 
@@ -6201,9 +10917,18 @@ func (self *F32x4Gt) String() string {
 	return "f32x4.gt"
 }
 
-func (self *F32x4Gt) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x43}
-	sink.WriteBytes(inst)
+func (self *F32x4Gt) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x43}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *F32x4Gt) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4Gt) printInstrBody(p *printer) {
 
 }
 
@@ -6225,6 +10950,15 @@ func (self *F32x4Le) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *F32x4Le) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4Le) printInstrBody(p *printer) {
+
+}
+
 type F32x4Ge struct {
 }
 
@@ -6243,6 +10977,15 @@ func (self *F32x4Ge) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *F32x4Ge) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4Ge) printInstrBody(p *printer) {
+
+}
+
 type F64x2Eq struct {
 }
 
@@ -6261,6 +11004,15 @@ func (self *F64x2Eq) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *F64x2Eq) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2Eq) printInstrBody(p *printer) {
+
+}
+
 type F64x2Ne struct {
 }
 
@@ -6279,6 +11031,15 @@ func (self *F64x2Ne) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *F64x2Ne) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2Ne) printInstrBody(p *printer) {
+
+}
+
 type F64x2Lt struct {
 }
 
@@ -6297,6 +11058,15 @@ func (self *F64x2Lt) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *F64x2Lt) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2Lt) printInstrBody(p *printer) {
+
+}
+
 type F64x2Gt struct {
 }
 
@@ -6315,6 +11085,15 @@ func (self *F64x2Gt) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *F64x2Gt) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2Gt) printInstrBody(p *printer) {
+
+}
+
 type F64x2Le struct {
 }
 
@@ -6333,6 +11112,15 @@ func (self *F64x2Le) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *F64x2Le) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2Le) printInstrBody(p *printer) {
+
+}
+
 type F64x2Ge struct {
 }
 
@@ -6351,6 +11139,15 @@ func (self *F64x2Ge) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *F64x2Ge) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2Ge) printInstrBody(p *printer) {
+
+}
+
 type V128Not struct {
 }
 
@@ -6369,6 +11166,15 @@ func (self *V128Not) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *V128Not) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *V128Not) printInstrBody(p *printer) {
+
+}
+
 type V128And struct {
 }
 
@@ -6387,6 +11193,15 @@ func (self *V128And) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *V128And) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *V128And) printInstrBody(p *printer) {
+
+}
+
 type V128Or struct {
 }
 
@@ -6405,6 +11220,15 @@ func (self *V128Or) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *V128Or) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *V128Or) printInstrBody(p *printer) {
+
+}
+
 type V128Xor struct {
 }
 
@@ -6423,6 +11247,15 @@ func (self *V128Xor) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *V128Xor) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *V128Xor) printInstrBody(p *printer) {
+
+}
+
 type V128Bitselect struct {
 }
 
@@ -6441,6 +11274,15 @@ func (self *V128Bitselect) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *V128Bitselect) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *V128Bitselect) printInstrBody(p *printer) {
+
+}
+
 type I8x16Neg struct {
 }
 
@@ -6459,6 +11301,15 @@ func (self *I8x16Neg) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16Neg) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16Neg) printInstrBody(p *printer) {
+
+}
+
 type I8x16AnyTrue struct {
 }
 
@@ -6477,6 +11328,15 @@ func (self *I8x16AnyTrue) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16AnyTrue) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16AnyTrue) printInstrBody(p *printer) {
+
+}
+
 type I8x16AllTrue struct {
 }
 
@@ -6495,6 +11355,15 @@ func (self *I8x16AllTrue) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16AllTrue) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16AllTrue) printInstrBody(p *printer) {
+
+}
+
 type I8x16Shl struct {
 }
 
@@ -6513,6 +11382,15 @@ func (self *I8x16Shl) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16Shl) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16Shl) printInstrBody(p *printer) {
+
+}
+
 type I8x16ShrS struct {
 }
 
@@ -6531,6 +11409,15 @@ func (self *I8x16ShrS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16ShrS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16ShrS) printInstrBody(p *printer) {
+
+}
+
 type I8x16ShrU struct {
 }
 
@@ -6549,6 +11436,15 @@ func (self *I8x16ShrU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16ShrU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16ShrU) printInstrBody(p *printer) {
+
+}
+
 type I8x16Add struct {
 }
 
@@ -6567,6 +11463,15 @@ func (self *I8x16Add) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16Add) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16Add) printInstrBody(p *printer) {
+
+}
+
 type I8x16AddSaturateS struct {
 }
 
@@ -6585,6 +11490,15 @@ func (self *I8x16AddSaturateS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16AddSaturateS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16AddSaturateS) printInstrBody(p *printer) {
+
+}
+
 type I8x16AddSaturateU struct {
 }
 
@@ -6603,6 +11517,15 @@ func (self *I8x16AddSaturateU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16AddSaturateU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16AddSaturateU) printInstrBody(p *printer) {
+
+}
+
 type I8x16Sub struct {
 }
 
@@ -6621,6 +11544,15 @@ func (self *I8x16Sub) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16Sub) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16Sub) printInstrBody(p *printer) {
+
+}
+
 type I8x16SubSaturateS struct {
 }
 
@@ -6639,6 +11571,15 @@ func (self *I8x16SubSaturateS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16SubSaturateS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16SubSaturateS) printInstrBody(p *printer) {
+
+}
+
 type I8x16SubSaturateU struct {
 }
 
@@ -6657,6 +11598,15 @@ func (self *I8x16SubSaturateU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16SubSaturateU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16SubSaturateU) printInstrBody(p *printer) {
+
+}
+
 type I8x16Mul struct {
 }
 
@@ -6675,6 +11625,15 @@ func (self *I8x16Mul) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I8x16Mul) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16Mul) printInstrBody(p *printer) {
+
+}
+
 type I16x8Neg struct {
 }
 
@@ -6693,6 +11652,15 @@ func (self *I16x8Neg) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8Neg) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8Neg) printInstrBody(p *printer) {
+
+}
+
 type I16x8AnyTrue struct {
 }
 
@@ -6711,6 +11679,15 @@ func (self *I16x8AnyTrue) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8AnyTrue) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8AnyTrue) printInstrBody(p *printer) {
+
+}
+
 type I16x8AllTrue struct {
 }
 
@@ -6729,6 +11706,15 @@ func (self *I16x8AllTrue) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8AllTrue) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8AllTrue) printInstrBody(p *printer) {
+
+}
+
 type I16x8Shl struct {
 }
 
@@ -6747,6 +11733,15 @@ func (self *I16x8Shl) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8Shl) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8Shl) printInstrBody(p *printer) {
+
+}
+
 type I16x8ShrS struct {
 }
 
@@ -6765,6 +11760,15 @@ func (self *I16x8ShrS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8ShrS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8ShrS) printInstrBody(p *printer) {
+
+}
+
 type I16x8ShrU struct {
 }
 
@@ -6783,6 +11787,15 @@ func (self *I16x8ShrU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8ShrU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8ShrU) printInstrBody(p *printer) {
+
+}
+
 type I16x8Add struct {
 }
 
@@ -6801,6 +11814,15 @@ func (self *I16x8Add) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8Add) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8Add) printInstrBody(p *printer) {
+
+}
+
 type I16x8AddSaturateS struct {
 }
 
@@ -6819,6 +11841,15 @@ func (self *I16x8AddSaturateS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8AddSaturateS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8AddSaturateS) printInstrBody(p *printer) {
+
+}
+
 type I16x8AddSaturateU struct {
 }
 
@@ -6837,6 +11868,15 @@ func (self *I16x8AddSaturateU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8AddSaturateU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8AddSaturateU) printInstrBody(p *printer) {
+
+}
+
 type I16x8Sub struct {
 }
 
@@ -6849,9 +11889,18 @@ func (self *I16x8Sub) String() string {
 	return "i16x8.sub"
 }
 
-func (self *I16x8Sub) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x6b}
-	sink.WriteBytes(inst)
+func (self *I16x8Sub) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0x6b}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *I16x8Sub) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8Sub) printInstrBody(p *printer) {
 
 }
 
@@ -6873,6 +11922,15 @@ func (self *I16x8SubSaturateS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8SubSaturateS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8SubSaturateS) printInstrBody(p *printer) {
+
+}
+
 type I16x8SubSaturateU struct {
 }
 
@@ -6891,6 +11949,15 @@ func (self *I16x8SubSaturateU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8SubSaturateU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8SubSaturateU) printInstrBody(p *printer) {
+
+}
+
 type I16x8Mul struct {
 }
 
@@ -6909,6 +11976,15 @@ func (self *I16x8Mul) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I16x8Mul) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8Mul) printInstrBody(p *printer) {
+
+}
+
 type I32x4Neg struct {
 }
 
@@ -6927,6 +12003,15 @@ func (self *I32x4Neg) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4Neg) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4Neg) printInstrBody(p *printer) {
+
+}
+
 type I32x4AnyTrue struct {
 }
 
@@ -6945,6 +12030,15 @@ func (self *I32x4AnyTrue) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4AnyTrue) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4AnyTrue) printInstrBody(p *printer) {
+
+}
+
 type I32x4AllTrue struct {
 }
 
@@ -6963,6 +12057,15 @@ func (self *I32x4AllTrue) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4AllTrue) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4AllTrue) printInstrBody(p *printer) {
+
+}
+
 type I32x4Shl struct {
 }
 
@@ -6981,6 +12084,15 @@ func (self *I32x4Shl) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4Shl) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4Shl) printInstrBody(p *printer) {
+
+}
+
 type I32x4ShrS struct {
 }
 
@@ -6999,6 +12111,15 @@ func (self *I32x4ShrS) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4ShrS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4ShrS) printInstrBody(p *printer) {
+
+}
+
 type I32x4ShrU struct {
 }
 
@@ -7017,6 +12138,15 @@ func (self *I32x4ShrU) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4ShrU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4ShrU) printInstrBody(p *printer) {
+
+}
+
 type I32x4Add struct {
 }
 
@@ -7035,6 +12165,15 @@ func (self *I32x4Add) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4Add) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4Add) printInstrBody(p *printer) {
+
+}
+
 type I32x4Sub struct {
 }
 
@@ -7053,6 +12192,15 @@ func (self *I32x4Sub) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4Sub) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4Sub) printInstrBody(p *printer) {
+
+}
+
 type I32x4Mul struct {
 }
 
@@ -7071,6 +12219,15 @@ func (self *I32x4Mul) Encode(sink *ZeroCopySink) {
 
 }
 
+func (self *I32x4Mul) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4Mul) printInstrBody(p *printer) {
+
+}
+
 type I64x2Neg struct {
 }
 
@@ -7084,11 +12241,20 @@ func (self *I64x2Neg) String() string {
 }
 
 func (self *I64x2Neg) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x84}
+	inst := []byte{0xfd, 0x84, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I64x2Neg) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64x2Neg) printInstrBody(p *printer) {
+
+}
+
 type I64x2AnyTrue struct {
 }
 
@@ -7102,11 +12268,20 @@ func (self *I64x2AnyTrue) String() string {
 }
 
 func (self *I64x2AnyTrue) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x85}
+	inst := []byte{0xfd, 0x85, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I64x2AnyTrue) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64x2AnyTrue) printInstrBody(p *printer) {
+
+}
+
 type I64x2AllTrue struct {
 }
 
@@ -7120,11 +12295,20 @@ func (self *I64x2AllTrue) String() string {
 }
 
 func (self *I64x2AllTrue) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x86}
+	inst := []byte{0xfd, 0x86, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I64x2AllTrue) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64x2AllTrue) printInstrBody(p *printer) {
+
+}
+
 type I64x2Shl struct {
 }
 
@@ -7138,11 +12322,20 @@ func (self *I64x2Shl) String() string {
 }
 
 func (self *I64x2Shl) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x87}
+	inst := []byte{0xfd, 0x87, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I64x2Shl) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64x2Shl) printInstrBody(p *printer) {
+
+}
+
 type I64x2ShrS struct {
 }
 
@@ -7156,11 +12349,20 @@ func (self *I64x2ShrS) String() string {
 }
 
 func (self *I64x2ShrS) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x88}
+	inst := []byte{0xfd, 0x88, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I64x2ShrS) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64x2ShrS) printInstrBody(p *printer) {
+
+}
+
 type I64x2ShrU struct {
 }
 
@@ -7174,11 +12376,20 @@ func (self *I64x2ShrU) String() string {
 }
 
 func (self *I64x2ShrU) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x89}
+	inst := []byte{0xfd, 0x89, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I64x2ShrU) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64x2ShrU) printInstrBody(p *printer) {
+
+}
+
 type I64x2Add struct {
 }
 
@@ -7192,11 +12403,20 @@ func (self *I64x2Add) String() string {
 }
 
 func (self *I64x2Add) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x8a}
+	inst := []byte{0xfd, 0x8a, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I64x2Add) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64x2Add) printInstrBody(p *printer) {
+
+}
+
 type I64x2Sub struct {
 }
 
@@ -7210,11 +12430,20 @@ func (self *I64x2Sub) String() string {
 }
 
 func (self *I64x2Sub) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x8d}
+	inst := []byte{0xfd, 0x8d, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I64x2Sub) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64x2Sub) printInstrBody(p *printer) {
+
+}
+
 type I64x2Mul struct {
 }
 
@@ -7228,11 +12457,20 @@ func (self *I64x2Mul) String() string {
 }
 
 func (self *I64x2Mul) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x90}
+	inst := []byte{0xfd, 0x90, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I64x2Mul) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64x2Mul) printInstrBody(p *printer) {
+
+}
+
 type F32x4Abs struct {
 }
 
@@ -7246,11 +12484,20 @@ func (self *F32x4Abs) String() string {
 }
 
 func (self *F32x4Abs) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x95}
+	inst := []byte{0xfd, 0x95, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F32x4Abs) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4Abs) printInstrBody(p *printer) {
+
+}
+
 type F32x4Neg struct {
 }
 
@@ -7264,11 +12511,20 @@ func (self *F32x4Neg) String() string {
 }
 
 func (self *F32x4Neg) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x96}
+	inst := []byte{0xfd, 0x96, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F32x4Neg) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4Neg) printInstrBody(p *printer) {
+
+}
+
 type F32x4Sqrt struct {
 }
 
@@ -7282,11 +12538,20 @@ func (self *F32x4Sqrt) String() string {
 }
 
 func (self *F32x4Sqrt) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x97}
+	inst := []byte{0xfd, 0x97, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F32x4Sqrt) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4Sqrt) printInstrBody(p *printer) {
+
+}
+
 type F32x4Add struct {
 }
 
@@ -7300,11 +12565,20 @@ func (self *F32x4Add) String() string {
 }
 
 func (self *F32x4Add) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x9a}
+	inst := []byte{0xfd, 0x9a, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F32x4Add) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4Add) printInstrBody(p *printer) {
+
+}
+
 type F32x4Sub struct {
 }
 
@@ -7318,11 +12592,20 @@ func (self *F32x4Sub) String() string {
 }
 
 func (self *F32x4Sub) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x9b}
+	inst := []byte{0xfd, 0x9b, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F32x4Sub) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4Sub) printInstrBody(p *printer) {
+
+}
+
 type F32x4Mul struct {
 }
 
@@ -7336,11 +12619,20 @@ func (self *F32x4Mul) String() string {
 }
 
 func (self *F32x4Mul) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x9c}
+	inst := []byte{0xfd, 0x9c, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F32x4Mul) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4Mul) printInstrBody(p *printer) {
+
+}
+
 type F32x4Div struct {
 }
 
@@ -7354,11 +12646,20 @@ func (self *F32x4Div) String() string {
 }
 
 func (self *F32x4Div) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x9d}
+	inst := []byte{0xfd, 0x9d, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F32x4Div) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4Div) printInstrBody(p *printer) {
+
+}
+
 type F32x4Min struct {
 }
 
@@ -7372,11 +12673,20 @@ func (self *F32x4Min) String() string {
 }
 
 func (self *F32x4Min) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x9e}
+	inst := []byte{0xfd, 0x9e, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F32x4Min) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4Min) printInstrBody(p *printer) {
+
+}
+
 type F32x4Max struct {
 }
 
@@ -7390,11 +12700,20 @@ func (self *F32x4Max) String() string {
 }
 
 func (self *F32x4Max) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0x9f}
+	inst := []byte{0xfd, 0x9f, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F32x4Max) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4Max) printInstrBody(p *printer) {
+
+}
+
 type F64x2Abs struct {
 }
 
@@ -7408,11 +12727,20 @@ func (self *F64x2Abs) String() string {
 }
 
 func (self *F64x2Abs) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xa0}
+	inst := []byte{0xfd, 0xa0, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F64x2Abs) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2Abs) printInstrBody(p *printer) {
+
+}
+
 type F64x2Neg struct {
 }
 
@@ -7426,11 +12754,20 @@ func (self *F64x2Neg) String() string {
 }
 
 func (self *F64x2Neg) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xa1}
+	inst := []byte{0xfd, 0xa1, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F64x2Neg) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2Neg) printInstrBody(p *printer) {
+
+}
+
 type F64x2Sqrt struct {
 }
 
@@ -7444,11 +12781,20 @@ func (self *F64x2Sqrt) String() string {
 }
 
 func (self *F64x2Sqrt) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xa2}
+	inst := []byte{0xfd, 0xa2, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F64x2Sqrt) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2Sqrt) printInstrBody(p *printer) {
+
+}
+
 type F64x2Add struct {
 }
 
@@ -7462,11 +12808,20 @@ func (self *F64x2Add) String() string {
 }
 
 func (self *F64x2Add) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xa5}
+	inst := []byte{0xfd, 0xa5, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F64x2Add) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2Add) printInstrBody(p *printer) {
+
+}
+
 type F64x2Sub struct {
 }
 
@@ -7480,11 +12835,20 @@ func (self *F64x2Sub) String() string {
 }
 
 func (self *F64x2Sub) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xa6}
+	inst := []byte{0xfd, 0xa6, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F64x2Sub) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2Sub) printInstrBody(p *printer) {
+
+}
+
 type F64x2Mul struct {
 }
 
@@ -7498,11 +12862,20 @@ func (self *F64x2Mul) String() string {
 }
 
 func (self *F64x2Mul) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xa7}
+	inst := []byte{0xfd, 0xa7, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F64x2Mul) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2Mul) printInstrBody(p *printer) {
+
+}
+
 type F64x2Div struct {
 }
 
@@ -7516,11 +12889,20 @@ func (self *F64x2Div) String() string {
 }
 
 func (self *F64x2Div) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xa8}
+	inst := []byte{0xfd, 0xa8, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F64x2Div) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2Div) printInstrBody(p *printer) {
+
+}
+
 type F64x2Min struct {
 }
 
@@ -7534,11 +12916,20 @@ func (self *F64x2Min) String() string {
 }
 
 func (self *F64x2Min) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xa9}
+	inst := []byte{0xfd, 0xa9, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F64x2Min) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2Min) printInstrBody(p *printer) {
+
+}
+
 type F64x2Max struct {
 }
 
@@ -7552,11 +12943,20 @@ func (self *F64x2Max) String() string {
 }
 
 func (self *F64x2Max) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xaa}
+	inst := []byte{0xfd, 0xaa, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F64x2Max) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2Max) printInstrBody(p *printer) {
+
+}
+
 type I32x4TruncSatF32x4S struct {
 }
 
@@ -7570,11 +12970,20 @@ func (self *I32x4TruncSatF32x4S) String() string {
 }
 
 func (self *I32x4TruncSatF32x4S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xab}
+	inst := []byte{0xfd, 0xab, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I32x4TruncSatF32x4S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4TruncSatF32x4S) printInstrBody(p *printer) {
+
+}
+
 type I32x4TruncSatF32x4U struct {
 }
 
@@ -7588,11 +12997,20 @@ func (self *I32x4TruncSatF32x4U) String() string {
 }
 
 func (self *I32x4TruncSatF32x4U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xac}
+	inst := []byte{0xfd, 0xac, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I32x4TruncSatF32x4U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4TruncSatF32x4U) printInstrBody(p *printer) {
+
+}
+
 type I64x2TruncSatF64x2S struct {
 }
 
@@ -7606,11 +13024,20 @@ func (self *I64x2TruncSatF64x2S) String() string {
 }
 
 func (self *I64x2TruncSatF64x2S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xad}
+	inst := []byte{0xfd, 0xad, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I64x2TruncSatF64x2S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64x2TruncSatF64x2S) printInstrBody(p *printer) {
+
+}
+
 type I64x2TruncSatF64x2U struct {
 }
 
@@ -7624,11 +13051,20 @@ func (self *I64x2TruncSatF64x2U) String() string {
 }
 
 func (self *I64x2TruncSatF64x2U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xae}
+	inst := []byte{0xfd, 0xae, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I64x2TruncSatF64x2U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I64x2TruncSatF64x2U) printInstrBody(p *printer) {
+
+}
+
 type F32x4ConvertI32x4S struct {
 }
 
@@ -7642,11 +13078,20 @@ func (self *F32x4ConvertI32x4S) String() string {
 }
 
 func (self *F32x4ConvertI32x4S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xaf}
+	inst := []byte{0xfd, 0xaf, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F32x4ConvertI32x4S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4ConvertI32x4S) printInstrBody(p *printer) {
+
+}
+
 type F32x4ConvertI32x4U struct {
 }
 
@@ -7660,11 +13105,20 @@ func (self *F32x4ConvertI32x4U) String() string {
 }
 
 func (self *F32x4ConvertI32x4U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xb0}
+	inst := []byte{0xfd, 0xb0, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F32x4ConvertI32x4U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F32x4ConvertI32x4U) printInstrBody(p *printer) {
+
+}
+
 type F64x2ConvertI64x2S struct {
 }
 
@@ -7678,11 +13132,20 @@ func (self *F64x2ConvertI64x2S) String() string {
 }
 
 func (self *F64x2ConvertI64x2S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xb1}
+	inst := []byte{0xfd, 0xb1, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F64x2ConvertI64x2S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2ConvertI64x2S) printInstrBody(p *printer) {
+
+}
+
 type F64x2ConvertI64x2U struct {
 }
 
@@ -7696,11 +13159,20 @@ func (self *F64x2ConvertI64x2U) String() string {
 }
 
 func (self *F64x2ConvertI64x2U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xb2}
+	inst := []byte{0xfd, 0xb2, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *F64x2ConvertI64x2U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *F64x2ConvertI64x2U) printInstrBody(p *printer) {
+
+}
+
 type V8x16Swizzle struct {
 }
 
@@ -7714,8 +13186,54 @@ func (self *V8x16Swizzle) String() string {
 }
 
 func (self *V8x16Swizzle) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xc0}
+	inst := []byte{0xfd, 0xc0, 0x1}
+	sink.WriteBytes(inst)
+
+}
+
+func (self *V8x16Swizzle) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *V8x16Swizzle) printInstrBody(p *printer) {
+
+}
+
+type V8x16Shuffle struct {
+	Lanes ShuffleLanes
+}
+
+func (self *V8x16Shuffle) parseInstrBody(ps *parser.ParserBuffer) error {
+	err := self.Lanes.Parse(ps)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *V8x16Shuffle) String() string {
+	return "v8x16.shuffle"
+}
+
+func (self *V8x16Shuffle) Encode(sink *ZeroCopySink) {
+	inst := []byte{0xfd, 0xc1, 0x1}
 	sink.WriteBytes(inst)
+	self.Lanes.Encode(sink)
+
+}
+
+func (self *V8x16Shuffle) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.Lanes.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *V8x16Shuffle) printInstrBody(p *printer) {
+	self.Lanes.print(p)
 
 }
 
@@ -7737,12 +13255,29 @@ func (self *V8x16LoadSplat) String() string {
 }
 
 func (self *V8x16LoadSplat) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xc2}
+	inst := []byte{0xfd, 0xc2, 0x1}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
+func (self *V8x16LoadSplat) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *V8x16LoadSplat) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
+
+}
+
+func (self *V8x16LoadSplat) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
+}
+
 type V16x8LoadSplat struct {
 	MemArg MemArg
 }
@@ -7761,12 +13296,29 @@ func (self *V16x8LoadSplat) String() string {
 }
 
 func (self *V16x8LoadSplat) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xc3}
+	inst := []byte{0xfd, 0xc3, 0x1}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
+func (self *V16x8LoadSplat) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *V16x8LoadSplat) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
+
+}
+
+func (self *V16x8LoadSplat) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
+}
+
 type V32x4LoadSplat struct {
 	MemArg MemArg
 }
@@ -7785,12 +13337,29 @@ func (self *V32x4LoadSplat) String() string {
 }
 
 func (self *V32x4LoadSplat) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xc4}
+	inst := []byte{0xfd, 0xc4, 0x1}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
+func (self *V32x4LoadSplat) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *V32x4LoadSplat) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
+
+}
+
+func (self *V32x4LoadSplat) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
+}
+
 type V64x2LoadSplat struct {
 	MemArg MemArg
 }
@@ -7809,12 +13378,29 @@ func (self *V64x2LoadSplat) String() string {
 }
 
 func (self *V64x2LoadSplat) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xc5}
+	inst := []byte{0xfd, 0xc5, 0x1}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
+func (self *V64x2LoadSplat) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *V64x2LoadSplat) printInstrBody(p *printer) {
+	self.MemArg.print(p, 8)
+
+}
+
+func (self *V64x2LoadSplat) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 8
+}
+
 type I8x16NarrowI16x8S struct {
 }
 
@@ -7828,11 +13414,20 @@ func (self *I8x16NarrowI16x8S) String() string {
 }
 
 func (self *I8x16NarrowI16x8S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xc6}
+	inst := []byte{0xfd, 0xc6, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I8x16NarrowI16x8S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16NarrowI16x8S) printInstrBody(p *printer) {
+
+}
+
 type I8x16NarrowI16x8U struct {
 }
 
@@ -7846,11 +13441,20 @@ func (self *I8x16NarrowI16x8U) String() string {
 }
 
 func (self *I8x16NarrowI16x8U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xc7}
+	inst := []byte{0xfd, 0xc7, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I8x16NarrowI16x8U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I8x16NarrowI16x8U) printInstrBody(p *printer) {
+
+}
+
 type I16x8NarrowI32x4S struct {
 }
 
@@ -7864,11 +13468,20 @@ func (self *I16x8NarrowI32x4S) String() string {
 }
 
 func (self *I16x8NarrowI32x4S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xc8}
+	inst := []byte{0xfd, 0xc8, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I16x8NarrowI32x4S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8NarrowI32x4S) printInstrBody(p *printer) {
+
+}
+
 type I16x8NarrowI32x4U struct {
 }
 
@@ -7882,11 +13495,20 @@ func (self *I16x8NarrowI32x4U) String() string {
 }
 
 func (self *I16x8NarrowI32x4U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xc9}
+	inst := []byte{0xfd, 0xc9, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I16x8NarrowI32x4U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8NarrowI32x4U) printInstrBody(p *printer) {
+
+}
+
 type I16x8WidenLowI8x16S struct {
 }
 
@@ -7900,11 +13522,20 @@ func (self *I16x8WidenLowI8x16S) String() string {
 }
 
 func (self *I16x8WidenLowI8x16S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xca}
+	inst := []byte{0xfd, 0xca, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I16x8WidenLowI8x16S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8WidenLowI8x16S) printInstrBody(p *printer) {
+
+}
+
 type I16x8WidenHighI8x16S struct {
 }
 
@@ -7918,11 +13549,20 @@ func (self *I16x8WidenHighI8x16S) String() string {
 }
 
 func (self *I16x8WidenHighI8x16S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xcb}
+	inst := []byte{0xfd, 0xcb, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I16x8WidenHighI8x16S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8WidenHighI8x16S) printInstrBody(p *printer) {
+
+}
+
 type I16x8WidenLowI8x16U struct {
 }
 
@@ -7936,11 +13576,20 @@ func (self *I16x8WidenLowI8x16U) String() string {
 }
 
 func (self *I16x8WidenLowI8x16U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xcc}
+	inst := []byte{0xfd, 0xcc, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I16x8WidenLowI8x16U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8WidenLowI8x16U) printInstrBody(p *printer) {
+
+}
+
 type I16x8WidenHighI8x16u struct {
 }
 
@@ -7954,11 +13603,20 @@ func (self *I16x8WidenHighI8x16u) String() string {
 }
 
 func (self *I16x8WidenHighI8x16u) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xcd}
+	inst := []byte{0xfd, 0xcd, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I16x8WidenHighI8x16u) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I16x8WidenHighI8x16u) printInstrBody(p *printer) {
+
+}
+
 type I32x4WidenLowI16x8S struct {
 }
 
@@ -7972,11 +13630,20 @@ func (self *I32x4WidenLowI16x8S) String() string {
 }
 
 func (self *I32x4WidenLowI16x8S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xce}
+	inst := []byte{0xfd, 0xce, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I32x4WidenLowI16x8S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4WidenLowI16x8S) printInstrBody(p *printer) {
+
+}
+
 type I32x4WidenHighI16x8S struct {
 }
 
@@ -7990,11 +13657,20 @@ func (self *I32x4WidenHighI16x8S) String() string {
 }
 
 func (self *I32x4WidenHighI16x8S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xcf}
+	inst := []byte{0xfd, 0xcf, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I32x4WidenHighI16x8S) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4WidenHighI16x8S) printInstrBody(p *printer) {
+
+}
+
 type I32x4WidenLowI16x8U struct {
 }
 
@@ -8008,11 +13684,20 @@ func (self *I32x4WidenLowI16x8U) String() string {
 }
 
 func (self *I32x4WidenLowI16x8U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xd0}
+	inst := []byte{0xfd, 0xd0, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I32x4WidenLowI16x8U) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4WidenLowI16x8U) printInstrBody(p *printer) {
+
+}
+
 type I32x4WidenHighI16x8u struct {
 }
 
@@ -8026,11 +13711,20 @@ func (self *I32x4WidenHighI16x8u) String() string {
 }
 
 func (self *I32x4WidenHighI16x8u) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xd1}
+	inst := []byte{0xfd, 0xd1, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *I32x4WidenHighI16x8u) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *I32x4WidenHighI16x8u) printInstrBody(p *printer) {
+
+}
+
 type I16x8Load8x8S struct {
 	MemArg MemArg
 }
@@ -8049,12 +13743,29 @@ func (self *I16x8Load8x8S) String() string {
 }
 
 func (self *I16x8Load8x8S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xd2}
+	inst := []byte{0xfd, 0xd2, 0x1}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
+func (self *I16x8Load8x8S) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I16x8Load8x8S) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
+
+}
+
+func (self *I16x8Load8x8S) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
+}
+
 type I16x8Load8x8U struct {
 	MemArg MemArg
 }
@@ -8073,12 +13784,29 @@ func (self *I16x8Load8x8U) String() string {
 }
 
 func (self *I16x8Load8x8U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xd3}
+	inst := []byte{0xfd, 0xd3, 0x1}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
+func (self *I16x8Load8x8U) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I16x8Load8x8U) printInstrBody(p *printer) {
+	self.MemArg.print(p, 1)
+
+}
+
+func (self *I16x8Load8x8U) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 1
+}
+
 type I32x4Load16x4S struct {
 	MemArg MemArg
 }
@@ -8097,12 +13825,29 @@ func (self *I32x4Load16x4S) String() string {
 }
 
 func (self *I32x4Load16x4S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xd4}
+	inst := []byte{0xfd, 0xd4, 0x1}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
+func (self *I32x4Load16x4S) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I32x4Load16x4S) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
+
+}
+
+func (self *I32x4Load16x4S) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
+}
+
 type I32x4Load16x4U struct {
 	MemArg MemArg
 }
@@ -8121,12 +13866,29 @@ func (self *I32x4Load16x4U) String() string {
 }
 
 func (self *I32x4Load16x4U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xd5}
+	inst := []byte{0xfd, 0xd5, 0x1}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
+func (self *I32x4Load16x4U) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I32x4Load16x4U) printInstrBody(p *printer) {
+	self.MemArg.print(p, 2)
+
+}
+
+func (self *I32x4Load16x4U) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 2
+}
+
 type I64x2Load32x2S struct {
 	MemArg MemArg
 }
@@ -8145,12 +13907,29 @@ func (self *I64x2Load32x2S) String() string {
 }
 
 func (self *I64x2Load32x2S) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xd6}
+	inst := []byte{0xfd, 0xd6, 0x1}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
+func (self *I64x2Load32x2S) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I64x2Load32x2S) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
+
+}
+
+func (self *I64x2Load32x2S) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
+}
+
 type I64x2Load32x2U struct {
 	MemArg MemArg
 }
@@ -8169,12 +13948,29 @@ func (self *I64x2Load32x2U) String() string {
 }
 
 func (self *I64x2Load32x2U) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xd7}
+	inst := []byte{0xfd, 0xd7, 0x1}
 	sink.WriteBytes(inst)
 	self.MemArg.Encode(sink)
 
 }
 
+func (self *I64x2Load32x2U) decodeInstrBody(source *ZeroCopySource) error {
+	if err := self.MemArg.Decode(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *I64x2Load32x2U) printInstrBody(p *printer) {
+	self.MemArg.print(p, 4)
+
+}
+
+func (self *I64x2Load32x2U) memArg() (*MemArg, uint32) {
+	return &self.MemArg, 4
+}
+
 type V128Andnot struct {
 }
 
@@ -8188,13 +13984,23 @@ func (self *V128Andnot) String() string {
 }
 
 func (self *V128Andnot) Encode(sink *ZeroCopySink) {
-	inst := []byte{0xfd, 0xd8}
+	inst := []byte{0xfd, 0xd8, 0x1}
 	sink.WriteBytes(inst)
 
 }
 
+func (self *V128Andnot) decodeInstrBody(source *ZeroCopySource) error {
+
+	return nil
+}
+
+func (self *V128Andnot) printInstrBody(p *printer) {
+
+}
+
 func parseInstr(ps *parser.ParserBuffer) (Instruction, error) {
 	var inst Instruction
+	span := ps.Pos()
 	kw, err := ps.ExpectKeyword()
 	if err != nil {
 		return nil, err
@@ -8210,6 +14016,18 @@ func parseInstr(ps *parser.ParserBuffer) (Instruction, error) {
 		inst = &Loop{}
 	case "end":
 		inst = &End{}
+	case "try":
+		inst = &Try{}
+	case "catch":
+		inst = &Catch{}
+	case "catch_all":
+		inst = &CatchAll{}
+	case "delegate":
+		inst = &Delegate{}
+	case "throw":
+		inst = &Throw{}
+	case "rethrow":
+		inst = &Rethrow{}
 	case "unreachable":
 		inst = &Unreachable{}
 	case "nop":
@@ -8298,6 +14116,8 @@ func parseInstr(ps *parser.ParserBuffer) (Instruction, error) {
 		inst = &MemorySize{}
 	case "memory.grow", "grow_memory":
 		inst = &MemoryGrow{}
+	case "memory.init":
+		inst = &MemoryInit{}
 	case "memory.copy":
 		inst = &MemoryCopy{}
 	case "memory.fill":
@@ -8306,6 +14126,8 @@ func parseInstr(ps *parser.ParserBuffer) (Instruction, error) {
 		inst = &DataDrop{}
 	case "elem.drop":
 		inst = &ElemDrop{}
+	case "table.init":
+		inst = &TableInit{}
 	case "table.copy":
 		inst = &TableCopy{}
 	case "table.fill":
@@ -8740,6 +14562,48 @@ func parseInstr(ps *parser.ParserBuffer) (Instruction, error) {
 		inst = &V128Load{}
 	case "v128.store":
 		inst = &V128Store{}
+	case "v128.const":
+		inst = &V128Const{}
+	case "i8x16.splat":
+		inst = &I8x16Splat{}
+	case "i8x16.extract_lane_s":
+		inst = &I8x16ExtractLaneS{}
+	case "i8x16.extract_lane_u":
+		inst = &I8x16ExtractLaneU{}
+	case "i8x16.replace_lane":
+		inst = &I8x16ReplaceLane{}
+	case "i16x8.splat":
+		inst = &I16x8Splat{}
+	case "i16x8.extract_lane_s":
+		inst = &I16x8ExtractLaneS{}
+	case "i16x8.extract_lane_u":
+		inst = &I16x8ExtractLaneU{}
+	case "i16x8.replace_lane":
+		inst = &I16x8ReplaceLane{}
+	case "i32x4.splat":
+		inst = &I32x4Splat{}
+	case "i32x4.extract_lane":
+		inst = &I32x4ExtractLane{}
+	case "i32x4.replace_lane":
+		inst = &I32x4ReplaceLane{}
+	case "i64x2.splat":
+		inst = &I64x2Splat{}
+	case "i64x2.extract_lane":
+		inst = &I64x2ExtractLane{}
+	case "i64x2.replace_lane":
+		inst = &I64x2ReplaceLane{}
+	case "f32x4.splat":
+		inst = &F32x4Splat{}
+	case "f32x4.extract_lane":
+		inst = &F32x4ExtractLane{}
+	case "f32x4.replace_lane":
+		inst = &F32x4ReplaceLane{}
+	case "f64x2.splat":
+		inst = &F64x2Splat{}
+	case "f64x2.extract_lane":
+		inst = &F64x2ExtractLane{}
+	case "f64x2.replace_lane":
+		inst = &F64x2ReplaceLane{}
 	case "i8x16.eq":
 		inst = &I8x16Eq{}
 	case "i8x16.ne":
@@ -8976,6 +14840,8 @@ func parseInstr(ps *parser.ParserBuffer) (Instruction, error) {
 		inst = &F64x2ConvertI64x2U{}
 	case "v8x16.swizzle":
 		inst = &V8x16Swizzle{}
+	case "v8x16.shuffle":
+		inst = &V8x16Shuffle{}
 	case "v8x16.load_splat":
 		inst = &V8x16LoadSplat{}
 	case "v16x8.load_splat":
@@ -9023,11 +14889,1012 @@ func parseInstr(ps *parser.ParserBuffer) (Instruction, error) {
 	case "v128.andnot":
 		inst = &V128Andnot{}
 	default:
-		panic(fmt.Sprintf("todo: implement instruction %s", kw))
+		return nil, unknownInstr(ps, span, kw)
 	}
 	err = inst.parseInstrBody(ps)
 	if err != nil {
 		return nil, err
 	}
+	err = requireInstrFeature(ps, span, inst)
+	if err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+func decodeInstr(source *ZeroCopySource) (Instruction, error) {
+	var inst Instruction
+	pos := source.Pos()
+	op, err := source.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case 0x2:
+		inst = &Block{}
+	case 0x4:
+		inst = &If{}
+	case 0x5:
+		inst = &Else{}
+	case 0x3:
+		inst = &Loop{}
+	case 0xb:
+		inst = &End{}
+	case 0x6:
+		inst = &Try{}
+	case 0x7:
+		inst = &Catch{}
+	case 0x19:
+		inst = &CatchAll{}
+	case 0x18:
+		inst = &Delegate{}
+	case 0x8:
+		inst = &Throw{}
+	case 0x9:
+		inst = &Rethrow{}
+	case 0x0:
+		inst = &Unreachable{}
+	case 0x1:
+		inst = &Nop{}
+	case 0xc:
+		inst = &Br{}
+	case 0xd:
+		inst = &BrIf{}
+	case 0xe:
+		inst = &BrTable{}
+	case 0xf:
+		inst = &Return{}
+	case 0x10:
+		inst = &Call{}
+	case 0x11:
+		inst = &CallIndirect{}
+	case 0x12:
+		inst = &ReturnCall{}
+	case 0x13:
+		inst = &ReturnCallIndirect{}
+	case 0x1a:
+		inst = &Drop{}
+	case 0x1b, 0x1c:
+		inst = &Select{}
+		source.BackUp(1)
+	case 0x20:
+		inst = &LocalGet{}
+	case 0x21:
+		inst = &LocalSet{}
+	case 0x22:
+		inst = &LocalTee{}
+	case 0x23:
+		inst = &GlobalGet{}
+	case 0x24:
+		inst = &GlobalSet{}
+	case 0x25:
+		inst = &TableGet{}
+	case 0x26:
+		inst = &TableSet{}
+	case 0x28:
+		inst = &I32Load{}
+	case 0x29:
+		inst = &I64Load{}
+	case 0x2a:
+		inst = &F32Load{}
+	case 0x2b:
+		inst = &F64Load{}
+	case 0x2c:
+		inst = &I32Load8s{}
+	case 0x2d:
+		inst = &I32Load8u{}
+	case 0x2e:
+		inst = &I32Load16s{}
+	case 0x2f:
+		inst = &I32Load16u{}
+	case 0x30:
+		inst = &I64Load8s{}
+	case 0x31:
+		inst = &I64Load8u{}
+	case 0x32:
+		inst = &I64Load16s{}
+	case 0x33:
+		inst = &I64Load16u{}
+	case 0x34:
+		inst = &I64Load32s{}
+	case 0x35:
+		inst = &I64Load32u{}
+	case 0x36:
+		inst = &I32Store{}
+	case 0x37:
+		inst = &I64Store{}
+	case 0x38:
+		inst = &F32Store{}
+	case 0x39:
+		inst = &F64Store{}
+	case 0x3a:
+		inst = &I32Store8{}
+	case 0x3b:
+		inst = &I32Store16{}
+	case 0x3c:
+		inst = &I64Store8{}
+	case 0x3d:
+		inst = &I64Store16{}
+	case 0x3e:
+		inst = &I64Store32{}
+	case 0x3f:
+		inst = &MemorySize{}
+	case 0x40:
+		inst = &MemoryGrow{}
+	case 0xd0:
+		inst = &RefNull{}
+	case 0xd1:
+		inst = &RefIsNull{}
+	case 0xff:
+		inst = &RefHost{}
+	case 0xd2:
+		inst = &RefFunc{}
+	case 0x41:
+		inst = &I32Const{}
+	case 0x42:
+		inst = &I64Const{}
+	case 0x43:
+		inst = &F32Const{}
+	case 0x44:
+		inst = &F64Const{}
+	case 0x67:
+		inst = &I32Clz{}
+	case 0x68:
+		inst = &I32Ctz{}
+	case 0x69:
+		inst = &I32Pocnt{}
+	case 0x6a:
+		inst = &I32Add{}
+	case 0x6b:
+		inst = &I32Sub{}
+	case 0x6c:
+		inst = &I32Mul{}
+	case 0x6d:
+		inst = &I32DivS{}
+	case 0x6e:
+		inst = &I32DivU{}
+	case 0x6f:
+		inst = &I32RemS{}
+	case 0x70:
+		inst = &I32RemU{}
+	case 0x71:
+		inst = &I32And{}
+	case 0x72:
+		inst = &I32Or{}
+	case 0x73:
+		inst = &I32Xor{}
+	case 0x74:
+		inst = &I32Shl{}
+	case 0x75:
+		inst = &I32ShrS{}
+	case 0x76:
+		inst = &I32ShrU{}
+	case 0x77:
+		inst = &I32Rotl{}
+	case 0x78:
+		inst = &I32Rotr{}
+	case 0x79:
+		inst = &I64Clz{}
+	case 0x7a:
+		inst = &I64Ctz{}
+	case 0x7b:
+		inst = &I64Popcnt{}
+	case 0x7c:
+		inst = &I64Add{}
+	case 0x7d:
+		inst = &I64Sub{}
+	case 0x7e:
+		inst = &I64Mul{}
+	case 0x7f:
+		inst = &I64DivS{}
+	case 0x80:
+		inst = &I64DivU{}
+	case 0x81:
+		inst = &I64RemS{}
+	case 0x82:
+		inst = &I64RemU{}
+	case 0x83:
+		inst = &I64And{}
+	case 0x84:
+		inst = &I64Or{}
+	case 0x85:
+		inst = &I64Xor{}
+	case 0x86:
+		inst = &I64Shl{}
+	case 0x87:
+		inst = &I64ShrS{}
+	case 0x88:
+		inst = &I64ShrU{}
+	case 0x89:
+		inst = &I64Rotl{}
+	case 0x8a:
+		inst = &I64Rotr{}
+	case 0x8b:
+		inst = &F32Abs{}
+	case 0x8c:
+		inst = &F32Neg{}
+	case 0x8d:
+		inst = &F32Ceil{}
+	case 0x8e:
+		inst = &F32Floor{}
+	case 0x8f:
+		inst = &F32Trunc{}
+	case 0x90:
+		inst = &F32Nearest{}
+	case 0x91:
+		inst = &F32Sqrt{}
+	case 0x92:
+		inst = &F32Add{}
+	case 0x93:
+		inst = &F32Sub{}
+	case 0x94:
+		inst = &F32Mul{}
+	case 0x95:
+		inst = &F32Div{}
+	case 0x96:
+		inst = &F32Min{}
+	case 0x97:
+		inst = &F32Max{}
+	case 0x98:
+		inst = &F32Copysign{}
+	case 0x99:
+		inst = &F64Abs{}
+	case 0x9a:
+		inst = &F64Neg{}
+	case 0x9b:
+		inst = &F64Ceil{}
+	case 0x9c:
+		inst = &F64Floor{}
+	case 0x9d:
+		inst = &F64Trunc{}
+	case 0x9e:
+		inst = &F64Nearest{}
+	case 0x9f:
+		inst = &F64Sqrt{}
+	case 0xa0:
+		inst = &F64Add{}
+	case 0xa1:
+		inst = &F64Sub{}
+	case 0xa2:
+		inst = &F64Mul{}
+	case 0xa3:
+		inst = &F64Div{}
+	case 0xa4:
+		inst = &F64Min{}
+	case 0xa5:
+		inst = &F64Max{}
+	case 0xa6:
+		inst = &F64Copysign{}
+	case 0x45:
+		inst = &I32Eqz{}
+	case 0x46:
+		inst = &I32Eq{}
+	case 0x47:
+		inst = &I32Ne{}
+	case 0x48:
+		inst = &I32LtS{}
+	case 0x49:
+		inst = &I32LtU{}
+	case 0x4a:
+		inst = &I32GtS{}
+	case 0x4b:
+		inst = &I32GtU{}
+	case 0x4c:
+		inst = &I32LeS{}
+	case 0x4d:
+		inst = &I32LeU{}
+	case 0x4e:
+		inst = &I32GeS{}
+	case 0x4f:
+		inst = &I32GeU{}
+	case 0x50:
+		inst = &I64Eqz{}
+	case 0x51:
+		inst = &I64Eq{}
+	case 0x52:
+		inst = &I64Ne{}
+	case 0x53:
+		inst = &I64LtS{}
+	case 0x54:
+		inst = &I64LtU{}
+	case 0x55:
+		inst = &I64GtS{}
+	case 0x56:
+		inst = &I64GtU{}
+	case 0x57:
+		inst = &I64LeS{}
+	case 0x58:
+		inst = &I64LeU{}
+	case 0x59:
+		inst = &I64GeS{}
+	case 0x5a:
+		inst = &I64GeU{}
+	case 0x5b:
+		inst = &F32Eq{}
+	case 0x5c:
+		inst = &F32Ne{}
+	case 0x5d:
+		inst = &F32Lt{}
+	case 0x5e:
+		inst = &F32Gt{}
+	case 0x5f:
+		inst = &F32Le{}
+	case 0x60:
+		inst = &F32Ge{}
+	case 0x61:
+		inst = &F64Eq{}
+	case 0x62:
+		inst = &F64Ne{}
+	case 0x63:
+		inst = &F64Lt{}
+	case 0x64:
+		inst = &F64Gt{}
+	case 0x65:
+		inst = &F64Le{}
+	case 0x66:
+		inst = &F64Ge{}
+	case 0xa7:
+		inst = &I32WrapI64{}
+	case 0xa8:
+		inst = &I32TruncF32S{}
+	case 0xa9:
+		inst = &I32TruncF32U{}
+	case 0xaa:
+		inst = &I32TruncF64S{}
+	case 0xab:
+		inst = &I32TruncF64U{}
+	case 0xac:
+		inst = &I64ExtendI32S{}
+	case 0xad:
+		inst = &I64ExtendI32U{}
+	case 0xae:
+		inst = &I64TruncF32S{}
+	case 0xaf:
+		inst = &I64TruncF32U{}
+	case 0xb0:
+		inst = &I64TruncF64S{}
+	case 0xb1:
+		inst = &I64TruncF64U{}
+	case 0xb2:
+		inst = &F32ConvertI32S{}
+	case 0xb3:
+		inst = &F32ConvertI32U{}
+	case 0xb4:
+		inst = &F32ConvertI64S{}
+	case 0xb5:
+		inst = &F32ConvertI64U{}
+	case 0xb6:
+		inst = &F32DemoteF64{}
+	case 0xb7:
+		inst = &F64ConvertI32S{}
+	case 0xb8:
+		inst = &F64ConvertI32U{}
+	case 0xb9:
+		inst = &F64ConvertI64S{}
+	case 0xba:
+		inst = &F64ConvertI64U{}
+	case 0xbb:
+		inst = &F64PromoteF32{}
+	case 0xbc:
+		inst = &I32ReinterpretF32{}
+	case 0xbd:
+		inst = &I64ReinterpretF64{}
+	case 0xbe:
+		inst = &F32ReinterpretI32{}
+	case 0xbf:
+		inst = &F64ReinterpretI64{}
+	case 0xc0:
+		inst = &I32Extend8S{}
+	case 0xc1:
+		inst = &I32Extend16S{}
+	case 0xc2:
+		inst = &I64Extend8S{}
+	case 0xc3:
+		inst = &I64Extend16S{}
+	case 0xc4:
+		inst = &I64Extend32S{}
+	case 0xfc:
+		sub, err := source.ReadUint32()
+		if err != nil {
+			return nil, err
+		}
+		switch sub {
+		case 0x8:
+			inst = &MemoryInit{}
+		case 0xa:
+			inst = &MemoryCopy{}
+		case 0xb:
+			inst = &MemoryFill{}
+		case 0x9:
+			inst = &DataDrop{}
+		case 0xd:
+			inst = &ElemDrop{}
+		case 0xc:
+			inst = &TableInit{}
+		case 0xe:
+			inst = &TableCopy{}
+		case 0x11:
+			inst = &TableFill{}
+		case 0x10:
+			inst = &TableSize{}
+		case 0xf:
+			inst = &TableGrow{}
+		case 0x0:
+			inst = &I32TruncSatF32S{}
+		case 0x1:
+			inst = &I32TruncSatF32U{}
+		case 0x2:
+			inst = &I32TruncSatF64S{}
+		case 0x3:
+			inst = &I32TruncSatF64U{}
+		case 0x4:
+			inst = &I64TruncSatF32S{}
+		case 0x5:
+			inst = &I64TruncSatF32U{}
+		case 0x6:
+			inst = &I64TruncSatF64S{}
+		case 0x7:
+			inst = &I64TruncSatF64U{}
+		default:
+			return nil, source.Errorf(pos, "illegal opcode 0x%x 0x%x", op, sub)
+		}
+	case 0xfe:
+		sub, err := source.ReadUint32()
+		if err != nil {
+			return nil, err
+		}
+		switch sub {
+		case 0x0:
+			inst = &AtomicNotify{}
+		case 0x1:
+			inst = &I32AtomicWait{}
+		case 0x2:
+			inst = &I64AtomicWait{}
+		case 0x3:
+			inst = &AtomicFence{}
+		case 0x10:
+			inst = &I32AtomicLoad{}
+		case 0x11:
+			inst = &I64AtomicLoad{}
+		case 0x12:
+			inst = &I32AtomicLoad8u{}
+		case 0x13:
+			inst = &I32AtomicLoad16u{}
+		case 0x14:
+			inst = &I64AtomicLoad8u{}
+		case 0x15:
+			inst = &I64AtomicLoad16u{}
+		case 0x16:
+			inst = &I64AtomicLoad32u{}
+		case 0x17:
+			inst = &I32AtomicStore{}
+		case 0x18:
+			inst = &I64AtomicStore{}
+		case 0x19:
+			inst = &I32AtomicStore8{}
+		case 0x1a:
+			inst = &I32AtomicStore16{}
+		case 0x1b:
+			inst = &I64AtomicStore8{}
+		case 0x1c:
+			inst = &I64AtomicStore16{}
+		case 0x1d:
+			inst = &I64AtomicStore32{}
+		case 0x1e:
+			inst = &I32AtomicRmwAdd{}
+		case 0x1f:
+			inst = &I64AtomicRmwAdd{}
+		case 0x20:
+			inst = &I32AtomicRmw8AddU{}
+		case 0x21:
+			inst = &I32AtomicRmw16AddU{}
+		case 0x22:
+			inst = &I64AtomicRmw8AddU{}
+		case 0x23:
+			inst = &I64AtomicRmw16AddU{}
+		case 0x24:
+			inst = &I64AtomicRmw32AddU{}
+		case 0x25:
+			inst = &I32AtomicRmwSub{}
+		case 0x26:
+			inst = &I64AtomicRmwSub{}
+		case 0x27:
+			inst = &I32AtomicRmw8SubU{}
+		case 0x28:
+			inst = &I32AtomicRmw16SubU{}
+		case 0x29:
+			inst = &I64AtomicRmw8SubU{}
+		case 0x2a:
+			inst = &I64AtomicRmw16SubU{}
+		case 0x2b:
+			inst = &I64AtomicRmw32SubU{}
+		case 0x2c:
+			inst = &I32AtomicRmwAnd{}
+		case 0x2d:
+			inst = &I64AtomicRmwAnd{}
+		case 0x2e:
+			inst = &I32AtomicRmw8AndU{}
+		case 0x2f:
+			inst = &I32AtomicRmw16AndU{}
+		case 0x30:
+			inst = &I64AtomicRmw8AndU{}
+		case 0x31:
+			inst = &I64AtomicRmw16AndU{}
+		case 0x32:
+			inst = &I64AtomicRmw32AndU{}
+		case 0x33:
+			inst = &I32AtomicRmwOr{}
+		case 0x34:
+			inst = &I64AtomicRmwOr{}
+		case 0x35:
+			inst = &I32AtomicRmw8OrU{}
+		case 0x36:
+			inst = &I32AtomicRmw16OrU{}
+		case 0x37:
+			inst = &I64AtomicRmw8OrU{}
+		case 0x38:
+			inst = &I64AtomicRmw16OrU{}
+		case 0x39:
+			inst = &I64AtomicRmw32OrU{}
+		case 0x3a:
+			inst = &I32AtomicRmwXor{}
+		case 0x3b:
+			inst = &I64AtomicRmwXor{}
+		case 0x3c:
+			inst = &I32AtomicRmw8XorU{}
+		case 0x3d:
+			inst = &I32AtomicRmw16XorU{}
+		case 0x3e:
+			inst = &I64AtomicRmw8XorU{}
+		case 0x3f:
+			inst = &I64AtomicRmw16XorU{}
+		case 0x40:
+			inst = &I64AtomicRmw32XorU{}
+		case 0x41:
+			inst = &I32AtomicRmwXchg{}
+		case 0x42:
+			inst = &I64AtomicRmwXchg{}
+		case 0x43:
+			inst = &I32AtomicRmw8XchgU{}
+		case 0x44:
+			inst = &I32AtomicRmw16XchgU{}
+		case 0x45:
+			inst = &I64AtomicRmw8XchgU{}
+		case 0x46:
+			inst = &I64AtomicRmw16XchgU{}
+		case 0x47:
+			inst = &I64AtomicRmw32XchgU{}
+		case 0x48:
+			inst = &I32AtomicRmwCmpxchg{}
+		case 0x49:
+			inst = &I64AtomicRmwCmpxchg{}
+		case 0x4a:
+			inst = &I32AtomicRmw8CmpxchgU{}
+		case 0x4b:
+			inst = &I32AtomicRmw16CmpxchgU{}
+		case 0x4c:
+			inst = &I64AtomicRmw8CmpxchgU{}
+		case 0x4d:
+			inst = &I64AtomicRmw16CmpxchgU{}
+		case 0x4e:
+			inst = &I64AtomicRmw32CmpxchgU{}
+		default:
+			return nil, source.Errorf(pos, "illegal opcode 0x%x 0x%x", op, sub)
+		}
+	case 0xfd:
+		sub, err := source.ReadUint32()
+		if err != nil {
+			return nil, err
+		}
+		switch sub {
+		case 0x0:
+			inst = &V128Load{}
+		case 0x1:
+			inst = &V128Store{}
+		case 0x2:
+			inst = &V128Const{}
+		case 0x4:
+			inst = &I8x16Splat{}
+		case 0x5:
+			inst = &I8x16ExtractLaneS{}
+		case 0x6:
+			inst = &I8x16ExtractLaneU{}
+		case 0x7:
+			inst = &I8x16ReplaceLane{}
+		case 0x8:
+			inst = &I16x8Splat{}
+		case 0x9:
+			inst = &I16x8ExtractLaneS{}
+		case 0xa:
+			inst = &I16x8ExtractLaneU{}
+		case 0xb:
+			inst = &I16x8ReplaceLane{}
+		case 0xc:
+			inst = &I32x4Splat{}
+		case 0xd:
+			inst = &I32x4ExtractLane{}
+		case 0xe:
+			inst = &I32x4ReplaceLane{}
+		case 0xf:
+			inst = &I64x2Splat{}
+		case 0x10:
+			inst = &I64x2ExtractLane{}
+		case 0x11:
+			inst = &I64x2ReplaceLane{}
+		case 0x12:
+			inst = &F32x4Splat{}
+		case 0x13:
+			inst = &F32x4ExtractLane{}
+		case 0x14:
+			inst = &F32x4ReplaceLane{}
+		case 0x15:
+			inst = &F64x2Splat{}
+		case 0x16:
+			inst = &F64x2ExtractLane{}
+		case 0x17:
+			inst = &F64x2ReplaceLane{}
+		case 0x18:
+			inst = &I8x16Eq{}
+		case 0x19:
+			inst = &I8x16Ne{}
+		case 0x1a:
+			inst = &I8x16LtS{}
+		case 0x1b:
+			inst = &I8x16LtU{}
+		case 0x1c:
+			inst = &I8x16GtS{}
+		case 0x1d:
+			inst = &I8x16GtU{}
+		case 0x1e:
+			inst = &I8x16LeS{}
+		case 0x1f:
+			inst = &I8x16LeU{}
+		case 0x20:
+			inst = &I8x16GeS{}
+		case 0x21:
+			inst = &I8x16GeU{}
+		case 0x22:
+			inst = &I16x8Eq{}
+		case 0x23:
+			inst = &I16x8Ne{}
+		case 0x24:
+			inst = &I16x8LtS{}
+		case 0x25:
+			inst = &I16x8LtU{}
+		case 0x26:
+			inst = &I16x8GtS{}
+		case 0x27:
+			inst = &I16x8GtU{}
+		case 0x28:
+			inst = &I16x8LeS{}
+		case 0x29:
+			inst = &I16x8LeU{}
+		case 0x2a:
+			inst = &I16x8GeS{}
+		case 0x2b:
+			inst = &I16x8GeU{}
+		case 0x2c:
+			inst = &I32x4Eq{}
+		case 0x2d:
+			inst = &I32x4Ne{}
+		case 0x2e:
+			inst = &I32x4LtS{}
+		case 0x2f:
+			inst = &I32x4LtU{}
+		case 0x30:
+			inst = &I32x4GtS{}
+		case 0x31:
+			inst = &I32x4GtU{}
+		case 0x32:
+			inst = &I32x4LeS{}
+		case 0x33:
+			inst = &I32x4LeU{}
+		case 0x34:
+			inst = &I32x4GeS{}
+		case 0x35:
+			inst = &I32x4GeU{}
+		case 0x40:
+			inst = &F32x4Eq{}
+		case 0x41:
+			inst = &F32x4Ne{}
+		case 0x42:
+			inst = &F32x4Lt{}
+		case 0x43:
+			inst = &F32x4Gt{}
+		case 0x44:
+			inst = &F32x4Le{}
+		case 0x45:
+			inst = &F32x4Ge{}
+		case 0x46:
+			inst = &F64x2Eq{}
+		case 0x47:
+			inst = &F64x2Ne{}
+		case 0x48:
+			inst = &F64x2Lt{}
+		case 0x49:
+			inst = &F64x2Gt{}
+		case 0x4a:
+			inst = &F64x2Le{}
+		case 0x4b:
+			inst = &F64x2Ge{}
+		case 0x4c:
+			inst = &V128Not{}
+		case 0x4d:
+			inst = &V128And{}
+		case 0x4e:
+			inst = &V128Or{}
+		case 0x4f:
+			inst = &V128Xor{}
+		case 0x50:
+			inst = &V128Bitselect{}
+		case 0x51:
+			inst = &I8x16Neg{}
+		case 0x52:
+			inst = &I8x16AnyTrue{}
+		case 0x53:
+			inst = &I8x16AllTrue{}
+		case 0x54:
+			inst = &I8x16Shl{}
+		case 0x55:
+			inst = &I8x16ShrS{}
+		case 0x56:
+			inst = &I8x16ShrU{}
+		case 0x57:
+			inst = &I8x16Add{}
+		case 0x58:
+			inst = &I8x16AddSaturateS{}
+		case 0x59:
+			inst = &I8x16AddSaturateU{}
+		case 0x5a:
+			inst = &I8x16Sub{}
+		case 0x5b:
+			inst = &I8x16SubSaturateS{}
+		case 0x5c:
+			inst = &I8x16SubSaturateU{}
+		case 0x5d:
+			inst = &I8x16Mul{}
+		case 0x62:
+			inst = &I16x8Neg{}
+		case 0x63:
+			inst = &I16x8AnyTrue{}
+		case 0x64:
+			inst = &I16x8AllTrue{}
+		case 0x65:
+			inst = &I16x8Shl{}
+		case 0x66:
+			inst = &I16x8ShrS{}
+		case 0x67:
+			inst = &I16x8ShrU{}
+		case 0x68:
+			inst = &I16x8Add{}
+		case 0x69:
+			inst = &I16x8AddSaturateS{}
+		case 0x6a:
+			inst = &I16x8AddSaturateU{}
+		case 0x6b:
+			inst = &I16x8Sub{}
+		case 0x6c:
+			inst = &I16x8SubSaturateS{}
+		case 0x6d:
+			inst = &I16x8SubSaturateU{}
+		case 0x6e:
+			inst = &I16x8Mul{}
+		case 0x73:
+			inst = &I32x4Neg{}
+		case 0x74:
+			inst = &I32x4AnyTrue{}
+		case 0x75:
+			inst = &I32x4AllTrue{}
+		case 0x76:
+			inst = &I32x4Shl{}
+		case 0x77:
+			inst = &I32x4ShrS{}
+		case 0x78:
+			inst = &I32x4ShrU{}
+		case 0x79:
+			inst = &I32x4Add{}
+		case 0x7c:
+			inst = &I32x4Sub{}
+		case 0x7f:
+			inst = &I32x4Mul{}
+		case 0x84:
+			inst = &I64x2Neg{}
+		case 0x85:
+			inst = &I64x2AnyTrue{}
+		case 0x86:
+			inst = &I64x2AllTrue{}
+		case 0x87:
+			inst = &I64x2Shl{}
+		case 0x88:
+			inst = &I64x2ShrS{}
+		case 0x89:
+			inst = &I64x2ShrU{}
+		case 0x8a:
+			inst = &I64x2Add{}
+		case 0x8d:
+			inst = &I64x2Sub{}
+		case 0x90:
+			inst = &I64x2Mul{}
+		case 0x95:
+			inst = &F32x4Abs{}
+		case 0x96:
+			inst = &F32x4Neg{}
+		case 0x97:
+			inst = &F32x4Sqrt{}
+		case 0x9a:
+			inst = &F32x4Add{}
+		case 0x9b:
+			inst = &F32x4Sub{}
+		case 0x9c:
+			inst = &F32x4Mul{}
+		case 0x9d:
+			inst = &F32x4Div{}
+		case 0x9e:
+			inst = &F32x4Min{}
+		case 0x9f:
+			inst = &F32x4Max{}
+		case 0xa0:
+			inst = &F64x2Abs{}
+		case 0xa1:
+			inst = &F64x2Neg{}
+		case 0xa2:
+			inst = &F64x2Sqrt{}
+		case 0xa5:
+			inst = &F64x2Add{}
+		case 0xa6:
+			inst = &F64x2Sub{}
+		case 0xa7:
+			inst = &F64x2Mul{}
+		case 0xa8:
+			inst = &F64x2Div{}
+		case 0xa9:
+			inst = &F64x2Min{}
+		case 0xaa:
+			inst = &F64x2Max{}
+		case 0xab:
+			inst = &I32x4TruncSatF32x4S{}
+		case 0xac:
+			inst = &I32x4TruncSatF32x4U{}
+		case 0xad:
+			inst = &I64x2TruncSatF64x2S{}
+		case 0xae:
+			inst = &I64x2TruncSatF64x2U{}
+		case 0xaf:
+			inst = &F32x4ConvertI32x4S{}
+		case 0xb0:
+			inst = &F32x4ConvertI32x4U{}
+		case 0xb1:
+			inst = &F64x2ConvertI64x2S{}
+		case 0xb2:
+			inst = &F64x2ConvertI64x2U{}
+		case 0xc0:
+			inst = &V8x16Swizzle{}
+		case 0xc1:
+			inst = &V8x16Shuffle{}
+		case 0xc2:
+			inst = &V8x16LoadSplat{}
+		case 0xc3:
+			inst = &V16x8LoadSplat{}
+		case 0xc4:
+			inst = &V32x4LoadSplat{}
+		case 0xc5:
+			inst = &V64x2LoadSplat{}
+		case 0xc6:
+			inst = &I8x16NarrowI16x8S{}
+		case 0xc7:
+			inst = &I8x16NarrowI16x8U{}
+		case 0xc8:
+			inst = &I16x8NarrowI32x4S{}
+		case 0xc9:
+			inst = &I16x8NarrowI32x4U{}
+		case 0xca:
+			inst = &I16x8WidenLowI8x16S{}
+		case 0xcb:
+			inst = &I16x8WidenHighI8x16S{}
+		case 0xcc:
+			inst = &I16x8WidenLowI8x16U{}
+		case 0xcd:
+			inst = &I16x8WidenHighI8x16u{}
+		case 0xce:
+			inst = &I32x4WidenLowI16x8S{}
+		case 0xcf:
+			inst = &I32x4WidenHighI16x8S{}
+		case 0xd0:
+			inst = &I32x4WidenLowI16x8U{}
+		case 0xd1:
+			inst = &I32x4WidenHighI16x8u{}
+		case 0xd2:
+			inst = &I16x8Load8x8S{}
+		case 0xd3:
+			inst = &I16x8Load8x8U{}
+		case 0xd4:
+			inst = &I32x4Load16x4S{}
+		case 0xd5:
+			inst = &I32x4Load16x4U{}
+		case 0xd6:
+			inst = &I64x2Load32x2S{}
+		case 0xd7:
+			inst = &I64x2Load32x2U{}
+		case 0xd8:
+			inst = &V128Andnot{}
+		default:
+			return nil, source.Errorf(pos, "illegal opcode 0x%x 0x%x", op, sub)
+		}
+	default:
+		return nil, source.Errorf(pos, "illegal opcode 0x%x", op)
+	}
+	err = inst.decodeInstrBody(source)
+	if err != nil {
+		return nil, err
+	}
 	return inst, nil
 }
+
+// instrFeature returns the proposal an instruction belongs to, 0 for the
+// MVP.
+func instrFeature(instr Instruction) Features {
+	switch inst := instr.(type) {
+	case *Select:
+		if len(inst.SelectTypes.Types) != 0 {
+			return FeatureReferenceTypes
+		}
+	case *Try, *Catch, *CatchAll, *Delegate, *Throw, *Rethrow:
+		return FeatureExceptions
+	case *ReturnCall, *ReturnCallIndirect:
+		return FeatureTailCall
+	case *TableGet, *TableSet, *TableFill, *TableSize, *TableGrow, *RefNull,
+		*RefIsNull, *RefFunc:
+		return FeatureReferenceTypes
+	case *MemoryInit, *MemoryCopy, *MemoryFill, *DataDrop, *ElemDrop, *TableInit,
+		*TableCopy:
+		return FeatureBulkMemory
+	case *I32TruncSatF32S, *I32TruncSatF32U, *I32TruncSatF64S, *I32TruncSatF64U, *I64TruncSatF32S, *I64TruncSatF32U,
+		*I64TruncSatF64S, *I64TruncSatF64U:
+		return FeatureSatTrunc
+	case *I32Extend8S, *I32Extend16S, *I64Extend8S, *I64Extend16S, *I64Extend32S:
+		return FeatureSignExt
+	case *AtomicNotify, *I32AtomicWait, *I64AtomicWait, *AtomicFence, *I32AtomicLoad, *I64AtomicLoad,
+		*I32AtomicLoad8u, *I32AtomicLoad16u, *I64AtomicLoad8u, *I64AtomicLoad16u, *I64AtomicLoad32u, *I32AtomicStore,
+		*I64AtomicStore, *I32AtomicStore8, *I32AtomicStore16, *I64AtomicStore8, *I64AtomicStore16, *I64AtomicStore32,
+		*I32AtomicRmwAdd, *I64AtomicRmwAdd, *I32AtomicRmw8AddU, *I32AtomicRmw16AddU, *I64AtomicRmw8AddU, *I64AtomicRmw16AddU,
+		*I64AtomicRmw32AddU, *I32AtomicRmwSub, *I64AtomicRmwSub, *I32AtomicRmw8SubU, *I32AtomicRmw16SubU, *I64AtomicRmw8SubU,
+		*I64AtomicRmw16SubU, *I64AtomicRmw32SubU, *I32AtomicRmwAnd, *I64AtomicRmwAnd, *I32AtomicRmw8AndU, *I32AtomicRmw16AndU,
+		*I64AtomicRmw8AndU, *I64AtomicRmw16AndU, *I64AtomicRmw32AndU, *I32AtomicRmwOr, *I64AtomicRmwOr, *I32AtomicRmw8OrU,
+		*I32AtomicRmw16OrU, *I64AtomicRmw8OrU, *I64AtomicRmw16OrU, *I64AtomicRmw32OrU, *I32AtomicRmwXor, *I64AtomicRmwXor,
+		*I32AtomicRmw8XorU, *I32AtomicRmw16XorU, *I64AtomicRmw8XorU, *I64AtomicRmw16XorU, *I64AtomicRmw32XorU, *I32AtomicRmwXchg,
+		*I64AtomicRmwXchg, *I32AtomicRmw8XchgU, *I32AtomicRmw16XchgU, *I64AtomicRmw8XchgU, *I64AtomicRmw16XchgU, *I64AtomicRmw32XchgU,
+		*I32AtomicRmwCmpxchg, *I64AtomicRmwCmpxchg, *I32AtomicRmw8CmpxchgU, *I32AtomicRmw16CmpxchgU, *I64AtomicRmw8CmpxchgU, *I64AtomicRmw16CmpxchgU,
+		*I64AtomicRmw32CmpxchgU:
+		return FeatureThreads
+	case *V128Load, *V128Store, *V128Const, *I8x16Splat, *I8x16ExtractLaneS, *I8x16ExtractLaneU,
+		*I8x16ReplaceLane, *I16x8Splat, *I16x8ExtractLaneS, *I16x8ExtractLaneU, *I16x8ReplaceLane, *I32x4Splat,
+		*I32x4ExtractLane, *I32x4ReplaceLane, *I64x2Splat, *I64x2ExtractLane, *I64x2ReplaceLane, *F32x4Splat,
+		*F32x4ExtractLane, *F32x4ReplaceLane, *F64x2Splat, *F64x2ExtractLane, *F64x2ReplaceLane, *I8x16Eq,
+		*I8x16Ne, *I8x16LtS, *I8x16LtU, *I8x16GtS, *I8x16GtU, *I8x16LeS,
+		*I8x16LeU, *I8x16GeS, *I8x16GeU, *I16x8Eq, *I16x8Ne, *I16x8LtS,
+		*I16x8LtU, *I16x8GtS, *I16x8GtU, *I16x8LeS, *I16x8LeU, *I16x8GeS,
+		*I16x8GeU, *I32x4Eq, *I32x4Ne, *I32x4LtS, *I32x4LtU, *I32x4GtS,
+		*I32x4GtU, *I32x4LeS, *I32x4LeU, *I32x4GeS, *I32x4GeU, *F32x4Eq,
+		*F32x4Ne, *F32x4Lt, *F32x4Gt, *F32x4Le, *F32x4Ge, *F64x2Eq,
+		*F64x2Ne, *F64x2Lt, *F64x2Gt, *F64x2Le, *F64x2Ge, *V128Not,
+		*V128And, *V128Or, *V128Xor, *V128Bitselect, *I8x16Neg, *I8x16AnyTrue,
+		*I8x16AllTrue, *I8x16Shl, *I8x16ShrS, *I8x16ShrU, *I8x16Add, *I8x16AddSaturateS,
+		*I8x16AddSaturateU, *I8x16Sub, *I8x16SubSaturateS, *I8x16SubSaturateU, *I8x16Mul, *I16x8Neg,
+		*I16x8AnyTrue, *I16x8AllTrue, *I16x8Shl, *I16x8ShrS, *I16x8ShrU, *I16x8Add,
+		*I16x8AddSaturateS, *I16x8AddSaturateU, *I16x8Sub, *I16x8SubSaturateS, *I16x8SubSaturateU, *I16x8Mul,
+		*I32x4Neg, *I32x4AnyTrue, *I32x4AllTrue, *I32x4Shl, *I32x4ShrS, *I32x4ShrU,
+		*I32x4Add, *I32x4Sub, *I32x4Mul, *I64x2Neg, *I64x2AnyTrue, *I64x2AllTrue,
+		*I64x2Shl, *I64x2ShrS, *I64x2ShrU, *I64x2Add, *I64x2Sub, *I64x2Mul,
+		*F32x4Abs, *F32x4Neg, *F32x4Sqrt, *F32x4Add, *F32x4Sub, *F32x4Mul,
+		*F32x4Div, *F32x4Min, *F32x4Max, *F64x2Abs, *F64x2Neg, *F64x2Sqrt,
+		*F64x2Add, *F64x2Sub, *F64x2Mul, *F64x2Div, *F64x2Min, *F64x2Max,
+		*I32x4TruncSatF32x4S, *I32x4TruncSatF32x4U, *I64x2TruncSatF64x2S, *I64x2TruncSatF64x2U, *F32x4ConvertI32x4S, *F32x4ConvertI32x4U,
+		*F64x2ConvertI64x2S, *F64x2ConvertI64x2U, *V8x16Swizzle, *V8x16Shuffle, *V8x16LoadSplat, *V16x8LoadSplat,
+		*V32x4LoadSplat, *V64x2LoadSplat, *I8x16NarrowI16x8S, *I8x16NarrowI16x8U, *I16x8NarrowI32x4S, *I16x8NarrowI32x4U,
+		*I16x8WidenLowI8x16S, *I16x8WidenHighI8x16S, *I16x8WidenLowI8x16U, *I16x8WidenHighI8x16u, *I32x4WidenLowI16x8S, *I32x4WidenHighI16x8S,
+		*I32x4WidenLowI16x8U, *I32x4WidenHighI16x8u, *I16x8Load8x8S, *I16x8Load8x8U, *I32x4Load16x4S, *I32x4Load16x4U,
+		*I64x2Load32x2S, *I64x2Load32x2U, *V128Andnot:
+		return FeatureSimd
+	}
+	return 0
+}
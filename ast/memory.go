@@ -1,7 +1,6 @@
 package ast
 
 import (
-	"fmt"
 	"github.com/ontio/wast-parser/lexer"
 	"github.com/ontio/wast-parser/parser"
 )
@@ -27,9 +26,26 @@ func (self *Memory) Parse(ps *parser.ParserBuffer) error {
 
 	// Afterwards figure out which style this is, either:
 	//  *   `(data ...)`
+	//  *   `i64 (data ...)`
 	//  *   `(import "a" "b") limits`
 	//  *   `limits`
-	if ps.PeekToken().Type() == lexer.LParenType {
+	if (matchKeyword(ps.PeekToken(), "i64") || matchKeyword(ps.PeekToken(), "i32")) &&
+		matchTokenType(ps.Peek2Token(), lexer.LParenType) {
+		is64, err := parseIndexType(ps)
+		if err != nil {
+			return err
+		}
+		err = ps.Parens(func(ps *parser.ParserBuffer) error {
+			err := ps.ExpectKeywordMatch("data")
+			if err != nil {
+				return err
+			}
+			self.Kind = &MemoryKindInline{Is64: is64}
+			return self.Kind.parseMemoryKindBody(ps)
+		})
+		return err
+	}
+	if matchTokenType(ps.PeekToken(), lexer.LParenType) {
 		err := ps.Parens(func(ps *parser.ParserBuffer) error {
 			kw, err := ps.ExpectKeyword()
 			if err != nil {
@@ -40,11 +56,11 @@ func (self *Memory) Parse(ps *parser.ParserBuffer) error {
 				self.Kind = &MemoryKindInline{}
 				self.Kind.parseMemoryKindBody(ps)
 			case "import":
-				module, err := ps.ExpectString()
+				module, err := ps.ExpectName()
 				if err != nil {
 					return err
 				}
-				name, err := ps.ExpectString()
+				name, err := ps.ExpectName()
 				if err != nil {
 					return err
 				}
@@ -53,7 +69,7 @@ func (self *Memory) Parse(ps *parser.ParserBuffer) error {
 					Name:   name,
 				}
 			default:
-				return fmt.Errorf("invalid import memory kind: %s", kw)
+				return ps.UnexpectedPrev("data", "import")
 			}
 			return nil
 		})
@@ -67,11 +83,13 @@ func (self *Memory) Parse(ps *parser.ParserBuffer) error {
 			return nil
 		}
 	}
-	_, err = ps.ExpectUint32()
-	if err != nil {
-		return err
+	if !matchKeyword(ps.PeekToken(), "i64") && !matchKeyword(ps.PeekToken(), "i32") {
+		_, err = ps.ExpectUint32()
+		if err != nil {
+			return err
+		}
+		ps.StepBack(1)
 	}
-	ps.StepBack(1)
 
 	self.Kind = &MemoryKindNormal{}
 	return self.Kind.parseMemoryKindBody(ps)
@@ -88,12 +106,12 @@ type MemoryKindImport struct {
 }
 
 func (self *MemoryKindImport) parseMemoryKindBody(ps *parser.ParserBuffer) error {
-	mod, err := ps.ExpectString()
+	mod, err := ps.ExpectName()
 	if err != nil {
 		return err
 	}
 	self.Module = mod
-	name, err := ps.ExpectString()
+	name, err := ps.ExpectName()
 	if err != nil {
 		return err
 	}
@@ -111,7 +129,8 @@ func (self *MemoryKindNormal) parseMemoryKindBody(ps *parser.ParserBuffer) error
 }
 
 type MemoryKindInline struct {
-	Val [][]byte
+	Val  [][]byte
+	Is64 bool
 }
 
 func (self *MemoryKindInline) parseMemoryKindBody(ps *parser.ParserBuffer) error {
@@ -1,16 +1,17 @@
 package ast
 
 import (
-	"fmt"
+	"github.com/ontio/wast-parser/lexer"
 	"github.com/ontio/wast-parser/parser"
 )
 
 type Module struct {
 	implWastDirective
-	implWastExecute
 	implQuoteModule
 	Name OptionId
 	Kind ModuleKind
+	// resolved is set by a successful Resolve, later calls have no effect
+	resolved bool
 }
 
 func (self *Module) Parse(ps *parser.ParserBuffer) error {
@@ -20,10 +21,16 @@ func (self *Module) Parse(ps *parser.ParserBuffer) error {
 	}
 
 	self.Name.Parse(ps)
+
+	return self.parseBody(ps)
+}
+
+// parseBody reads the fields or the binary strings after the name.
+func (self *Module) parseBody(ps *parser.ParserBuffer) error {
 	if matchKeyword(ps.PeekToken(), "binary") {
 		err := ps.ExpectKeywordMatch("binary")
 		if err != nil {
-			panic(err)
+			return err
 		}
 		var data [][]byte
 		for !ps.Empty() {
@@ -37,12 +44,18 @@ func (self *Module) Parse(ps *parser.ParserBuffer) error {
 		self.Kind = ModuleKindBinary{Bins: data}
 	} else {
 		var fields []ModuleField
+		var tables, memories int
 		for !ps.Empty() {
-			err = ps.Parens(func(ps *parser.ParserBuffer) error {
+			err := ps.Parens(func(ps *parser.ParserBuffer) error {
+				span := ps.Pos()
 				field, err := parseModuleField(ps)
 				if err != nil {
 					return err
 				}
+				err = requireIndexSpaces(ps, span, field, &tables, &memories)
+				if err != nil {
+					return err
+				}
 				fields = append(fields, field)
 				return nil
 			})
@@ -57,6 +70,36 @@ func (self *Module) Parse(ps *parser.ParserBuffer) error {
 	return nil
 }
 
+// requireIndexSpaces counts the tables and memories defined or imported by
+// field, a second one needs a proposal.
+func requireIndexSpaces(ps *parser.ParserBuffer, span lexer.Span, field ModuleField, tables, memories *int) error {
+	switch val := field.(type) {
+	case Table:
+		*tables += 1
+	case Memory:
+		*memories += 1
+	case Import:
+		switch val.Item.(type) {
+		case ImportTable:
+			*tables += 1
+		case ImportMemory:
+			*memories += 1
+		}
+	default:
+		return nil
+	}
+	if *tables > 1 {
+		if err := ps.Require(span, FeatureReferenceTypes, "multiple tables"); err != nil {
+			return err
+		}
+	}
+	if *memories > 1 {
+		return ps.Require(span, FeatureMultiMemory, "multiple memories")
+	}
+
+	return nil
+}
+
 type ModuleKind interface {
 	moduleKind()
 }
@@ -89,6 +132,15 @@ type implModuleField struct{}
 func (self implModuleField) moduleField() {}
 
 func parseModuleField(ps *parser.ParserBuffer) (ModuleField, error) {
+	if matchAnnotation(ps.PeekToken(), "custom") {
+		var custom Custom
+		err := custom.Parse(ps)
+		if err != nil {
+			return nil, err
+		}
+		return custom, nil
+	}
+
 	kw, err := ps.PeekKeyword()
 	if err != nil {
 		return nil, err
@@ -119,6 +171,10 @@ func parseModuleField(ps *parser.ParserBuffer) (ModuleField, error) {
 		var global Global
 		err = global.Parse(ps)
 		field = global
+	case "tag":
+		var tag Tag
+		err = tag.Parse(ps)
+		field = tag
 	case "export":
 		var val Export
 		err = val.Parse(ps)
@@ -137,7 +193,7 @@ func parseModuleField(ps *parser.ParserBuffer) (ModuleField, error) {
 		err = val.Parse(ps)
 		field = val
 	default:
-		return nil, fmt.Errorf("invalid module field keyword: %s", kw)
+		return nil, ps.Unexpected("type", "import", "func", "table", "memory", "global", "tag", "export", "start", "elem", "data")
 	}
 
 	if err != nil {
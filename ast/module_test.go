@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -39,3 +40,158 @@ func TestParser(t *testing.T) {
 
 	fmt.Printf("tokens: %v", module.Module)
 }
+
+func TestParseErrorPosition(t *testing.T) {
+	ps, err := parser.NewParserBuffer(`(module
+  (memory 1)
+  (data (i32.const 0) 1))`)
+	assert.Nil(t, err)
+
+	var module Wat
+	err = module.Parse(ps)
+	var unexpected *parser.UnexpectedTokenError
+	assert.True(t, errors.As(err, &unexpected))
+	assert.Equal(t, 3, unexpected.Span.Line)
+	assert.Equal(t, 23, unexpected.Span.Column)
+	assert.Equal(t, "3:23: unexpected token 1, expected string", err.Error())
+}
+
+func TestParseErrorKinds(t *testing.T) {
+	parse := func(source string) error {
+		ps, err := parser.NewParserBuffer(source)
+		if err != nil {
+			return err
+		}
+		var module Wat
+		err = module.Parse(ps)
+		if err != nil {
+			return err
+		}
+		return module.Module.Resolve()
+	}
+
+	err := parse(`(module (func (drop (i32.const 0x1_0000_0000))))`)
+	var overflow *parser.ConstantOverflowError
+	assert.True(t, errors.As(err, &overflow))
+	assert.Equal(t, "i32 constant out of range: 0x1_0000_0000", overflow.Message())
+	assert.Equal(t, 32, overflow.Span.Column)
+
+	// the literal is quoted as written
+	err = parse(`(module (func (drop (i32.const -0x8000_0001))))`)
+	assert.True(t, errors.As(err, &overflow))
+	assert.Equal(t, "i32 constant out of range: -0x8000_0001", overflow.Message())
+
+	err = parse(`(module (func i32.frob))`)
+	var unknownInstr *parser.UnknownInstructionError
+	assert.True(t, errors.As(err, &unknownInstr))
+	assert.Equal(t, "i32.frob", unknownInstr.Name)
+	assert.Equal(t, "1:15: unknown operator i32.frob", err.Error())
+
+	err = parse("(module\n  (func $f)\n  (func $f))")
+	var duplicate *parser.DuplicateIdentifierError
+	assert.True(t, errors.As(err, &duplicate))
+	assert.Equal(t, "3:9: duplicate func $f", err.Error())
+
+	err = parse("(module\n  (func (call $g)))")
+	var unknown *parser.UnknownIdentifierError
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, "func", unknown.Kind)
+	assert.Equal(t, "2:15: unknown func $g", err.Error())
+
+	err = parse(`(module (func (br $l)))`)
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, "label", unknown.Kind)
+
+	// keywords of the type use out of order are unexpected tokens
+	var unexpected *parser.UnexpectedTokenError
+	err = parse(`(module (func (call_indirect (result i32) (param i32) (i32.const 0))))`)
+	assert.True(t, errors.As(err, &unexpected))
+	assert.Equal(t, "1:44: unexpected token param", err.Error())
+	err = parse(`(module (type (func)) (func (call_indirect (param i32) (type 0) (i32.const 0))))`)
+	assert.True(t, errors.As(err, &unexpected))
+	assert.Equal(t, "1:57: unexpected token type", err.Error())
+
+	err = parse("(module\n  (type (func))\n  (func (type 0) (result i32) (i32.const 0)))")
+	var inline *parser.InlineTypeError
+	assert.True(t, errors.As(err, &inline))
+	assert.Equal(t, "3:9: inline function type doesn't match type 0", err.Error())
+
+	err = parse(`(module (func (block $a (block $b end $a))))`)
+	var mismatch *parser.MismatchingLabelError
+	assert.True(t, errors.As(err, &mismatch))
+	assert.Equal(t, "1:39: mismatching label $a", err.Error())
+
+	err = parse(`(module (export "\ff" (func 0)))`)
+	var utf8 *parser.InvalidUTF8Error
+	assert.True(t, errors.As(err, &utf8))
+	assert.Equal(t, "1:17: malformed UTF-8 encoding", err.Error())
+}
+
+func TestParseFeatures(t *testing.T) {
+	parse := func(source string, features parser.Features) error {
+		ps, err := parser.NewParserBuffer(source)
+		if err != nil {
+			return err
+		}
+		ps.SetFeatures(features)
+		var module Wat
+		return module.Parse(ps)
+	}
+
+	mvp := Features(0)
+	for _, c := range []struct {
+		source  string
+		feature Features
+		err     string
+	}{
+		{`(module (func (return_call 0)))`, FeatureTailCall,
+			"1:16: instruction return_call requires the tail-call proposal"},
+		{`(module (memory 1 1 shared))`, FeatureThreads,
+			"1:21: shared memory requires the threads proposal"},
+		{`(module (func (param v128)))`, FeatureSimd,
+			"1:22: value type v128 requires the simd proposal"},
+		{`(module (global externref (ref.null extern)))`, FeatureReferenceTypes,
+			"1:17: value type externref requires the reference-types proposal"},
+		{`(module (func (drop (i32.atomic.load (i32.const 0)))) (memory 1 1 shared))`, FeatureThreads,
+			"1:22: instruction i32.atomic.load requires the threads proposal"},
+		{`(module (func (drop (i32.trunc_sat_f32_s (f32.const 0)))))`, FeatureSatTrunc,
+			"1:22: instruction i32.trunc_sat_f32_s requires the nontrapping-float-to-int-conversions proposal"},
+		{`(module (func (drop (i32.extend8_s (i32.const 0)))))`, FeatureSignExt,
+			"1:22: instruction i32.extend8_s requires the sign-extension-ops proposal"},
+		{`(module (memory i64 1))`, FeatureMemory64,
+			"1:17: 64-bit memory requires the memory64 proposal"},
+		{`(module (func (select (result i32) (i32.const 0) (i32.const 1) (i32.const 0)) drop))`, FeatureReferenceTypes,
+			"1:16: instruction select requires the reference-types proposal"},
+		{`(module (table 1 externref))`, FeatureReferenceTypes,
+			"1:18: table type externref requires the reference-types proposal"},
+		{`(module (func (result i32 i32) (i32.const 0) (i32.const 1)))`, FeatureMultiValue,
+			"1:27: multiple results requires the multi-value proposal"},
+		{`(module (func (block (result i32 i64) (i32.const 0) (i64.const 1)) (drop) (drop)))`, FeatureMultiValue,
+			"1:34: multiple results requires the multi-value proposal"},
+		{`(module (func (i32.const 0) (block (param i32) (drop))))`, FeatureMultiValue,
+			"1:36: block type with a type index or parameters requires the multi-value proposal"},
+		{`(module (memory 1) (data "x"))`, FeatureBulkMemory,
+			"1:26: passive data segment requires the bulk-memory proposal"},
+		{`(module (func $f) (elem func $f))`, FeatureBulkMemory,
+			"1:25: passive element segment requires the bulk-memory proposal"},
+		{`(module (func $f) (elem declare func $f))`, FeatureBulkMemory,
+			"1:25: declared element segment requires the bulk-memory proposal"},
+		{`(module (table 1 funcref) (elem (i32.const 0) funcref (ref.null func)))`, FeatureBulkMemory,
+			"1:55: element expressions requires the bulk-memory proposal"},
+		{`(module (table 1 funcref) (table 1 funcref))`, FeatureReferenceTypes,
+			"1:28: multiple tables requires the reference-types proposal"},
+		{`(module (import "env" "t" (table 1 funcref)) (table 1 funcref))`, FeatureReferenceTypes,
+			"1:47: multiple tables requires the reference-types proposal"},
+		{`(module (memory 1) (memory (import "env" "m") 1))`, FeatureMultiMemory,
+			"1:21: multiple memories requires the multi-memory proposal"},
+	} {
+		err := parse(c.source, mvp)
+		var ferr *parser.FeatureError
+		assert.True(t, errors.As(err, &ferr), c.source)
+		assert.EqualError(t, err, c.err)
+		assert.Nil(t, parse(c.source, c.feature), c.source)
+	}
+
+	assert.Nil(t, parse(`(module (memory 1) (func (result i32) (i32.load (i32.const 0))))`, mvp))
+	assert.Nil(t, parse(`(module (table 1 funcref) (func $f) (elem (i32.const 0) $f) (memory 1) (data (i32.const 0) "x"))`, mvp))
+}
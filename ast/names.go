@@ -0,0 +1,198 @@
+package ast
+
+// nameEntry binds an index to the identifier it had in the text format.
+type nameEntry struct {
+	index uint32
+	name  string
+}
+
+type nameMap []nameEntry
+
+func (self *nameMap) add(index uint32, id OptionId) {
+	if id.IsSome() {
+		*self = append(*self, nameEntry{index: index, name: id.ToId().Name})
+	}
+}
+
+func (self nameMap) Encode(sink *ZeroCopySink) {
+	sink.WriteUint32(uint32(len(self)))
+	for _, entry := range self {
+		sink.WriteUint32(entry.index)
+		sink.WriteString(entry.name)
+	}
+}
+
+// indirectNameMap holds a name map for each function, e.g. its locals.
+type indirectNameMap []indirectNameEntry
+
+type indirectNameEntry struct {
+	index uint32
+	names nameMap
+}
+
+func (self *indirectNameMap) add(index uint32, names nameMap) {
+	if len(names) != 0 {
+		*self = append(*self, indirectNameEntry{index: index, names: names})
+	}
+}
+
+func (self indirectNameMap) Encode(sink *ZeroCopySink) {
+	sink.WriteUint32(uint32(len(self)))
+	for _, entry := range self {
+		sink.WriteUint32(entry.index)
+		entry.names.Encode(sink)
+	}
+}
+
+// nameSection is the "name" custom section with the subsections of the
+// extended name proposal.
+type nameSection struct {
+	module   OptionId
+	funcs    nameMap
+	locals   indirectNameMap
+	labels   indirectNameMap
+	types    nameMap
+	tables   nameMap
+	memories nameMap
+	globals  nameMap
+	elems    nameMap
+	datas    nameMap
+	tags     nameMap
+}
+
+// collectNames gathers the identifiers of a resolved module, the fields are
+// in the order they are emitted so imports come before definitions.
+func collectNames(module OptionId, fields []ModuleField) *nameSection {
+	names := &nameSection{module: module}
+	var funcs, tables, memories, globals, tags uint32
+	var types, elems, datas uint32
+	for _, field := range fields {
+		switch val := field.(type) {
+		case Type:
+			names.types.add(types, val.Name)
+			types += 1
+		case Import:
+			switch val.Item.(type) {
+			case ImportFunc:
+				names.funcs.add(funcs, val.Id)
+				funcs += 1
+			case ImportTable:
+				names.tables.add(tables, val.Id)
+				tables += 1
+			case ImportMemory:
+				names.memories.add(memories, val.Id)
+				memories += 1
+			case ImportGlobal:
+				names.globals.add(globals, val.Id)
+				globals += 1
+			case ImportTag:
+				names.tags.add(tags, val.Id)
+				tags += 1
+			}
+		case Func:
+			names.funcs.add(funcs, val.Name)
+			if inline, ok := val.Kind.(FuncKindInline); ok {
+				names.addFuncLocals(funcs, val.Type, inline)
+			}
+			funcs += 1
+		case Table:
+			names.tables.add(tables, val.Name)
+			tables += 1
+		case Memory:
+			names.memories.add(memories, val.Name)
+			memories += 1
+		case Global:
+			names.globals.add(globals, val.Name)
+			globals += 1
+		case Tag:
+			names.tags.add(tags, val.Name)
+			tags += 1
+		case Elem:
+			names.elems.add(elems, val.Name)
+			elems += 1
+		case Data:
+			names.datas.add(datas, val.Name)
+			datas += 1
+		}
+	}
+
+	return names
+}
+
+// addFuncLocals records the names of the parameters and locals, and of the
+// labels numbered in the order their blocks start.
+func (self *nameSection) addFuncLocals(index uint32, ty TypeUse, inline FuncKindInline) {
+	var locals nameMap
+	var local uint32
+	for _, param := range ty.Type.Params {
+		locals.add(local, param.Id)
+		local += 1
+	}
+	for _, val := range inline.Locals {
+		locals.add(local, val.Id)
+		local += 1
+	}
+	self.locals.add(index, locals)
+
+	var labels nameMap
+	var label uint32
+	for _, instr := range inline.Expr.Instrs {
+		var block BlockType
+		switch inst := instr.(type) {
+		case *Block:
+			block = inst.BlockType
+		case *Loop:
+			block = inst.BlockType
+		case *If:
+			block = inst.BlockType
+		case *Try:
+			block = inst.BlockType
+		default:
+			continue
+		}
+		labels.add(label, block.Label)
+		label += 1
+	}
+	self.labels.add(index, labels)
+}
+
+func (self *nameSection) Encode(sink *ZeroCopySink) {
+	sink.WriteString("name")
+	if self.module.IsSome() {
+		tmpSink := sink.sub()
+		tmpSink.WriteString(self.module.ToId().Name)
+		sink.WriteByte(0x0)
+		sink.WriteVarBytes(tmpSink.Bytes())
+	}
+	for _, sub := range []struct {
+		id    byte
+		len   int
+		names Section
+	}{
+		{0x1, len(self.funcs), self.funcs},
+		{0x2, len(self.locals), self.locals},
+		{0x3, len(self.labels), self.labels},
+		{0x4, len(self.types), self.types},
+		{0x5, len(self.tables), self.tables},
+		{0x6, len(self.memories), self.memories},
+		{0x7, len(self.globals), self.globals},
+		{0x8, len(self.elems), self.elems},
+		{0x9, len(self.datas), self.datas},
+		{0xb, len(self.tags), self.tags},
+	} {
+		if sub.len == 0 {
+			continue
+		}
+		tmpSink := sink.sub()
+		sub.names.Encode(tmpSink)
+		sink.WriteByte(sub.id)
+		sink.WriteVarBytes(tmpSink.Bytes())
+	}
+}
+
+func (self *nameSection) empty() bool {
+	return !self.module.IsSome() && len(self.funcs) == 0 && len(self.locals) == 0 &&
+		len(self.labels) == 0 && len(self.types) == 0 && len(self.tables) == 0 &&
+		len(self.memories) == 0 && len(self.globals) == 0 && len(self.elems) == 0 &&
+		len(self.datas) == 0 && len(self.tags) == 0
+}
@@ -0,0 +1,1080 @@
+package ast
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type PrintStyle byte
+
+const (
+	// PrintFlat writes function bodies as one instruction per line with
+	// explicit `end` instructions.
+	PrintFlat PrintStyle = iota
+	// PrintFolded writes function bodies as nested s-expressions, the
+	// operands of an instruction are folded under it where its arity is
+	// known.
+	PrintFolded
+)
+
+// Print renders the module in the text format. Identifiers are kept where the
+// module has them, numeric indices are used otherwise.
+func (self *Module) Print(style PrintStyle) string {
+	p := &printer{style: style}
+	p.open("module")
+	self.Name.print(p)
+	switch kind := self.Kind.(type) {
+	case ModuleKindText:
+		p.ctx = newFoldContext(kind.Fields)
+		p.indent += 1
+		for _, field := range kind.Fields {
+			p.newline()
+			p.printField(field)
+		}
+		p.indent -= 1
+	case ModuleKindBinary:
+		p.word("binary")
+		for _, bin := range kind.Bins {
+			p.str(bin)
+		}
+	}
+	p.close()
+
+	return p.buf.String()
+}
+
+type printer struct {
+	buf       strings.Builder
+	style     PrintStyle
+	indent    int
+	inline    bool
+	needSpace bool
+
+	// the context of folded bodies: the signatures of the module, the
+	// labels of the enclosing blocks and the number of results of the
+	// function, -1 if unknown
+	ctx     *foldContext
+	labels  []foldLabel
+	results int
+}
+
+func (self *printer) word(s string) {
+	if self.needSpace {
+		self.buf.WriteByte(' ')
+	}
+	self.buf.WriteString(s)
+	self.needSpace = true
+}
+
+func (self *printer) open(kw string) {
+	if self.needSpace {
+		self.buf.WriteByte(' ')
+	}
+	self.buf.WriteString("(" + kw)
+	self.needSpace = true
+}
+
+func (self *printer) close() {
+	self.buf.WriteByte(')')
+	self.needSpace = true
+}
+
+func (self *printer) newline() {
+	if self.inline {
+		return
+	}
+	self.buf.WriteByte('\n')
+	self.buf.WriteString(strings.Repeat("  ", self.indent))
+	self.needSpace = false
+}
+
+func (self *printer) str(data []byte) {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, c := range data {
+		switch {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c >= 0x20 && c < 0x7f:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "\\%02x", c)
+		}
+	}
+	b.WriteByte('"')
+	self.word(b.String())
+}
+
+func (self *printer) printField(field ModuleField) {
+	switch val := field.(type) {
+	case Type:
+		self.open("type")
+		val.Name.print(self)
+		self.open("func")
+		val.Func.print(self)
+		self.close()
+		self.close()
+	case Import:
+		self.open("import")
+		self.str([]byte(val.Module))
+		self.str([]byte(val.Field))
+		self.open(val.Item.ImportType())
+		val.Id.print(self)
+		switch item := val.Item.(type) {
+		case ImportFunc:
+			item.TypeUse.print(self)
+		case ImportTable:
+			item.Table.print(self)
+		case ImportMemory:
+			item.Mem.print(self)
+		case ImportGlobal:
+			item.Global.print(self)
+		case ImportTag:
+			item.TypeUse.print(self)
+		}
+		self.close()
+		self.close()
+	case Func:
+		self.printFunc(val)
+	case Table:
+		self.open("table")
+		val.Name.print(self)
+		val.Exports.print(self)
+		switch kind := val.Kind.(type) {
+		case TableKindNormal:
+			kind.Type.print(self)
+		case TableKindImport:
+			printInlineImport(self, kind.Module, kind.Name)
+			kind.Type.print(self)
+		case TableKindInline:
+			self.word(kind.Elem.String())
+			self.open("elem")
+			printElemItems(self, kind.Payload)
+			self.close()
+		}
+		self.close()
+	case Memory:
+		self.open("memory")
+		val.Name.print(self)
+		val.Exports.print(self)
+		switch kind := val.Kind.(type) {
+		case *MemoryKindNormal:
+			kind.Type.print(self)
+		case *MemoryKindImport:
+			printInlineImport(self, kind.Module, kind.Name)
+			kind.Type.print(self)
+		case *MemoryKindInline:
+			if kind.Is64 {
+				self.word("i64")
+			}
+			self.open("data")
+			for _, data := range kind.Val {
+				self.str(data)
+			}
+			self.close()
+		}
+		self.close()
+	case Global:
+		self.open("global")
+		val.Name.print(self)
+		val.Exports.print(self)
+		switch kind := val.Kind.(type) {
+		case GlobalKindImport:
+			printInlineImport(self, kind.Module, kind.Field)
+			val.ValType.print(self)
+		case GlobalKindInline:
+			val.ValType.print(self)
+			self.printConstExpr(kind.Expr)
+		}
+		self.close()
+	case Tag:
+		self.open("tag")
+		val.Name.print(self)
+		val.Exports.print(self)
+		if imp, ok := val.Kind.(TagKindImport); ok {
+			printInlineImport(self, imp.Module, imp.Field)
+		}
+		val.Type.print(self)
+		self.close()
+	case Export:
+		self.open("export")
+		self.str([]byte(val.Name))
+		self.open(val.Type.String())
+		val.Index.print(self)
+		self.close()
+		self.close()
+	case StartField:
+		self.open("start")
+		val.Index.print(self)
+		self.close()
+	case Elem:
+		self.open("elem")
+		val.Name.print(self)
+		switch kind := val.Kind.(type) {
+		case ElemKindActive:
+			if val.forceNonZero || !kind.Table.Isnum || kind.Table.Num != 0 {
+				self.open("table")
+				kind.Table.print(self)
+				self.close()
+			}
+			self.open("offset")
+			self.printConstExpr(kind.Offset)
+			self.close()
+		case ElemKindDeclared:
+			self.word("declare")
+		}
+		if _, ok := val.Payload.(ElemPayloadIndices); ok {
+			if _, active := val.Kind.(ElemKindActive); !active {
+				self.word("func")
+			}
+		}
+		printElemItems(self, val.Payload)
+		self.close()
+	case Custom:
+		self.open("@custom")
+		self.str([]byte(val.Name))
+		self.open(val.Place.String())
+		self.close()
+		for _, data := range val.Data {
+			self.str(data)
+		}
+		self.close()
+	case Data:
+		self.open("data")
+		val.Name.print(self)
+		if active, ok := val.Kind.(DataKindActive); ok {
+			if !active.Memory.Isnum || active.Memory.Num != 0 {
+				self.open("memory")
+				active.Memory.print(self)
+				self.close()
+			}
+			self.open("offset")
+			self.printConstExpr(active.Offset)
+			self.close()
+		}
+		for _, data := range val.Val {
+			self.str(data)
+		}
+		self.close()
+	}
+}
+
+func printInlineImport(p *printer, module, name string) {
+	p.open("import")
+	p.str([]byte(module))
+	p.str([]byte(name))
+	p.close()
+}
+
+func printElemItems(p *printer, payload ElemPayload) {
+	switch val := payload.(type) {
+	case ElemPayloadIndices:
+		for _, index := range val.Indices {
+			index.print(p)
+		}
+	case ElemPayloadExprs:
+		p.word(val.Type.String())
+		for _, expr := range val.Exprs {
+			if expr.IsSome() {
+				p.open("ref.func")
+				expr.ToIndex().print(p)
+			} else {
+				p.open("ref.null")
+			}
+			p.close()
+		}
+	}
+}
+
+func (self *printer) printFunc(fun Func) {
+	self.open("func")
+	fun.Name.print(self)
+	fun.Exports.print(self)
+	switch kind := fun.Kind.(type) {
+	case FuncKindImport:
+		printInlineImport(self, kind.Module, kind.Name)
+		fun.Type.print(self)
+	case FuncKindInline:
+		fun.Type.print(self)
+		_, results, ok := self.ctx.signature(fun.Type)
+		self.results = results
+		if !ok {
+			self.results = -1
+		}
+		self.indent += 1
+		for _, local := range kind.Locals {
+			self.newline()
+			self.open("local")
+			local.Id.print(self)
+			local.ValType.print(self)
+			self.close()
+		}
+		self.printInstrs(kind.Expr.Instrs)
+		self.indent -= 1
+	}
+	self.close()
+}
+
+// printConstExpr always folds, constant expressions are written inline.
+func (self *printer) printConstExpr(expr Expression) {
+	style, inline := self.style, self.inline
+	self.style, self.inline = PrintFolded, true
+	self.printInstrs(expr.Instrs)
+	self.style, self.inline = style, inline
+}
+
+func (self *printer) printInstrs(instrs []Instruction) {
+	if self.style == PrintFolded {
+		self.printFolded(instrs)
+		return
+	}
+
+	for _, instr := range instrs {
+		switch instr.(type) {
+		case *End, *Else, *Catch, *CatchAll, *Delegate:
+			self.indent -= 1
+		}
+		self.newline()
+		self.printInstr(instr)
+		switch instr.(type) {
+		case *Block, *Loop, *If, *Else, *Try, *Catch, *CatchAll:
+			self.indent += 1
+		}
+	}
+}
+
+func (self *printer) printInstr(instr Instruction) {
+	self.word(instr.String())
+	instr.printInstrBody(self)
+}
+
+// foldNode is an instruction of a folded body along with the operands folded
+// under it. Its writing waits until it is known whether a later instruction
+// consumes its result.
+type foldNode struct {
+	print func()
+	// results is the number of values pushed, an instruction consumes the
+	// nodes of a single result only
+	results int
+	// leaf is set for an instruction without operands or nested blocks
+	leaf bool
+}
+
+// foldLabel is a label of the folded body, arity is the number of values a
+// branch to it takes, -1 if unknown.
+type foldLabel struct {
+	name  OptionId
+	arity int
+}
+
+// foldContext holds the signatures the arity of calls and blocks depends on.
+// Items are found by identifier, or by index for resolved modules.
+type foldContext struct {
+	types     []FunctionType
+	typeNames map[string]int
+	funcs     []TypeUse
+	funcNames map[string]int
+}
+
+func newFoldContext(fields []ModuleField) *foldContext {
+	ctx := &foldContext{typeNames: make(map[string]int), funcNames: make(map[string]int)}
+	addFunc := func(name OptionId, ty TypeUse) {
+		if name.IsSome() {
+			ctx.funcNames[name.ToId().Name] = len(ctx.funcs)
+		}
+		ctx.funcs = append(ctx.funcs, ty)
+	}
+	// imported functions come first, as numbered by Resolve
+	for _, field := range fields {
+		switch val := field.(type) {
+		case Type:
+			if val.Name.IsSome() {
+				ctx.typeNames[val.Name.ToId().Name] = len(ctx.types)
+			}
+			ctx.types = append(ctx.types, val.Func)
+		case Import:
+			if item, ok := val.Item.(ImportFunc); ok {
+				addFunc(val.Id, item.TypeUse)
+			}
+		case Func:
+			if _, ok := val.Kind.(FuncKindImport); ok {
+				addFunc(val.Name, val.Type)
+			}
+		}
+	}
+	for _, field := range fields {
+		if val, ok := field.(Func); ok {
+			if _, ok := val.Kind.(FuncKindImport); !ok {
+				addFunc(val.Name, val.Type)
+			}
+		}
+	}
+
+	return ctx
+}
+
+func lookup(index Index, names map[string]int, count int) (int, bool) {
+	if index.Id.Name != "" {
+		num, ok := names[index.Id.Name]
+		return num, ok
+	}
+
+	return int(index.Num), int(index.Num) < count
+}
+
+// signature returns the number of parameters and results of a type use, ok
+// is false if it refers to a type out of reach.
+func (self *foldContext) signature(ty TypeUse) (params, results int, ok bool) {
+	if len(ty.Type.Params) != 0 || len(ty.Type.Results) != 0 || !ty.Index.IsSome() {
+		return len(ty.Type.Params), len(ty.Type.Results), true
+	}
+	if self == nil {
+		return 0, 0, false
+	}
+	index, ok := lookup(ty.Index.ToIndex(), self.typeNames, len(self.types))
+	if !ok {
+		return 0, 0, false
+	}
+	fn := self.types[index]
+
+	return len(fn.Params), len(fn.Results), true
+}
+
+func (self *foldContext) funcSignature(index Index) (params, results int, ok bool) {
+	if self == nil {
+		return 0, 0, false
+	}
+	num, ok := lookup(index, self.funcNames, len(self.funcs))
+	if !ok {
+		return 0, 0, false
+	}
+
+	return self.signature(self.funcs[num])
+}
+
+// label returns the arity of a branch to index, -1 if unknown.
+func (self *printer) label(index Index) int {
+	if index.Id.Name != "" {
+		for i := len(self.labels) - 1; i >= 0; i-- {
+			label := self.labels[i]
+			if label.name.IsSome() && label.name.ToId().Name == index.Id.Name {
+				return label.arity
+			}
+		}
+		return -1
+	}
+	if int(index.Num) >= len(self.labels) {
+		return -1
+	}
+
+	return self.labels[len(self.labels)-1-int(index.Num)].arity
+}
+
+// arity returns the number of operands an instruction pops and of the values
+// it pushes, ok is false if they are unknown, e.g. for a call to a function
+// out of reach.
+func (self *printer) arity(instr Instruction) (pops, pushes int, ok bool) {
+	switch inst := instr.(type) {
+	case *Unreachable, *Nop, *Rethrow, *ElemDrop, *DataDrop:
+		return 0, 0, true
+	case *Br:
+		arity := self.label(inst.Index)
+		return arity, 0, arity >= 0
+	case *BrIf:
+		arity := self.label(inst.Index)
+		return arity + 1, arity, arity >= 0
+	case *BrTable:
+		arity := self.label(inst.Indices.Default)
+		return arity + 1, 0, arity >= 0
+	case *Return:
+		return self.results, 0, self.results >= 0
+	case *Call:
+		params, results, ok := self.ctx.funcSignature(inst.Index)
+		return params, results, ok
+	case *ReturnCall:
+		params, _, ok := self.ctx.funcSignature(inst.Index)
+		return params, 0, ok
+	case *CallIndirect:
+		params, results, ok := self.ctx.signature(inst.Impl.Type)
+		return params + 1, results, ok
+	case *ReturnCallIndirect:
+		params, _, ok := self.ctx.signature(inst.Impl.Type)
+		return params + 1, 0, ok
+	case *Drop, *LocalSet, *GlobalSet:
+		return 1, 0, true
+	case *Select:
+		return 3, 1, true
+	case *LocalGet, *GlobalGet, *TableSize, *MemorySize, *RefNull, *RefFunc, *RefHost:
+		return 0, 1, true
+	case *LocalTee, *TableGet, *MemoryGrow, *RefIsNull:
+		return 1, 1, true
+	case *TableSet:
+		return 2, 0, true
+	case *TableGrow:
+		return 2, 1, true
+	case *TableFill, *TableCopy, *TableInit, *MemoryFill, *MemoryCopy, *MemoryInit:
+		return 3, 0, true
+	}
+	sig, ok := operatorSigs[instr.String()]
+
+	return len(sig.params), len(sig.results), ok
+}
+
+// blockEnd returns the index of the `else`, `catch`, `catch_all`,
+// `delegate` or `end` closing the block whose body starts instrs.
+func blockEnd(instrs []Instruction) int {
+	depth := 0
+	for i, instr := range instrs {
+		switch instr.(type) {
+		case *Block, *Loop, *If, *Try:
+			depth += 1
+		case *Else, *Catch, *CatchAll:
+			if depth == 0 {
+				return i
+			}
+		case *End, *Delegate:
+			if depth == 0 {
+				return i
+			}
+			depth -= 1
+		}
+	}
+
+	return len(instrs)
+}
+
+// printFolded writes the flat instruction sequence as nested s-expressions,
+// the operands of an instruction are folded under it where its arity is
+// known and they are pushed by the instructions right before it. Otherwise
+// the instructions are written one after the other as in the flat form.
+func (self *printer) printFolded(instrs []Instruction) {
+	var pending []foldNode
+	flush := func() {
+		for _, node := range pending {
+			node.print()
+		}
+		pending = pending[:0]
+	}
+	// operands takes the last n pending nodes if all of them push a single
+	// value
+	operands := func(n int) ([]foldNode, bool) {
+		if n > len(pending) {
+			return nil, false
+		}
+		for _, node := range pending[len(pending)-n:] {
+			if node.results != 1 {
+				return nil, false
+			}
+		}
+		children := append([]foldNode(nil), pending[len(pending)-n:]...)
+		pending = pending[:len(pending)-n]
+		return children, true
+	}
+	push := func(node foldNode) {
+		if node.results == 1 {
+			pending = append(pending, node)
+			return
+		}
+		flush()
+		node.print()
+	}
+
+	for i := 0; i < len(instrs); i++ {
+		switch inst := instrs[i].(type) {
+		case *Block, *Loop:
+			ty := blockTypeOf(inst)
+			params, results, ok := self.ctx.signature(ty.Ty)
+			if params != 0 || !ok {
+				flush()
+			}
+			if !ok {
+				results = -1
+			}
+			end := i + 1 + blockEnd(instrs[i+1:])
+			arity := results
+			if _, ok := inst.(*Loop); ok {
+				arity = params
+			}
+			body := instrs[i+1 : end]
+			push(foldNode{results: results, print: func() {
+				self.newline()
+				self.open(inst.String())
+				inst.printInstrBody(self)
+				self.printFoldedBody(ty.Label, arity, body)
+				self.close()
+			}})
+			i = end
+		case *If:
+			params, results, ok := self.ctx.signature(inst.BlockType.Ty)
+			cond, folded := operands(1)
+			if params != 0 || !ok || !folded {
+				pending = append(pending, cond...)
+				cond = nil
+				flush()
+			}
+			if !ok {
+				results = -1
+			}
+			end := i + 1 + blockEnd(instrs[i+1:])
+			then := instrs[i+1 : end]
+			var els []Instruction
+			if end < len(instrs) {
+				if _, ok := instrs[end].(*Else); ok {
+					start := end + 1
+					end = start + blockEnd(instrs[start:])
+					els = instrs[start:end]
+				}
+			}
+			push(foldNode{results: results, print: func() { self.printFoldedIf(inst, cond, results, then, els) }})
+			i = end
+		case *Try:
+			params, results, ok := self.ctx.signature(inst.BlockType.Ty)
+			if params != 0 || !ok {
+				flush()
+			}
+			if !ok {
+				results = -1
+			}
+			end := i + tryEnd(instrs[i:])
+			last := end + 1
+			if last > len(instrs) {
+				last = len(instrs)
+			}
+			block := instrs[i:last]
+			push(foldNode{results: results, print: func() { self.printFoldedTry(inst, block, results) }})
+			i = end
+		default:
+			pops, pushes, ok := self.arity(inst)
+			children, folded := operands(pops)
+			if !ok || !folded {
+				pending = append(pending, children...)
+				children = nil
+				flush()
+			}
+			if !ok {
+				pushes = -1
+			}
+			push(foldNode{results: pushes, leaf: len(children) == 0, print: func() { self.printNode(inst, children) }})
+		}
+	}
+	flush()
+}
+
+func blockTypeOf(instr Instruction) BlockType {
+	switch inst := instr.(type) {
+	case *Block:
+		return inst.BlockType
+	case *Loop:
+		return inst.BlockType
+	}
+
+	return BlockType{}
+}
+
+// printNode writes an instruction with its folded operands.
+func (self *printer) printNode(instr Instruction, children []foldNode) {
+	self.newline()
+	self.open(instr.String())
+	instr.printInstrBody(self)
+	self.printOperands(children)
+	self.close()
+}
+
+// printOperands writes folded operands, on the same line if none of them has
+// operands itself.
+func (self *printer) printOperands(children []foldNode) {
+	inline := self.inline
+	self.inline = true
+	for _, child := range children {
+		if !child.leaf {
+			self.inline = inline
+		}
+	}
+	self.indent += 1
+	for _, child := range children {
+		child.print()
+	}
+	self.indent -= 1
+	self.inline = inline
+}
+
+// printFoldedBody writes the body of a block, the label of which takes arity
+// values.
+func (self *printer) printFoldedBody(label OptionId, arity int, instrs []Instruction) {
+	self.labels = append(self.labels, foldLabel{name: label, arity: arity})
+	self.indent += 1
+	self.printFolded(instrs)
+	self.indent -= 1
+	self.labels = self.labels[:len(self.labels)-1]
+}
+
+// printFoldedIf writes an if with its folded condition and branches.
+func (self *printer) printFoldedIf(inst *If, cond []foldNode, results int, then, els []Instruction) {
+	self.newline()
+	self.open("if")
+	inst.printInstrBody(self)
+	self.printOperands(cond)
+	self.indent += 1
+	self.newline()
+	self.open("then")
+	self.printFoldedBody(inst.BlockType.Label, results, then)
+	self.close()
+	if els != nil {
+		self.newline()
+		self.open("else")
+		self.printFoldedBody(inst.BlockType.Label, results, els)
+		self.close()
+	}
+	self.indent -= 1
+	self.close()
+}
+
+// tryEnd returns the index of the `end` or `delegate` closing the try block
+// instrs starts with.
+func tryEnd(instrs []Instruction) int {
+	i := 1 + blockEnd(instrs[1:])
+	for i < len(instrs) {
+		switch instrs[i].(type) {
+		case *Catch, *CatchAll:
+			i += 1 + blockEnd(instrs[i+1:])
+			continue
+		}
+		break
+	}
+
+	return i
+}
+
+// printFoldedTry writes a try block with its handlers, instrs runs from the
+// `try` to the closing `end` or `delegate`.
+func (self *printer) printFoldedTry(try *Try, instrs []Instruction, results int) {
+	self.newline()
+	self.open("try")
+	try.printInstrBody(self)
+	self.indent += 1
+	self.newline()
+	self.open("do")
+	i := 1 + blockEnd(instrs[1:])
+	self.printFoldedBody(try.BlockType.Label, results, instrs[1:i])
+	self.close()
+	for i < len(instrs) {
+		switch inst := instrs[i].(type) {
+		case *Catch, *CatchAll:
+			self.newline()
+			self.open(inst.String())
+			inst.printInstrBody(self)
+			end := i + 1 + blockEnd(instrs[i+1:])
+			self.printFoldedBody(try.BlockType.Label, results, instrs[i+1:end])
+			self.close()
+			i = end
+			continue
+		case *Delegate:
+			self.newline()
+			self.open(inst.String())
+			inst.printInstrBody(self)
+			self.close()
+		}
+		break
+	}
+	self.indent -= 1
+	self.close()
+}
+
+func (self OptionId) print(p *printer) {
+	if self.IsSome() {
+		p.word("$" + self.ToId().Name)
+	}
+}
+
+func (self Index) print(p *printer) {
+	if self.Id.Name != "" {
+		p.word("$" + self.Id.Name)
+		return
+	}
+	p.word(strconv.FormatUint(uint64(self.Num), 10))
+}
+
+func (self InlineExport) print(p *printer) {
+	for _, name := range self.Names {
+		p.open("export")
+		p.str([]byte(name))
+		p.close()
+	}
+}
+
+func (self ValType) String() string {
+	switch self {
+	case I32:
+		return "i32"
+	case I64:
+		return "i64"
+	case F32:
+		return "f32"
+	case F64:
+		return "f64"
+	case Anyref:
+		return "externref"
+	case Funcref:
+		return "funcref"
+	case V128:
+		return "v128"
+	case nullType:
+		return "nullref"
+	case unknownType:
+		return "unknown"
+	}
+
+	return fmt.Sprintf("valtype(%d)", self.ty)
+}
+
+func (self ValType) print(p *printer) {
+	p.word(self.String())
+}
+
+func (self TableElemType) String() string {
+	switch self {
+	case FuncRef:
+		return "funcref"
+	case AnyRef:
+		return "externref"
+	case NullRef:
+		return "nullref"
+	}
+
+	return fmt.Sprintf("elemtype(%d)", self.ty)
+}
+
+func (self HeapType) String() string {
+	if self == HeapExtern {
+		return "extern"
+	}
+
+	return "func"
+}
+
+func (self HeapType) print(p *printer) {
+	p.word(self.String())
+}
+
+func (self ExportType) String() string {
+	switch self {
+	case ExportFunc:
+		return "func"
+	case ExportTable:
+		return "table"
+	case ExportMemory:
+		return "memory"
+	case ExportGlobal:
+		return "global"
+	case ExportTag:
+		return "tag"
+	}
+
+	return fmt.Sprintf("export(%d)", byte(self))
+}
+
+func (self GlobalValType) print(p *printer) {
+	if self.Mutable {
+		p.open("mut")
+		self.Type.print(p)
+		p.close()
+		return
+	}
+	self.Type.print(p)
+}
+
+func (self Limits) print(p *printer) {
+	p.word(strconv.FormatUint(self.Min, 10))
+	if self.HasMax {
+		p.word(strconv.FormatUint(self.Max, 10))
+	}
+}
+
+func (self TableType) print(p *printer) {
+	self.Limits.print(p)
+	p.word(self.Elem.String())
+}
+
+func (self MemoryType) print(p *printer) {
+	if self.Is64 {
+		p.word("i64")
+	}
+	self.Limits.print(p)
+	if self.Shared {
+		p.word("shared")
+	}
+}
+
+func (self FunctionType) print(p *printer) {
+	var unnamed []ValType
+	flush := func() {
+		if len(unnamed) != 0 {
+			p.open("param")
+			for _, ty := range unnamed {
+				ty.print(p)
+			}
+			p.close()
+			unnamed = nil
+		}
+	}
+	for _, param := range self.Params {
+		if !param.Id.IsSome() {
+			unnamed = append(unnamed, param.Val)
+			continue
+		}
+		flush()
+		p.open("param")
+		param.Id.print(p)
+		param.Val.print(p)
+		p.close()
+	}
+	flush()
+
+	if len(self.Results) != 0 {
+		p.open("result")
+		for _, ty := range self.Results {
+			ty.print(p)
+		}
+		p.close()
+	}
+}
+
+func (self TypeUse) print(p *printer) {
+	if self.Index.IsSome() {
+		p.open("type")
+		self.Index.ToIndex().print(p)
+		p.close()
+	}
+	self.Type.print(p)
+}
+
+func (self BlockType) print(p *printer) {
+	self.Label.print(p)
+	self.Ty.print(p)
+}
+
+func (self MemArg) print(p *printer, defaultAlign uint32) {
+	printDefaultIndex(p, self.Memory)
+	if self.Offset != 0 {
+		p.word("offset=" + strconv.FormatUint(self.Offset, 10))
+	}
+	if self.Align != defaultAlign {
+		p.word("align=" + strconv.FormatUint(uint64(self.Align), 10))
+	}
+}
+
+// printDefaultIndex omits an index which defaults to 0 in the text format.
+func printDefaultIndex(p *printer, index Index) {
+	if index.Isnum && index.Num == 0 {
+		return
+	}
+	index.print(p)
+}
+
+func (self BrTableIndices) print(p *printer) {
+	for _, label := range self.Labels {
+		label.print(p)
+	}
+	self.Default.print(p)
+}
+
+func (self CallIndirectInner) print(p *printer) {
+	if !self.Table.Isnum || self.Table.Num != 0 {
+		self.Table.print(p)
+	}
+	self.Type.print(p)
+}
+
+func (self TableInitInner) print(p *printer) {
+	if !self.Table.Isnum || self.Table.Num != 0 {
+		self.Table.print(p)
+	}
+	self.Elem.print(p)
+}
+
+func (self TableCopyInner) print(p *printer) {
+	if self.Dst.Isnum && self.Dst.Num == 0 && self.Src.Isnum && self.Src.Num == 0 {
+		return
+	}
+	self.Dst.print(p)
+	self.Src.print(p)
+}
+
+func (self MemoryInitInner) print(p *printer) {
+	printDefaultIndex(p, self.Memory)
+	self.Data.print(p)
+}
+
+func (self MemoryCopyInner) print(p *printer) {
+	if self.Dst.Isnum && self.Dst.Num == 0 && self.Src.Isnum && self.Src.Num == 0 {
+		return
+	}
+	self.Dst.print(p)
+	self.Src.print(p)
+}
+
+func (self SelectTypes) print(p *printer) {
+	if len(self.Types) != 0 {
+		p.open("result")
+		for _, ty := range self.Types {
+			ty.print(p)
+		}
+		p.close()
+	}
+}
+
+func (self Float32) print(p *printer) {
+	bits := self.Bits
+	sign := ""
+	if bits>>31 != 0 {
+		sign = "-"
+	}
+	if bits>>23&0xff == 0xff {
+		p.word(sign + formatSpecialFloat(uint64(bits&0x7fffff), 0x400000))
+		return
+	}
+	p.word(strconv.FormatFloat(float64(math.Float32frombits(bits)), 'g', -1, 32))
+}
+
+func (self Float64) print(p *printer) {
+	bits := self.Bits
+	sign := ""
+	if bits>>63 != 0 {
+		sign = "-"
+	}
+	if bits>>52&0x7ff == 0x7ff {
+		p.word(sign + formatSpecialFloat(bits&0xfffffffffffff, 0x8000000000000))
+		return
+	}
+	p.word(strconv.FormatFloat(math.Float64frombits(bits), 'g', -1, 64))
+}
+
+// print writes the constant as four i32 lanes, which keeps every bit
+// whatever shape it was written in.
+func (self V128Bits) print(p *printer) {
+	p.word("i32x4")
+	for i := 0; i < 16; i += 4 {
+		lane := uint32(self.Bytes[i]) | uint32(self.Bytes[i+1])<<8 | uint32(self.Bytes[i+2])<<16 | uint32(self.Bytes[i+3])<<24
+		p.word(fmt.Sprintf("0x%08x", lane))
+	}
+}
+
+func (self ShuffleLanes) print(p *printer) {
+	for _, lane := range self.Lanes {
+		p.word(strconv.Itoa(int(lane)))
+	}
+}
+
+func formatSpecialFloat(signif uint64, canonical uint64) string {
+	switch signif {
+	case 0:
+		return "inf"
+	case canonical:
+		return "nan"
+	default:
+		return fmt.Sprintf("nan:0x%x", signif)
+	}
+}
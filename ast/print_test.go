@@ -0,0 +1,135 @@
+package ast
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const printSource = `
+(module $m
+  (type $t (func (param i32 i64) (result i32)))
+  (import "env" "f" (func $imp (param f32)))
+  (import "env" "mem" (memory 1 2))
+  (global $g (mut i32) (i32.const -7))
+  (global f64 (f64.const -inf))
+  (table $tab 2 funcref)
+  (func $f (export "f") (type $t) (param $a i32) (param i64) (result i32)
+    (local $x f32) (local f64)
+    (block $b (result i32)
+      (loop $l
+        local.get $a
+        br_if $l
+        (if (result i32) (i32.const 1)
+          (then (i32.load offset=4 align=2 (i32.const 8)))
+          (else (i64.store8 offset=1 (i32.const 0) (i64.const 3)) (i32.const 2))))
+      br_table $b $b 0)
+    f32.const nan:0x1
+    drop
+    (call_indirect (type $t) (i32.const 0) (i64.const 0) (i32.const 1)))
+  (func (param $p i32)
+    (select (result i32) (local.get 0) (local.get 0) (local.get 0))
+    drop)
+  (elem (i32.const 0) $f 1)
+  (elem func $imp)
+  (elem declare func $f)
+  (elem funcref (ref.func $f) (ref.null))
+  (data (i32.const 16) "a\"b\\c\00\ff")
+  (start $imp)
+)
+`
+
+func TestPrintRoundTrip(t *testing.T) {
+	module := parseWat(t, printSource)
+	flat := module.Print(PrintFlat)
+
+	for _, style := range []PrintStyle{PrintFlat, PrintFolded} {
+		text := module.Print(style)
+		printed := parseWat(t, text)
+		assert.Equal(t, text, printed.Print(style))
+		assert.Equal(t, flat, printed.Print(PrintFlat))
+	}
+
+	resolved := parseWat(t, printSource)
+	assert.Nil(t, resolved.Resolve())
+	text := resolved.Print(PrintFolded)
+	printed := parseWat(t, text)
+	assert.Nil(t, printed.Resolve(), text)
+	assert.Equal(t, text, printed.Print(PrintFolded))
+}
+
+func TestPrintImmediates(t *testing.T) {
+	module := parseWat(t, printSource)
+
+	flat := module.Print(PrintFlat)
+	for _, expected := range []string{
+		"(module $m",
+		"(param $a i32) (param i64)",
+		"block $b (result i32)",
+		"br_if $l",
+		"i32.load offset=4 align=2",
+		"i64.store8 offset=1\n",
+		"br_table $b $b 0",
+		"f32.const nan:0x1",
+		"call_indirect (type $t)",
+		"(global $g (mut i32) (i32.const -7))",
+		`"a\"b\\c\00\ff"`,
+	} {
+		assert.True(t, strings.Contains(flat, expected), expected)
+	}
+
+	folded := module.Print(PrintFolded)
+	for _, expected := range []string{
+		"(block $b (result i32)",
+		"(if (result i32)",
+		"(then",
+		"(else",
+		"(local.get $a)",
+	} {
+		assert.True(t, strings.Contains(folded, expected), expected)
+	}
+	assert.False(t, strings.Contains(folded, "end"))
+}
+
+func TestPrintFoldOperands(t *testing.T) {
+	module := parseWat(t, `
+(module
+  (type $t (func (param i32) (result i32)))
+  (func $f (type $t)
+    (block $b (result i32)
+      (loop $lp
+        local.get 0
+        br_if $lp
+        i32.const 0
+        i32.const 1
+        br_table $b $b $b))
+    local.get 0
+    i32.add
+    call $f
+    (if (then nop))
+    call $g
+    i32.const 2)
+  (func $g (import "env" "g") (param i32)))
+`)
+	assert.Equal(t, `(module
+  (type $t (func (param i32) (result i32)))
+  (func $f (type $t)
+    (if
+      (call $f
+        (i32.add
+          (block $b (result i32)
+            (loop $lp
+              (br_if $lp (local.get 0))
+              (br_table $b $b $b (i32.const 0) (i32.const 1))))
+          (local.get 0)))
+      (then
+        (nop)))
+    (call $g)
+    (i32.const 2))
+  (func $g (import "env" "g") (param i32)))`, module.Print(PrintFolded))
+
+	// the operands unfold to the same sequence
+	printed := parseWat(t, module.Print(PrintFolded))
+	assert.Equal(t, module.Print(PrintFlat), printed.Print(PrintFlat))
+}
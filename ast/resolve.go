@@ -0,0 +1,583 @@
+package ast
+
+import (
+	"github.com/ontio/wast-parser/parser"
+)
+
+// Resolve expands the inline forms of a text module and rewrites every
+// symbolic `$id` index into its numeric form, so the module can be emitted by
+// `Encode`. The module is changed in place, resolving it again has no effect.
+func (self *Module) Resolve() error {
+	if self.resolved {
+		return nil
+	}
+	self.Expand()
+	text, ok := self.Kind.(ModuleKindText)
+	if !ok {
+		return nil
+	}
+
+	res := newResolver()
+	for _, field := range text.Fields {
+		err := res.register(field)
+		if err != nil {
+			return err
+		}
+	}
+
+	fields := make([]ModuleField, 0, len(text.Fields))
+	for _, field := range text.Fields {
+		field, err := res.resolveField(field)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, field)
+	}
+	fields = append(fields, res.implicitTypes...)
+
+	self.Kind = ModuleKindText{Fields: fields}
+	self.resolved = true
+	return nil
+}
+
+type namespace struct {
+	kind  string
+	names map[string]uint32
+	count uint32
+}
+
+func newNamespace(kind string) namespace {
+	return namespace{kind: kind, names: make(map[string]uint32)}
+}
+
+// register allocates the next index of the namespace and binds it to name if
+// the name is present.
+func (self *namespace) register(name OptionId) (uint32, error) {
+	index := self.count
+	if name.IsSome() {
+		id := name.ToId()
+		if _, exist := self.names[id.Name]; exist {
+			return 0, &parser.DuplicateIdentifierError{Span: id.Span, Kind: self.kind, Name: id.Name}
+		}
+		self.names[id.Name] = index
+	}
+	self.count += 1
+
+	return index, nil
+}
+
+func (self *namespace) resolve(index *Index) error {
+	if index.Isnum {
+		return nil
+	}
+	num, ok := self.names[index.Id.Name]
+	if !ok {
+		return &parser.UnknownIdentifierError{Span: index.Id.Span, Kind: self.kind, Name: index.Id.Name}
+	}
+	index.Isnum = true
+	index.Num = num
+
+	return nil
+}
+
+func (self *namespace) resolveOption(index *OptionIndex) error {
+	if !index.IsSome() {
+		return nil
+	}
+
+	return self.resolve(&index.index)
+}
+
+type resolver struct {
+	types    namespace
+	funcs    namespace
+	tables   namespace
+	memories namespace
+	globals  namespace
+	elems    namespace
+	datas    namespace
+	tags     namespace
+
+	typeDefs      []FunctionType
+	implicitTypes []ModuleField
+}
+
+func newResolver() *resolver {
+	return &resolver{
+		types:    newNamespace("type"),
+		funcs:    newNamespace("func"),
+		tables:   newNamespace("table"),
+		memories: newNamespace("memory"),
+		globals:  newNamespace("global"),
+		elems:    newNamespace("elem"),
+		datas:    newNamespace("data"),
+		tags:     newNamespace("tag"),
+	}
+}
+
+func (self *resolver) register(field ModuleField) error {
+	var err error
+	switch val := field.(type) {
+	case Type:
+		_, err = self.types.register(val.Name)
+		self.typeDefs = append(self.typeDefs, val.Func)
+	case Import:
+		switch val.Item.(type) {
+		case ImportFunc:
+			_, err = self.funcs.register(val.Id)
+		case ImportTable:
+			_, err = self.tables.register(val.Id)
+		case ImportMemory:
+			_, err = self.memories.register(val.Id)
+		case ImportGlobal:
+			_, err = self.globals.register(val.Id)
+		case ImportTag:
+			_, err = self.tags.register(val.Id)
+		}
+	case Func:
+		_, err = self.funcs.register(val.Name)
+	case Table:
+		_, err = self.tables.register(val.Name)
+	case Memory:
+		_, err = self.memories.register(val.Name)
+	case Global:
+		_, err = self.globals.register(val.Name)
+	case Elem:
+		_, err = self.elems.register(val.Name)
+	case Data:
+		_, err = self.datas.register(val.Name)
+	case Tag:
+		_, err = self.tags.register(val.Name)
+	}
+
+	return err
+}
+
+func (self *resolver) resolveField(field ModuleField) (ModuleField, error) {
+	switch val := field.(type) {
+	case Import:
+		switch item := val.Item.(type) {
+		case ImportFunc:
+			err := self.resolveTypeUse(&item.TypeUse)
+			if err != nil {
+				return nil, err
+			}
+			val.Item = item
+		case ImportTag:
+			err := self.resolveTypeUse(&item.TypeUse)
+			if err != nil {
+				return nil, err
+			}
+			val.Item = item
+		}
+		return val, nil
+	case Tag:
+		err := self.resolveTypeUse(&val.Type)
+		if err != nil {
+			return nil, err
+		}
+		return val, nil
+	case Func:
+		err := self.resolveTypeUse(&val.Type)
+		if err != nil {
+			return nil, err
+		}
+		if inline, ok := val.Kind.(FuncKindInline); ok {
+			expr := newExprResolver(self)
+			err = expr.registerLocals(val.Type, inline.Locals)
+			if err != nil {
+				return nil, err
+			}
+			err = expr.resolve(&inline.Expr)
+			if err != nil {
+				return nil, err
+			}
+			val.Kind = inline
+		}
+		return val, nil
+	case Global:
+		if inline, ok := val.Kind.(GlobalKindInline); ok {
+			err := self.resolveConstExpr(&inline.Expr)
+			if err != nil {
+				return nil, err
+			}
+			val.Kind = inline
+		}
+		return val, nil
+	case Export:
+		var err error
+		switch val.Type {
+		case ExportFunc:
+			err = self.funcs.resolve(&val.Index)
+		case ExportTable:
+			err = self.tables.resolve(&val.Index)
+		case ExportMemory:
+			err = self.memories.resolve(&val.Index)
+		case ExportGlobal:
+			err = self.globals.resolve(&val.Index)
+		case ExportTag:
+			err = self.tags.resolve(&val.Index)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return val, nil
+	case StartField:
+		err := self.funcs.resolve(&val.Index)
+		if err != nil {
+			return nil, err
+		}
+		return val, nil
+	case Elem:
+		if active, ok := val.Kind.(ElemKindActive); ok {
+			err := self.tables.resolve(&active.Table)
+			if err != nil {
+				return nil, err
+			}
+			err = self.resolveConstExpr(&active.Offset)
+			if err != nil {
+				return nil, err
+			}
+			val.Kind = active
+		}
+		payload, err := self.resolveElemPayload(val.Payload)
+		if err != nil {
+			return nil, err
+		}
+		val.Payload = payload
+		return val, nil
+	case Data:
+		if active, ok := val.Kind.(DataKindActive); ok {
+			err := self.memories.resolve(&active.Memory)
+			if err != nil {
+				return nil, err
+			}
+			err = self.resolveConstExpr(&active.Offset)
+			if err != nil {
+				return nil, err
+			}
+			val.Kind = active
+		}
+		return val, nil
+	default:
+		return field, nil
+	}
+}
+
+// resolveTypeUse makes sure a type use refers to an entry of the type
+// section. An explicit index must agree with the inline signature if both are
+// present, otherwise the first entry with the inline signature is used, and
+// appended to the type section if there is none. On return the inline
+// signature is filled in from the referenced type. An index past the type
+// section is kept as is, the module is then invalid rather than malformed and
+// `Validate` reports it.
+func (self *resolver) resolveTypeUse(ty *TypeUse) error {
+	if !ty.Index.IsSome() {
+		ty.Index = NewOptionIndex(NewNumIndex(self.typeIndex(ty.Type)))
+		return nil
+	}
+
+	err := self.types.resolveOption(&ty.Index)
+	if err != nil {
+		return err
+	}
+	index := ty.Index.ToIndex().Num
+	if int(index) >= len(self.typeDefs) {
+		return nil
+	}
+	def := self.typeDefs[index]
+	if len(ty.Type.Params) == 0 && len(ty.Type.Results) == 0 {
+		for _, param := range def.Params {
+			ty.Type.Params = append(ty.Type.Params, FuncParam{Val: param.Val})
+		}
+		ty.Type.Results = def.Results
+		return nil
+	}
+	if !sameSignature(ty.Type, def) {
+		return &parser.InlineTypeError{Span: ty.Span, Index: index}
+	}
+
+	return nil
+}
+
+// resolveBlockType only allocates a type entry for block types which can not
+// be encoded inline, that is with parameters or more than one result.
+func (self *resolver) resolveBlockType(ty *TypeUse) error {
+	if !ty.Index.IsSome() && len(ty.Type.Params) == 0 && len(ty.Type.Results) <= 1 {
+		return nil
+	}
+
+	return self.resolveTypeUse(ty)
+}
+
+func (self *resolver) typeIndex(fn FunctionType) uint32 {
+	for i, def := range self.typeDefs {
+		if sameSignature(def, fn) {
+			return uint32(i)
+		}
+	}
+
+	def := FunctionType{Results: fn.Results}
+	for _, param := range fn.Params {
+		def.Params = append(def.Params, FuncParam{Val: param.Val})
+	}
+	index, _ := self.types.register(NoneOptionId())
+	self.typeDefs = append(self.typeDefs, def)
+	self.implicitTypes = append(self.implicitTypes, Type{Name: NoneOptionId(), Func: def})
+
+	return index
+}
+
+func sameSignature(a, b FunctionType) bool {
+	if len(a.Params) != len(b.Params) || len(a.Results) != len(b.Results) {
+		return false
+	}
+	for i := range a.Params {
+		if a.Params[i].Val != b.Params[i].Val {
+			return false
+		}
+	}
+	for i := range a.Results {
+		if a.Results[i] != b.Results[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (self *resolver) resolveConstExpr(expr *Expression) error {
+	return newExprResolver(self).resolve(expr)
+}
+
+func (self *resolver) resolveElemPayload(payload ElemPayload) (ElemPayload, error) {
+	switch val := payload.(type) {
+	case ElemPayloadIndices:
+		indices := make([]Index, len(val.Indices))
+		for i, index := range val.Indices {
+			err := self.funcs.resolve(&index)
+			if err != nil {
+				return nil, err
+			}
+			indices[i] = index
+		}
+		val.Indices = indices
+		return val, nil
+	case ElemPayloadExprs:
+		exprs := make([]OptionIndex, len(val.Exprs))
+		for i, index := range val.Exprs {
+			err := self.funcs.resolveOption(&index)
+			if err != nil {
+				return nil, err
+			}
+			exprs[i] = index
+		}
+		val.Exprs = exprs
+		return val, nil
+	default:
+		return payload, nil
+	}
+}
+
+type exprResolver struct {
+	module *resolver
+	locals namespace
+	labels []OptionId
+}
+
+func newExprResolver(module *resolver) *exprResolver {
+	return &exprResolver{
+		module: module,
+		locals: newNamespace("local"),
+	}
+}
+
+func (self *exprResolver) registerLocals(ty TypeUse, locals []Local) error {
+	for _, param := range ty.Type.Params {
+		_, err := self.locals.register(param.Id)
+		if err != nil {
+			return err
+		}
+	}
+	for _, local := range locals {
+		_, err := self.locals.register(local.Id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (self *exprResolver) resolveLabel(index *Index) error {
+	if index.Isnum {
+		return nil
+	}
+	for i := len(self.labels) - 1; i >= 0; i-- {
+		label := self.labels[i]
+		if label.IsSome() && label.ToId().Name == index.Id.Name {
+			index.Isnum = true
+			index.Num = uint32(len(self.labels) - 1 - i)
+			return nil
+		}
+	}
+
+	return &parser.UnknownIdentifierError{Span: index.Id.Span, Kind: "label", Name: index.Id.Name}
+}
+
+func (self *exprResolver) checkLabelEnd(id OptionId) error {
+	if !id.IsSome() || len(self.labels) == 0 {
+		return nil
+	}
+	label := self.labels[len(self.labels)-1]
+	if !label.IsSome() || label.ToId().Name != id.ToId().Name {
+		return &parser.MismatchingLabelError{Span: id.ToId().Span, Name: id.ToId().Name}
+	}
+
+	return nil
+}
+
+func (self *exprResolver) resolveBlockType(ty *BlockType) error {
+	self.labels = append(self.labels, ty.Label)
+	return self.module.resolveBlockType(&ty.Ty)
+}
+
+func (self *exprResolver) resolve(expr *Expression) error {
+	for _, instr := range expr.Instrs {
+		err := self.resolveInstr(instr)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (self *exprResolver) resolveInstr(instr Instruction) error {
+	module := self.module
+	if mem, ok := instr.(memoryInstr); ok {
+		arg, _ := mem.memArg()
+		return module.memories.resolve(&arg.Memory)
+	}
+	switch inst := instr.(type) {
+	case *Block:
+		return self.resolveBlockType(&inst.BlockType)
+	case *Loop:
+		return self.resolveBlockType(&inst.BlockType)
+	case *If:
+		return self.resolveBlockType(&inst.BlockType)
+	case *Try:
+		return self.resolveBlockType(&inst.BlockType)
+	case *Else:
+		return self.checkLabelEnd(inst.Id)
+	case *Catch:
+		return module.tags.resolve(&inst.Index)
+	case *Delegate:
+		// delegate closes the try block, its label is relative to the
+		// enclosing blocks
+		if len(self.labels) > 0 {
+			self.labels = self.labels[:len(self.labels)-1]
+		}
+		return self.resolveLabel(&inst.Index)
+	case *Throw:
+		return module.tags.resolve(&inst.Index)
+	case *Rethrow:
+		return self.resolveLabel(&inst.Index)
+	case *End:
+		err := self.checkLabelEnd(inst.Id)
+		if err != nil {
+			return err
+		}
+		// the final `end` of a function body has no matching label
+		if len(self.labels) > 0 {
+			self.labels = self.labels[:len(self.labels)-1]
+		}
+		return nil
+	case *Br:
+		return self.resolveLabel(&inst.Index)
+	case *BrIf:
+		return self.resolveLabel(&inst.Index)
+	case *BrTable:
+		for i := range inst.Indices.Labels {
+			err := self.resolveLabel(&inst.Indices.Labels[i])
+			if err != nil {
+				return err
+			}
+		}
+		return self.resolveLabel(&inst.Indices.Default)
+	case *Call:
+		return module.funcs.resolve(&inst.Index)
+	case *ReturnCall:
+		return module.funcs.resolve(&inst.Index)
+	case *RefFunc:
+		return module.funcs.resolve(&inst.Index)
+	case *CallIndirect:
+		err := module.tables.resolve(&inst.Impl.Table)
+		if err != nil {
+			return err
+		}
+		return module.resolveTypeUse(&inst.Impl.Type)
+	case *ReturnCallIndirect:
+		err := module.tables.resolve(&inst.Impl.Table)
+		if err != nil {
+			return err
+		}
+		return module.resolveTypeUse(&inst.Impl.Type)
+	case *LocalGet:
+		return self.locals.resolve(&inst.Index)
+	case *LocalSet:
+		return self.locals.resolve(&inst.Index)
+	case *LocalTee:
+		return self.locals.resolve(&inst.Index)
+	case *GlobalGet:
+		return module.globals.resolve(&inst.Index)
+	case *GlobalSet:
+		return module.globals.resolve(&inst.Index)
+	case *TableGet:
+		return module.tables.resolve(&inst.Index)
+	case *TableSet:
+		return module.tables.resolve(&inst.Index)
+	case *TableFill:
+		return module.tables.resolve(&inst.Index)
+	case *TableSize:
+		return module.tables.resolve(&inst.Index)
+	case *TableGrow:
+		return module.tables.resolve(&inst.Index)
+	case *TableInit:
+		err := module.tables.resolve(&inst.Impl.Table)
+		if err != nil {
+			return err
+		}
+		return module.elems.resolve(&inst.Impl.Elem)
+	case *TableCopy:
+		err := module.tables.resolve(&inst.Impl.Dst)
+		if err != nil {
+			return err
+		}
+		return module.tables.resolve(&inst.Impl.Src)
+	case *MemorySize:
+		return module.memories.resolve(&inst.Mem)
+	case *MemoryGrow:
+		return module.memories.resolve(&inst.Mem)
+	case *MemoryFill:
+		return module.memories.resolve(&inst.Mem)
+	case *MemoryCopy:
+		err := module.memories.resolve(&inst.Impl.Dst)
+		if err != nil {
+			return err
+		}
+		return module.memories.resolve(&inst.Impl.Src)
+	case *MemoryInit:
+		err := module.memories.resolve(&inst.Impl.Memory)
+		if err != nil {
+			return err
+		}
+		return module.datas.resolve(&inst.Impl.Data)
+	case *DataDrop:
+		return module.datas.resolve(&inst.Index)
+	case *ElemDrop:
+		return module.elems.resolve(&inst.Index)
+	}
+
+	return nil
+}
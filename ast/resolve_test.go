@@ -0,0 +1,191 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/ontio/wast-parser/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseWat(t *testing.T, source string) Module {
+	ps, err := parser.NewParserBuffer(source)
+	assert.Nil(t, err)
+
+	var wat Wat
+	err = wat.Parse(ps)
+	assert.Nil(t, err)
+
+	return wat.Module
+}
+
+func encodeWat(t *testing.T, module *Module) []byte {
+	bin, err := module.Encode()
+	assert.Nil(t, err)
+
+	return bin
+}
+
+func TestResolveNames(t *testing.T) {
+	named := parseWat(t, `
+(module
+  (type $t0 (func (param i32) (param i32) (result i32)))
+  (func $add (type $t0) (param $a i32) (param $b i32) (result i32)
+    (local $tmp i32)
+    local.get $a
+    local.get $b
+    i32.add
+    local.set $tmp
+    local.get $tmp)
+  (func $main (type $t0)
+    (local $x i32)
+    local.get 0
+    local.get $x
+    call $add)
+)
+`)
+	numbered := parseWat(t, `
+(module
+  (type (func (param i32) (param i32) (result i32)))
+  (func (type 0) (param i32) (param i32) (result i32)
+    (local i32)
+    local.get 0
+    local.get 1
+    i32.add
+    local.set 2
+    local.get 2)
+  (func (type 0)
+    (local i32)
+    local.get 0
+    local.get 2
+    call 0)
+)
+`)
+
+	assert.Nil(t, named.Resolve())
+	assert.Nil(t, numbered.Resolve())
+	assert.Equal(t, encodeWat(t, &numbered), encodeWat(t, &named))
+}
+
+func TestResolveLabels(t *testing.T) {
+	module := parseWat(t, `
+(module
+  (func
+    (block $outer
+      (loop $inner
+        br $inner
+        br $outer
+        (block
+          br_table $inner $outer 0)))))
+`)
+	assert.Nil(t, module.Resolve())
+
+	fun := module.Kind.(ModuleKindText).Fields[0].(Func)
+	var depths []uint32
+	for _, instr := range fun.Kind.(FuncKindInline).Expr.Instrs {
+		switch inst := instr.(type) {
+		case *Br:
+			depths = append(depths, inst.Index.Num)
+		case *BrTable:
+			for _, label := range inst.Indices.Labels {
+				depths = append(depths, label.Num)
+			}
+			depths = append(depths, inst.Indices.Default.Num)
+		}
+	}
+	assert.Equal(t, []uint32{0, 1, 1, 2, 0}, depths)
+}
+
+func TestResolveErrors(t *testing.T) {
+	for _, source := range []string{
+		`(module (func $f) (func $f))`,
+		`(module (func (local $x i32) (local $x i32)))`,
+		`(module (func call $g))`,
+		`(module (func local.get $x))`,
+		`(module (func br $l))`,
+		`(module (export "e" (global $g)))`,
+		`(module (func (block $a end $b)))`,
+	} {
+		module := parseWat(t, source)
+		assert.NotNil(t, module.Resolve(), source)
+	}
+}
+
+func TestResolveTwice(t *testing.T) {
+	module := parseWat(t, `
+(module
+  (func $f (export "f") (param $x i32) (result i32)
+    (local.get $x)
+    (block (param i32) (result i32) (call $f))))
+`)
+	assert.Nil(t, module.Resolve())
+	bin := encodeWat(t, &module)
+	fields := len(module.Kind.(ModuleKindText).Fields)
+
+	// Validate resolves the module it is given, which must be a no-op here
+	assert.Empty(t, Validate(&module))
+	assert.Nil(t, module.Resolve())
+	assert.Equal(t, fields, len(module.Kind.(ModuleKindText).Fields))
+	assert.Equal(t, bin, encodeWat(t, &module))
+}
+
+func TestCloneResolve(t *testing.T) {
+	module := parseWat(t, `(module (func $f (export "f") call $f))`)
+	clone := module.Clone()
+	assert.Nil(t, clone.Resolve())
+
+	call := func(m *Module) *Call {
+		for _, field := range m.Kind.(ModuleKindText).Fields {
+			if fn, ok := field.(Func); ok {
+				return fn.Kind.(FuncKindInline).Expr.Instrs[0].(*Call)
+			}
+		}
+		return nil
+	}
+	assert.True(t, call(clone).Index.Isnum)
+	assert.False(t, call(&module).Index.Isnum)
+	assert.Equal(t, 1, len(module.Kind.(ModuleKindText).Fields))
+}
+
+func TestResolveTypeUse(t *testing.T) {
+	inline := parseWat(t, `
+(module
+  (type $t (func (param i32) (result i32)))
+  (import "env" "f" (func $imp (param i64)))
+  (table 1 funcref)
+  (func $f (param $x i32) (result i32)
+    local.get $x)
+  (func $g (param f32) (param f64)
+    (call_indirect (param i32) (result i32) (i32.const 1) (i32.const 0))
+    drop)
+  (func (type $t) (local i32)
+    local.get 1)
+)
+`)
+	explicit := parseWat(t, `
+(module
+  (type (func (param i32) (result i32)))
+  (import "env" "f" (func (type 1)))
+  (table 1 funcref)
+  (func (type 0)
+    local.get 0)
+  (func (type 2)
+    (call_indirect (type 0) (i32.const 1) (i32.const 0))
+    drop)
+  (func (type 0) (local i32)
+    local.get 1)
+  (type (func (param i64)))
+  (type (func (param f32 f64)))
+)
+`)
+
+	assert.Nil(t, inline.Resolve())
+	assert.Nil(t, explicit.Resolve())
+	assert.Equal(t, encodeWat(t, &explicit), encodeWat(t, &inline))
+
+	mismatch := parseWat(t, `
+(module
+  (type (func (param i32)))
+  (func (type 0) (param i64)))
+`)
+	assert.NotNil(t, mismatch.Resolve())
+}
@@ -1,8 +1,6 @@
 package ast
 
 import (
-	"fmt"
-
 	"github.com/ontio/wast-parser/lexer"
 	"github.com/ontio/wast-parser/parser"
 )
@@ -39,13 +37,17 @@ func (self *Table) Parse(ps *parser.ParserBuffer) error {
 	//  *   `(import "a" "b") limits`
 	//  *   `limits`
 	var elemType TableElemType
-	if ps.TryParse(&elemType) == nil {
+	if matchTableElemType(ps.PeekToken()) {
+		err := elemType.Parse(ps)
+		if err != nil {
+			return err
+		}
 		return ps.Parens(func(ps *parser.ParserBuffer) error {
 			err := ps.ExpectKeywordMatch("elem")
 			if err != nil {
 				return err
 			}
-			if ps.PeekToken().Type() == lexer.LParenType {
+			if matchTokenType(ps.PeekToken(), lexer.LParenType) {
 				payload, err := parseElemPayloadExprs(ps, elemType)
 				if err != nil {
 					return err
@@ -63,18 +65,18 @@ func (self *Table) Parse(ps *parser.ParserBuffer) error {
 
 			return nil
 		})
-	} else if ps.PeekToken().Type() == lexer.LParenType {
+	} else if matchTokenType(ps.PeekToken(), lexer.LParenType) {
 		var module, name string
 		err := ps.Parens(func(ps *parser.ParserBuffer) error {
 			err := ps.ExpectKeywordMatch("import")
 			if err != nil {
 				return err
 			}
-			module, err = ps.ExpectString()
+			module, err = ps.ExpectName()
 			if err != nil {
 				return err
 			}
-			name, err = ps.ExpectString()
+			name, err = ps.ExpectName()
 			if err != nil {
 				return err
 			}
@@ -102,7 +104,7 @@ func (self *Table) Parse(ps *parser.ParserBuffer) error {
 		self.Kind = normal
 		return nil
 	}
-	return fmt.Errorf("table parse error")
+	return ps.Unexpected("table type")
 }
 
 type ElemPayload interface {
@@ -111,6 +113,10 @@ type ElemPayload interface {
 }
 
 func parseElemPayloadExprs(ps *parser.ParserBuffer, elemType TableElemType) (ElemPayload, error) {
+	err := ps.Require(ps.Pos(), FeatureBulkMemory, "element expressions")
+	if err != nil {
+		return nil, err
+	}
 	var exprs []OptionIndex
 	for !ps.Empty() {
 		var index OptionIndex
@@ -120,9 +126,14 @@ func parseElemPayloadExprs(ps *parser.ParserBuffer, elemType TableElemType) (Ele
 				return err
 			}
 			switch kw {
-			case "ref_null":
+			case "ref_null", "ref.null":
+				// the heap type is implied by the segment type
+				if matchKeyword(ps.PeekToken(), "func") || matchKeyword(ps.PeekToken(), "extern") {
+					var heap HeapType
+					_ = heap.Parse(ps)
+				}
 				index = NoneOptionIndex()
-			case "ref_func":
+			case "ref_func", "ref.func":
 				var ind Index
 				err := ind.Parse(ps)
 				if err != nil {
@@ -130,7 +141,7 @@ func parseElemPayloadExprs(ps *parser.ParserBuffer, elemType TableElemType) (Ele
 				}
 				index = NewOptionIndex(ind)
 			default:
-				return fmt.Errorf("parse elem payload error. invalid keyword: %s", kw)
+				return ps.UnexpectedPrev("ref.null", "ref.func")
 			}
 
 			return nil
@@ -161,8 +172,16 @@ func parseElemPayloadIndices(ps *parser.ParserBuffer) (ElemPayload, error) {
 }
 
 func parseElemPayload(ps *parser.ParserBuffer) (ElemPayload, error) {
-	var elemType TableElemType
-	if ps.TryParse(&elemType) == nil {
+	if matchKeyword(ps.PeekToken(), "func") {
+		_ = ps.ExpectKeywordMatch("func")
+		return parseElemPayloadIndices(ps)
+	}
+	if matchTableElemType(ps.PeekToken()) {
+		var elemType TableElemType
+		err := elemType.Parse(ps)
+		if err != nil {
+			return nil, err
+		}
 		return parseElemPayloadExprs(ps, elemType)
 	}
 
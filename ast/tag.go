@@ -0,0 +1,72 @@
+package ast
+
+import "github.com/ontio/wast-parser/parser"
+
+// Tag is an exception tag of the exception handling proposal, the type use
+// gives the parameters an exception with the tag carries.
+type Tag struct {
+	implModuleField
+	Name    OptionId
+	Exports InlineExport
+	Type    TypeUse
+	Kind    TagKind
+}
+
+type TagKind interface {
+	tagKind()
+}
+
+type implTagKind struct{}
+
+func (self implTagKind) tagKind() {}
+
+type TagKindImport struct {
+	implTagKind
+	Module string
+	Field  string
+}
+
+type TagKindInline struct {
+	implTagKind
+}
+
+func (self *Tag) Parse(ps *parser.ParserBuffer) error {
+	span := ps.Pos()
+	err := ps.ExpectKeywordMatch("tag")
+	if err != nil {
+		return err
+	}
+	err = ps.Require(span, FeatureExceptions, "tag")
+	if err != nil {
+		return err
+	}
+	self.Name.Parse(ps)
+
+	err = self.Exports.Parse(ps)
+	if err != nil {
+		return err
+	}
+
+	self.Kind = TagKindInline{}
+	if matchKeyword(ps.Peek2Token(), "import") {
+		var imp TagKindImport
+		err = ps.Parens(func(ps *parser.ParserBuffer) error {
+			err := ps.ExpectKeywordMatch("import")
+			if err != nil {
+				return err
+			}
+			imp.Module, err = ps.ExpectName()
+			if err != nil {
+				return err
+			}
+			imp.Field, err = ps.ExpectName()
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		self.Kind = imp
+	}
+
+	return self.Type.Parse(ps)
+}
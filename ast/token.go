@@ -2,7 +2,6 @@ package ast
 
 import (
 	"errors"
-	"fmt"
 	"math"
 	"strconv"
 	"strings"
@@ -13,14 +12,18 @@ import (
 
 type Id struct {
 	Name string
+	// position of the identifier in the source, for error reporting
+	Span lexer.Span
 }
 
 func (self *Id) Parse(ps *parser.ParserBuffer) error {
+	span := ps.Pos()
 	id := ps.TryGetId()
 	if len(id) == 0 {
-		return errors.New("expect an identifier")
+		return ps.Unexpected("identifier")
 	}
 	self.Name = id
+	self.Span = span
 	return nil
 }
 
@@ -61,6 +64,15 @@ func NewNumIndex(num uint32) Index {
 	}
 }
 
+// parseDefaultIndex reads an index which may be omitted in the text format,
+// as the table of `table.get`, and defaults to 0.
+func parseDefaultIndex(ps *parser.ParserBuffer) Index {
+	var index OptionIndex
+	index.Parse(ps)
+
+	return index.ToIndexOr(NewNumIndex(0))
+}
+
 type OptionIndex struct {
 	isSome bool
 	index  Index
@@ -105,10 +117,11 @@ func (self OptionIndex) ToIndexOr(ind Index) Index {
 }
 
 func (self *Index) Parse(ps *parser.ParserBuffer) error {
+	span := ps.Pos()
 	id := ps.TryGetId()
 	if len(id) != 0 {
 		self.Isnum = false
-		self.Id = Id{Name: id}
+		self.Id = Id{Name: id, Span: span}
 		return nil
 	}
 
@@ -130,6 +143,51 @@ func matchTokenType(token lexer.Token, ty lexer.TokenType) bool {
 	return token != nil && token.Type() == ty
 }
 
+// errFloatRange reports a float literal which rounds to infinity or a NaN
+// payload which does not fit in the significand.
+var errFloatRange = errors.New("constant out of range")
+
+// parseFloatVal rounds a decimal or hexadecimal literal to the nearest value
+// of the given bit size, ties to even.
+func parseFloatVal(num lexer.FloatVal, bitSize int) (float64, error) {
+	integral := num.Integral
+	neg := strings.HasPrefix(integral, "-")
+	if neg {
+		integral = integral[1:]
+	}
+
+	s := integral
+	if num.Hex {
+		s = "0x" + s
+	}
+	if num.Decimal != "" {
+		s += "." + num.Decimal
+	}
+	if num.Hex {
+		// strconv requires the binary exponent of hex floats
+		exponent := num.Exponent
+		if exponent == "" {
+			exponent = "0"
+		}
+		s += "p" + exponent
+	} else if num.Exponent != "" {
+		s += "e" + num.Exponent
+	}
+	if neg {
+		s = "-" + s
+	}
+
+	f, err := strconv.ParseFloat(s, bitSize)
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange && math.IsInf(f, 0) {
+			return 0, errFloatRange
+		}
+		return 0, err
+	}
+
+	return f, nil
+}
+
 func string2f64(val lexer.Float) (uint64, error) {
 	width := uint64(64)
 	negOffset := width - 1
@@ -137,7 +195,6 @@ func string2f64(val lexer.Float) (uint64, error) {
 	expOffset := negOffset - expBits
 	signifBits := width - 1 - expBits
 	signifMask := uint64(1<<expOffset) - 1
-	//bias := (1<<(expBits - 1)) - 1
 	switch num := val.(type) {
 	case lexer.Inf:
 		exprBits := uint64((1 << expBits) - 1)
@@ -156,37 +213,19 @@ func string2f64(val lexer.Float) (uint64, error) {
 		if num.SpecBit {
 			signif = uint64(num.Val)
 		}
-		if signif&signifMask == 0 {
-			return 0, errors.New("parse float64 error")
+		if signif == 0 || signif > signifMask {
+			return 0, errFloatRange
 		}
-		return (negBits << negOffset) | (exprBits << expOffset) | (signif & signifMask), nil
+		return (negBits << negOffset) | (exprBits << expOffset) | signif, nil
 	case lexer.FloatVal:
-		if !num.Hex {
-			s := num.Integral
-			if num.Decimal != "" {
-				s += "." + num.Decimal
-			}
-			if num.Exponent != "" {
-				s += "e" + num.Exponent
-			}
-			f, err := strconv.ParseFloat(s, 64)
-			if err != nil {
-				return 0, err
-			}
-			// looks like the `*.wat` format considers infinite overflow to
-			// be invalid.
-			if math.IsInf(f, 0) {
-				return 0, errors.New("parse float64 error, float infinite")
-			}
-
-			return math.Float64bits(f), nil
+		f, err := parseFloatVal(num, 64)
+		if err != nil {
+			return 0, err
 		}
-		//TODO
-		fmt.Printf("todo: parse hex float not implemented yet,type: %x, val: %s\n", val.Type(), val.String())
-		//return 0, nil
-		panic(fmt.Sprintf("todo: parse hex float not implemented yet,type: %x, val: %s\n", val.Type(), val.String()))
+
+		return math.Float64bits(f), nil
 	default:
-		panic("unreachable")
+		return 0, errors.New("unexpected float literal")
 	}
 }
 
@@ -197,7 +236,6 @@ func string2f32(val lexer.Float) (uint32, error) {
 	expOffset := negOffset - expBits
 	signifBits := width - 1 - expBits
 	signifMask := uint32(1<<expOffset) - 1
-	//bias := (1<<(expBits - 1)) - 1
 	switch num := val.(type) {
 	case lexer.Inf:
 		exprBits := uint32((1 << expBits) - 1)
@@ -212,71 +250,66 @@ func string2f32(val lexer.Float) (uint32, error) {
 		if num.Neg {
 			negBits = 1
 		}
-		signif := uint32(1 << (signifBits - 1))
+		signif := uint64(1 << (signifBits - 1))
 		if num.SpecBit {
-			signif = uint32(num.Val)
+			signif = num.Val
 		}
-		if signif&signifMask == 0 {
-			return 0, errors.New("parse float 32 error")
+		if signif == 0 || signif > uint64(signifMask) {
+			return 0, errFloatRange
 		}
-		return (negBits << negOffset) | (exprBits << expOffset) | (signif & signifMask), nil
+		return (negBits << negOffset) | (exprBits << expOffset) | uint32(signif), nil
 	case lexer.FloatVal:
-		if !num.Hex {
-			s := num.Integral
-			if num.Decimal != "" {
-				s += "." + num.Decimal
-			}
-			if num.Exponent != "" {
-				s += "e" + num.Exponent
-			}
-			f, err := strconv.ParseFloat(s, 32)
-			if err != nil {
-				return 0, err
-			}
-			// looks like the `*.wat` format considers infinite overflow to
-			// be invalid.
-			if math.IsInf(f, 0) {
-				return 0, errors.New("parse float32 error, float infinite")
-			}
-
-			return math.Float32bits(float32(f)), nil
+		f, err := parseFloatVal(num, 32)
+		if err != nil {
+			return 0, err
 		}
-		//TODO
-		//fmt.Println("todo: parse hex float not implemented yet")
-		panic(fmt.Sprintf("todo: parse hex float not implemented yet,type: %x, val: %s\n", val.Type(), val.String()))
-		//return 0, nil
+
+		// exact, f is already rounded to float32
+		return math.Float32bits(float32(f)), nil
 	default:
-		panic("unreachable")
+		return 0, errors.New("unexpected float literal")
 	}
 }
 
-func (self *Float32) Parse(ps *parser.ParserBuffer) error {
+// parseFloat reads the literal of a float constant and converts it with
+// convert, ty names the type in range errors.
+func parseFloat(ps *parser.ParserBuffer, ty string, convert func(lexer.Float) error) (bool, error) {
 	token := ps.PeekToken()
+	var val lexer.Float
 	if matchTokenType(token, lexer.FloatType) {
-		val, err := ps.Float()
+		float, err := ps.Float()
 		if err != nil {
-			return err
+			return true, err
 		}
-		self.Bits, err = string2f32(val)
-		return err
+		val = float
 	} else if matchTokenType(token, lexer.IntegerType) {
 		num, err := ps.ExpectInteger()
 		if err != nil {
-			return err
+			return true, err
 		}
-		self.Bits, err = string2f32(lexer.FloatVal{Hex: num.Hex, Integral: num.Val, Decimal: "", Exponent: ""})
-		return err
+		val = lexer.FloatVal{Hex: num.Hex, Integral: num.Val}
+	} else {
+		return false, nil
 	}
-	if matchKeyword(token, "nan:canonical") || matchKeyword(token, "nan:arithmetic") {
-		return nil
+
+	err := convert(val)
+	if err == errFloatRange {
+		return true, &parser.ConstantOverflowError{Span: token.Span(), Literal: ps.Literal(token), Type: ty}
 	}
 
-	if token.Type() == lexer.ReservedType {
-		_ = ps.ExpectReserved()
-		return nil
+	return true, err
+}
+
+func (self *Float32) Parse(ps *parser.ParserBuffer) error {
+	ok, err := parseFloat(ps, "f32", func(val lexer.Float) (err error) {
+		self.Bits, err = string2f32(val)
+		return
+	})
+	if ok {
+		return err
 	}
 
-	return fmt.Errorf("parse float32 error. expect number type, type: %x, val: %s", token.Type(), token.String())
+	return ps.Unexpected("float")
 }
 
 type Float64 struct {
@@ -284,30 +317,15 @@ type Float64 struct {
 }
 
 func (self *Float64) Parse(ps *parser.ParserBuffer) error {
-	token := ps.PeekToken()
-	if matchTokenType(token, lexer.FloatType) {
-		val, err := ps.Float()
-		if err != nil {
-			return err
-		}
+	ok, err := parseFloat(ps, "f64", func(val lexer.Float) (err error) {
 		self.Bits, err = string2f64(val)
+		return
+	})
+	if ok {
 		return err
-	} else if matchTokenType(token, lexer.IntegerType) {
-		num, err := ps.ExpectInteger()
-		if err != nil {
-			return err
-		}
-		self.Bits, err = string2f64(lexer.FloatVal{Hex: num.Hex, Integral: num.Val, Decimal: "", Exponent: ""})
-		return err
-	}
-	if matchKeyword(token, "nan:canonical") || matchKeyword(token, "nan:arithmetic") {
-		return nil
-	}
-	if token.Type() == lexer.ReservedType {
-		_ = ps.ExpectReserved()
-		return nil
 	}
-	return fmt.Errorf("parse float64 error. expect number type, %x, val: %s", token.Type(), token.String())
+
+	return ps.Unexpected("float")
 }
 
 type BlockType struct {
@@ -317,46 +335,54 @@ type BlockType struct {
 
 func (self *BlockType) Parse(ps *parser.ParserBuffer) error {
 	self.Label.Parse(ps)
+	span := ps.Pos()
 	ty := TypeUse{}
 	err := ty.ParseNoNames(ps)
 	if err != nil {
 		return err
 	}
 	self.Ty = ty
+	// multiple results are reported by the function type itself
+	if ty.Index.IsSome() || len(ty.Type.Params) != 0 {
+		return ps.Require(span, FeatureMultiValue, "block type with a type index or parameters")
+	}
 	return nil
 }
 
+// MemArg is the immediate of loads and stores, Memory defaults to 0 if
+// omitted in the text format.
 type MemArg struct {
+	Memory Index
 	Align  uint32
-	Offset uint32
+	Offset uint64
 }
 
 func (self *MemArg) Parse(ps *parser.ParserBuffer, defaultAlign uint32) error {
-	parseField := func(name string, ps *parser.ParserBuffer) (some bool, val uint32, err error) {
-		kw, err := ps.ExpectKeyword()
-		if err != nil {
+	parseField := func(name string, ps *parser.ParserBuffer) (some bool, val uint64, err error) {
+		span := ps.Pos()
+		kw, err := ps.PeekKeyword()
+		if err != nil || !strings.HasPrefix(kw, name+"=") {
 			return false, 0, nil
 		}
-		if strings.HasPrefix(kw, name) == false {
-			return false, 0, nil
-		}
-		kw = kw[len(name):]
-		if strings.HasPrefix(kw, "=") == false {
-			return false, 0, nil
-		}
-		kw = kw[1:]
+		_, _ = ps.ExpectKeyword()
+		literal := kw[len(name)+1:]
+		kw = strings.Replace(literal, "_", "", -1)
 		base := 10
 		if strings.HasPrefix(kw, "0x") {
 			base = 16
 			kw = kw[2:]
 		}
-		value, err := strconv.ParseUint(kw, base, 32)
+		value, err := strconv.ParseUint(kw, base, 64)
 		if err != nil {
-			return false, 0, err
+			if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+				return false, 0, &parser.ConstantOverflowError{Span: span, Literal: literal}
+			}
+			return false, 0, ps.UnexpectedPrev(name + "=<integer>")
 		}
-		return true, uint32(value), nil
+		return true, value, nil
 	}
 
+	self.Memory = parseDefaultIndex(ps)
 	some, offset, err := parseField("offset", ps)
 	if err != nil {
 		return err
@@ -367,19 +393,113 @@ func (self *MemArg) Parse(ps *parser.ParserBuffer, defaultAlign uint32) error {
 	self.Offset = offset
 	some, align, err := parseField("align", ps)
 	if err != nil {
-		self.Align = defaultAlign
-		return nil
+		return err
 	}
-	if some && !isTwoPower(align) {
-		return fmt.Errorf("alignment must be a power of two, %d", align)
-	} else {
-		align = defaultAlign
+	if !some {
+		align = uint64(defaultAlign)
+	} else if align == 0 || align > math.MaxUint32 || !isTwoPower(uint32(align)) {
+		return ps.Errorf("alignment must be a power of two: %d", align)
 	}
 
-	self.Align = align
+	self.Align = uint32(align)
 	return nil
 }
 
 func isTwoPower(num uint32) bool {
 	return num&(num-1) == 0
 }
+
+// V128Bits is the immediate of `v128.const`, the lanes in little endian
+// byte order whatever shape the literal was written in.
+type V128Bits struct {
+	Bytes [16]byte
+}
+
+func (self *V128Bits) Parse(ps *parser.ParserBuffer) error {
+	shape, err := ps.ExpectKeyword()
+	if err != nil {
+		return err
+	}
+
+	buf := self.Bytes[:0]
+	switch shape {
+	case "i8x16":
+		for i := 0; i < 16; i++ {
+			val, err := ps.ExpectI8()
+			if err != nil {
+				return err
+			}
+			buf = append(buf, val)
+		}
+	case "i16x8":
+		for i := 0; i < 8; i++ {
+			val, err := ps.ExpectI16()
+			if err != nil {
+				return err
+			}
+			buf = append(buf, byte(val), byte(val>>8))
+		}
+	case "i32x4":
+		for i := 0; i < 4; i++ {
+			val, err := ps.ExpectI32()
+			if err != nil {
+				return err
+			}
+			buf = appendUint64(buf, uint64(val), 4)
+		}
+	case "i64x2":
+		for i := 0; i < 2; i++ {
+			val, err := ps.ExpectInt64()
+			if err != nil {
+				return err
+			}
+			buf = appendUint64(buf, uint64(val), 8)
+		}
+	case "f32x4":
+		for i := 0; i < 4; i++ {
+			var val Float32
+			if err := val.Parse(ps); err != nil {
+				return err
+			}
+			buf = appendUint64(buf, uint64(val.Bits), 4)
+		}
+	case "f64x2":
+		for i := 0; i < 2; i++ {
+			var val Float64
+			if err := val.Parse(ps); err != nil {
+				return err
+			}
+			buf = appendUint64(buf, val.Bits, 8)
+		}
+	default:
+		return ps.UnexpectedPrev("i8x16", "i16x8", "i32x4", "i64x2", "f32x4", "f64x2")
+	}
+
+	return nil
+}
+
+func appendUint64(buf []byte, val uint64, size int) []byte {
+	for i := 0; i < size; i++ {
+		buf = append(buf, byte(val>>(8*uint(i))))
+	}
+
+	return buf
+}
+
+// ShuffleLanes is the immediate of `v8x16.shuffle`, each lane selects one of
+// the 32 bytes of the two operands.
+type ShuffleLanes struct {
+	Lanes [16]uint8
+}
+
+func (self *ShuffleLanes) Parse(ps *parser.ParserBuffer) error {
+	for i := range self.Lanes {
+		lane, err := ps.ExpectUint8()
+		if err != nil {
+			return err
+		}
+		self.Lanes[i] = lane
+	}
+
+	return nil
+}
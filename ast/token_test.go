@@ -0,0 +1,84 @@
+package ast
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ontio/wast-parser/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFloatLiterals(t *testing.T) {
+	parseF32 := func(literal string) (uint32, error) {
+		ps, err := parser.NewParserBuffer(literal)
+		assert.Nil(t, err)
+		var f Float32
+		err = f.Parse(ps)
+		return f.Bits, err
+	}
+	parseF64 := func(literal string) (uint64, error) {
+		ps, err := parser.NewParserBuffer(literal)
+		assert.Nil(t, err)
+		var f Float64
+		err = f.Parse(ps)
+		return f.Bits, err
+	}
+
+	for _, test := range []struct {
+		literal string
+		bits    uint32
+	}{
+		{"0x1.fffffep+127", 0x7f7fffff},
+		{"-0x1p-149", 0x80000001},
+		{"0x1p-150", 0x00000000},
+		{"0x1.000001p-149", 0x00000001},
+		{"0x1.000001p0", 0x3f800000},
+		{"0x1.000003p0", 0x3f800002},
+		{"0x1.00000100000000000p-50", 0x26800000},
+		{"0x1.00000100000000001p-50", 0x26800001},
+		{"0x1_0000_0000", 0x4f800000},
+		{"0x1.fffffefffffffffffp127", 0x7f7fffff},
+		{"1e-45", 0x00000001},
+		{"1_000.5", 0x447a2000},
+		{"-0x0p0", 0x80000000},
+		{"nan:0x7fffff", 0x7fffffff},
+	} {
+		bits, err := parseF32(test.literal)
+		assert.Nil(t, err, test.literal)
+		assert.Equal(t, test.bits, bits, test.literal)
+	}
+
+	for _, test := range []struct {
+		literal string
+		bits    uint64
+	}{
+		{"0x1.fffffffffffffp1023", 0x7fefffffffffffff},
+		{"-0x1p-1074", 0x8000000000000001},
+		{"0x1.00000000000008p0", 0x3ff0000000000000},
+		{"0x1.00000000000018p0", 0x3ff0000000000002},
+		{"0x0123456789.ABCDEFp019", 0x43223456789abcdf},
+		{"nan:0x8000000000000", 0x7ff8000000000000},
+	} {
+		bits, err := parseF64(test.literal)
+		assert.Nil(t, err, test.literal)
+		assert.Equal(t, test.bits, bits, test.literal)
+	}
+
+	for _, literal := range []string{"0x1p128", "-0x1.ffffffp127", "1e39", "nan:0x800000", "nan:0x0"} {
+		_, err := parseF32(literal)
+		var overflow *parser.ConstantOverflowError
+		assert.True(t, errors.As(err, &overflow), literal)
+		assert.Equal(t, "f32 constant out of range: "+literal, overflow.Message())
+	}
+	_, err := parseF64("0x1p1024")
+	assert.EqualError(t, err, "1:1: f64 constant out of range: 0x1p1024")
+
+	// malformed literals lex as reserved tokens
+	for _, literal := range []string{"0x.8p1", "1.e", "0x1p", "nan:x"} {
+		_, err := parseF32(literal)
+		var unexpected *parser.UnexpectedTokenError
+		assert.True(t, errors.As(err, &unexpected), literal)
+		_, err = parseF64(literal)
+		assert.True(t, errors.As(err, &unexpected), literal)
+	}
+}
@@ -1,8 +1,6 @@
 package ast
 
 import (
-	"errors"
-	"fmt"
 	"github.com/ontio/wast-parser/lexer"
 	"github.com/ontio/wast-parser/parser"
 )
@@ -20,6 +18,7 @@ type ValType struct {
 }
 
 func (self *ValType) Parse(ps *parser.ParserBuffer) error {
+	span := ps.Pos()
 	kw, err := ps.ExpectKeyword()
 	if err != nil {
 		return err
@@ -34,7 +33,7 @@ func (self *ValType) Parse(ps *parser.ParserBuffer) error {
 		*self = F32
 	case "f64":
 		*self = F64
-	case "anyref":
+	case "externref", "anyref":
 		*self = Anyref
 	case "funcref":
 		*self = Funcref
@@ -43,7 +42,11 @@ func (self *ValType) Parse(ps *parser.ParserBuffer) error {
 	case "v128":
 		*self = V128
 	default:
-		return fmt.Errorf("parse valtype error, unexpected keyword: %s", kw)
+		return ps.UnexpectedPrev("value type")
+	}
+
+	if f := valTypeFeature(*self); f != 0 {
+		return ps.Require(span, f, "value type "+kw)
 	}
 
 	return nil
@@ -75,50 +78,88 @@ func (self *GlobalValType) Parse(ps *parser.ParserBuffer) error {
 	return nil
 }
 
+// Limits bounds the size of a table or memory, Max is only meaningful if
+// HasMax is set.
 type Limits struct {
-	Min uint32
-	//opional 0 if None
-	Max uint32
+	Min    uint64
+	Max    uint64
+	HasMax bool
 }
 
 func (self *Limits) Parse(ps *parser.ParserBuffer) error {
-	min, err := ps.ExpectUint32()
+	return self.parse(ps, false)
+}
+
+// parse reads 64-bit limits if is64 is set, 32-bit limits otherwise.
+func (self *Limits) parse(ps *parser.ParserBuffer, is64 bool) error {
+	expect := func() (uint64, error) {
+		if is64 {
+			return ps.ExpectUint64()
+		}
+		val, err := ps.ExpectUint32()
+		return uint64(val), err
+	}
+	var err error
+	self.Min, err = expect()
 	if err != nil {
 		return err
 	}
-	self.Min = min
 
-	val, err := ps.ExpectInteger()
-	if err == nil {
-		max, err := val.ToUint(32)
+	self.HasMax = matchTokenType(ps.PeekToken(), lexer.IntegerType)
+	if self.HasMax {
+		self.Max, err = expect()
 		if err != nil {
 			return err
 		}
-		self.Max = uint32(max)
 	}
 
 	return nil
 }
 
+// MemoryType is the type of a linear memory, Is64 marks a memory64 memory
+// indexed with i64 addresses.
 type MemoryType struct {
 	Limits Limits
 	Shared bool
+	Is64   bool
 }
 
 func (self *MemoryType) Parse(ps *parser.ParserBuffer) error {
-	err := self.Limits.Parse(ps)
+	var err error
+	self.Is64, err = parseIndexType(ps)
+	if err != nil {
+		return err
+	}
+	err = self.Limits.parse(ps, self.Is64)
 	if err != nil {
 		return err
 	}
 
+	span := ps.Pos()
 	err = ps.ExpectKeywordMatch("shared")
 	if err == nil {
 		self.Shared = true
+		return ps.Require(span, FeatureThreads, "shared memory")
 	}
 
 	return nil
 }
 
+// parseIndexType reads the optional index type of a memory and reports
+// whether it is i64.
+func parseIndexType(ps *parser.ParserBuffer) (bool, error) {
+	if matchKeyword(ps.PeekToken(), "i64") {
+		span := ps.Pos()
+		_, _ = ps.ExpectKeyword()
+		return true, ps.Require(span, FeatureMemory64, "64-bit memory")
+	}
+	if matchKeyword(ps.PeekToken(), "i32") {
+		_, _ = ps.ExpectKeyword()
+	}
+
+	return false, nil
+}
+
 type TableElemType struct {
 	ty byte
 }
@@ -128,6 +169,7 @@ var AnyRef = TableElemType{ty: 1}
 var NullRef = TableElemType{ty: 2}
 
 func (self *TableElemType) Parse(ps *parser.ParserBuffer) error {
+	span := ps.Pos()
 	kw, err := ps.ExpectKeyword()
 	if err != nil {
 		return err
@@ -138,17 +180,68 @@ func (self *TableElemType) Parse(ps *parser.ParserBuffer) error {
 		*self = FuncRef
 	case "funcref":
 		*self = FuncRef
-	case "anyref":
+	case "externref", "anyref":
 		*self = AnyRef
 	case "nullref":
 		*self = NullRef
 	default:
-		return fmt.Errorf("default")
+		return ps.UnexpectedPrev("funcref", "externref")
+	}
+
+	if *self != FuncRef {
+		return ps.Require(span, FeatureReferenceTypes, "table type "+kw)
+	}
+
+	return nil
+}
+
+// matchTableElemType reports whether token starts a table element type, the
+// type is then parsed for good so a disabled proposal is reported.
+func matchTableElemType(token lexer.Token) bool {
+	for _, kw := range []string{"anyfunc", "funcref", "externref", "anyref", "nullref"} {
+		if matchKeyword(token, kw) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HeapType is the type of the reference produced by `ref.null`.
+type HeapType struct {
+	ty byte
+}
+
+var HeapFunc = HeapType{ty: 0}
+var HeapExtern = HeapType{ty: 1}
+
+func (self *HeapType) Parse(ps *parser.ParserBuffer) error {
+	kw, err := ps.ExpectKeyword()
+	if err != nil {
+		return err
+	}
+
+	switch kw {
+	case "func":
+		*self = HeapFunc
+	case "extern":
+		*self = HeapExtern
+	default:
+		return ps.UnexpectedPrev("func", "extern")
 	}
 
 	return nil
 }
 
+// ValType returns the reference type of the heap type.
+func (self HeapType) ValType() ValType {
+	if self == HeapExtern {
+		return Anyref
+	}
+
+	return Funcref
+}
+
 type TableType struct {
 	Limits Limits
 	Elem   TableElemType
@@ -200,7 +293,7 @@ func (self *FunctionType) ParseBody(ps *parser.ParserBuffer, allowNames bool) er
 			switch kw {
 			case "param":
 				if len(self.Results) > 0 {
-					return errors.New("result before parameter")
+					return ps.UnexpectedPrev()
 				}
 				if ps.Empty() {
 					return nil
@@ -234,15 +327,22 @@ func (self *FunctionType) ParseBody(ps *parser.ParserBuffer, allowNames bool) er
 				}
 			case "result":
 				for !ps.Empty() {
+					span := ps.Pos()
 					var valType ValType
 					err := valType.Parse(ps)
 					if err != nil {
 						return err
 					}
 					self.Results = append(self.Results, valType)
+					if len(self.Results) == 2 {
+						err = ps.Require(span, FeatureMultiValue, "multiple results")
+						if err != nil {
+							return err
+						}
+					}
 				}
 			default:
-				return fmt.Errorf("invalid func param: %s", kw)
+				return ps.UnexpectedPrev("param", "result")
 			}
 			return nil
 		})
@@ -275,6 +375,8 @@ func (self *Type) Parse(ps *parser.ParserBuffer) error {
 type TypeUse struct {
 	Index OptionIndex // Optional
 	Type  FunctionType
+	// Span locates the type use in the text format for errors
+	Span lexer.Span
 }
 
 func (self *TypeUse) ParseNoNames(ps *parser.ParserBuffer) error {
@@ -282,6 +384,7 @@ func (self *TypeUse) ParseNoNames(ps *parser.ParserBuffer) error {
 }
 
 func (self *TypeUse) ParseAllowNames(ps *parser.ParserBuffer, allowNames bool) error {
+	self.Span = ps.Pos()
 	if matchKeyword(ps.Peek2Token(), "type") {
 		err := ps.Parens(func(ps *parser.ParserBuffer) error {
 			_ = ps.ExpectKeywordMatch("type")
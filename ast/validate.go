@@ -0,0 +1,1328 @@
+package ast
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// maxPages is the largest number of 64KiB pages a 32-bit memory can have.
+const maxPages = 65536
+
+// maxPages64 is the largest number of pages a memory64 memory can have.
+const maxPages64 = 1 << 48
+
+// ValidationError reports a rule of the spec broken by a module, Msg is worded
+// like the messages of the reference interpreter so `assert_invalid` can match
+// on it. Context names the item the error was found in, e.g. `func 2`.
+type ValidationError struct {
+	Context string
+	Msg     string
+}
+
+func (self *ValidationError) Error() string {
+	if self.Context == "" {
+		return self.Msg
+	}
+	return self.Context + ": " + self.Msg
+}
+
+// unknownType is the type of operands popped from the stack of unreachable
+// code, it matches every other type.
+var unknownType = ValType{ty: 0xff}
+
+// nullType is the type of `ref.null`, it matches every reference type.
+var nullType = ValType{ty: 0xfe}
+
+// Validate type checks a module against the validation rules of the spec. A
+// text module is resolved in place first, as by `Resolve`, so it can be
+// encoded or instantiated afterwards without resolving it again. Every
+// function body is checked on its own, so more than one error may be returned,
+// an empty result means the module is valid.
+func Validate(module *Module) []error {
+	if bin, ok := module.Kind.(ModuleKindBinary); ok {
+		decoded, err := DecodeModule(concatBytes(bin.Bins))
+		if err != nil {
+			return []error{err}
+		}
+		module = decoded
+	}
+	err := module.Resolve()
+	if err != nil {
+		return []error{err}
+	}
+
+	// a module without a kind is empty, as for Encode and Print
+	text, _ := module.Kind.(ModuleKindText)
+	val := &validator{}
+	val.validate(text.Fields)
+	return val.errs
+}
+
+func concatBytes(bins [][]byte) []byte {
+	var data []byte
+	for _, bin := range bins {
+		data = append(data, bin...)
+	}
+	return data
+}
+
+type validator struct {
+	types           []FunctionType
+	funcs           []uint32
+	tables          []TableType
+	memories        []MemoryType
+	globals         []GlobalValType
+	importedGlobals int
+	elems           []TableElemType
+	datas           int
+	tags            []uint32
+	// refs are the functions declared outside of the function bodies, in
+	// elem segments, exports and initializers, which ref.func may refer to
+	refs map[uint32]bool
+
+	errs []error
+}
+
+func (self *validator) errorf(context string, format string, a ...interface{}) {
+	self.errs = append(self.errs, &ValidationError{Context: context, Msg: fmt.Sprintf(format, a...)})
+}
+
+func (self *validator) validate(fields []ModuleField) {
+	// the index spaces are complete before any item is checked, since
+	// functions may refer to items defined after them
+	self.refs = make(map[uint32]bool)
+	for _, field := range fields {
+		switch val := field.(type) {
+		case Type:
+			self.types = append(self.types, val.Func)
+		case Import:
+			switch item := val.Item.(type) {
+			case ImportFunc:
+				self.funcs = append(self.funcs, item.TypeUse.Index.ToIndex().Num)
+			case ImportTable:
+				self.tables = append(self.tables, item.Table)
+			case ImportMemory:
+				self.memories = append(self.memories, item.Mem)
+			case ImportGlobal:
+				self.globals = append(self.globals, item.Global)
+				self.importedGlobals += 1
+			case ImportTag:
+				self.tags = append(self.tags, item.TypeUse.Index.ToIndex().Num)
+			}
+		case Func:
+			self.funcs = append(self.funcs, val.Type.Index.ToIndex().Num)
+		case Table:
+			if normal, ok := val.Kind.(TableKindNormal); ok {
+				self.tables = append(self.tables, normal.Type)
+			}
+		case Memory:
+			if normal, ok := val.Kind.(*MemoryKindNormal); ok {
+				self.memories = append(self.memories, normal.Type)
+			}
+		case Global:
+			self.globals = append(self.globals, val.ValType)
+			if inline, ok := val.Kind.(GlobalKindInline); ok {
+				self.declareRefs(inline.Expr)
+			}
+		case Elem:
+			elemType := FuncRef
+			switch payload := val.Payload.(type) {
+			case ElemPayloadIndices:
+				for _, index := range payload.Indices {
+					self.refs[index.Num] = true
+				}
+			case ElemPayloadExprs:
+				elemType = payload.Type
+				for _, expr := range payload.Exprs {
+					if expr.IsSome() {
+						self.refs[expr.ToIndex().Num] = true
+					}
+				}
+			}
+			if active, ok := val.Kind.(ElemKindActive); ok {
+				self.declareRefs(active.Offset)
+			}
+			self.elems = append(self.elems, elemType)
+		case Data:
+			if active, ok := val.Kind.(DataKindActive); ok {
+				self.declareRefs(active.Offset)
+			}
+			self.datas += 1
+		case Export:
+			if val.Type == ExportFunc {
+				self.refs[val.Index.Num] = true
+			}
+		case Tag:
+			self.tags = append(self.tags, val.Type.Index.ToIndex().Num)
+		}
+	}
+
+	var funcs, tables, memories, globals, elems, datas, tags int
+	exports := make(map[string]bool)
+	for _, field := range fields {
+		switch val := field.(type) {
+		case Import:
+			switch item := val.Item.(type) {
+			case ImportFunc:
+				self.checkFuncType(fmt.Sprintf("func %d", funcs), item.TypeUse)
+				funcs += 1
+			case ImportTable:
+				self.checkTableType(fmt.Sprintf("table %d", tables), item.Table)
+				tables += 1
+			case ImportMemory:
+				self.checkMemoryType(fmt.Sprintf("memory %d", memories), item.Mem)
+				memories += 1
+			case ImportGlobal:
+				globals += 1
+			case ImportTag:
+				self.checkTagType(fmt.Sprintf("tag %d", tags), item.TypeUse)
+				tags += 1
+			}
+		case Func:
+			context := fmt.Sprintf("func %d", funcs)
+			if self.checkFuncType(context, val.Type) {
+				self.checkFunc(context, val)
+			}
+			funcs += 1
+		case Table:
+			if normal, ok := val.Kind.(TableKindNormal); ok {
+				self.checkTableType(fmt.Sprintf("table %d", tables), normal.Type)
+			}
+			tables += 1
+		case Memory:
+			if normal, ok := val.Kind.(*MemoryKindNormal); ok {
+				self.checkMemoryType(fmt.Sprintf("memory %d", memories), normal.Type)
+			}
+			memories += 1
+		case Global:
+			if inline, ok := val.Kind.(GlobalKindInline); ok {
+				context := fmt.Sprintf("global %d", globals)
+				// only imported globals may be referred to by initializers
+				self.checkConstExpr(context, inline.Expr, val.ValType.Type, self.importedGlobals)
+			}
+			globals += 1
+		case Tag:
+			self.checkTagType(fmt.Sprintf("tag %d", tags), val.Type)
+			tags += 1
+		case Export:
+			if exports[val.Name] {
+				self.errorf("", "duplicate export name %q", val.Name)
+			}
+			exports[val.Name] = true
+			self.checkExport(val)
+		case StartField:
+			self.checkStart(val)
+		case Elem:
+			self.checkElem(fmt.Sprintf("elem %d", elems), val)
+			elems += 1
+		case Data:
+			self.checkData(fmt.Sprintf("data %d", datas), val)
+			datas += 1
+		}
+	}
+}
+
+// declareRefs adds the functions referred to by ref.func in expr to refs.
+func (self *validator) declareRefs(expr Expression) {
+	for _, instr := range expr.Instrs {
+		if ref, ok := instr.(*RefFunc); ok {
+			self.refs[ref.Index.Num] = true
+		}
+	}
+}
+
+func (self *validator) checkFuncType(context string, ty TypeUse) bool {
+	index := ty.Index.ToIndex().Num
+	if int(index) >= len(self.types) {
+		self.errorf(context, "unknown type %d", index)
+		return false
+	}
+	return true
+}
+
+func (self *validator) checkTagType(context string, ty TypeUse) {
+	if !self.checkFuncType(context, ty) {
+		return
+	}
+	if len(self.types[ty.Index.ToIndex().Num].Results) != 0 {
+		self.errorf(context, "non-empty tag result type")
+	}
+}
+
+// tagType returns the signature of a tag, an unknown type index has already
+// been reported by checkTagType.
+func (self *validator) tagType(index uint32) FunctionType {
+	ty := self.tags[index]
+	if int(ty) >= len(self.types) {
+		return FunctionType{}
+	}
+	return self.types[ty]
+}
+
+func (self *validator) checkLimits(context string, limits Limits, bound uint64, msg string) {
+	if limits.Min > bound || limits.HasMax && limits.Max > bound {
+		self.errorf(context, msg)
+	}
+	if limits.HasMax && limits.Min > limits.Max {
+		self.errorf(context, "size minimum must not be greater than maximum")
+	}
+}
+
+func (self *validator) checkTableType(context string, ty TableType) {
+	self.checkLimits(context, ty.Limits, math.MaxUint32, "table size must be at most 2^32-1")
+}
+
+func (self *validator) checkMemoryType(context string, ty MemoryType) {
+	if ty.Is64 {
+		self.checkLimits(context, ty.Limits, maxPages64, "memory size must be at most 2^48 pages")
+	} else {
+		self.checkLimits(context, ty.Limits, maxPages, "memory size must be at most 65536 pages (4GiB)")
+	}
+	if ty.Shared && !ty.Limits.HasMax {
+		self.errorf(context, "shared memory must have maximum")
+	}
+}
+
+func (self *validator) checkExport(export Export) {
+	context := fmt.Sprintf("export %q", export.Name)
+	index := export.Index.Num
+	switch export.Type {
+	case ExportFunc:
+		if int(index) >= len(self.funcs) {
+			self.errorf(context, "unknown function %d", index)
+		}
+	case ExportTable:
+		if int(index) >= len(self.tables) {
+			self.errorf(context, "unknown table %d", index)
+		}
+	case ExportMemory:
+		if int(index) >= len(self.memories) {
+			self.errorf(context, "unknown memory %d", index)
+		}
+	case ExportGlobal:
+		if int(index) >= len(self.globals) {
+			self.errorf(context, "unknown global %d", index)
+		}
+	case ExportTag:
+		if int(index) >= len(self.tags) {
+			self.errorf(context, "unknown tag %d", index)
+		}
+	}
+}
+
+func (self *validator) checkStart(start StartField) {
+	index := start.Index.Num
+	if int(index) >= len(self.funcs) {
+		self.errorf("start", "unknown function %d", index)
+		return
+	}
+	ty := self.funcType(index)
+	if len(ty.Params) != 0 || len(ty.Results) != 0 {
+		self.errorf("start", "start function must not have parameters or results")
+	}
+}
+
+func (self *validator) checkElem(context string, elem Elem) {
+	elemType := FuncRef
+	if exprs, ok := elem.Payload.(ElemPayloadExprs); ok {
+		elemType = exprs.Type
+	}
+	if active, ok := elem.Kind.(ElemKindActive); ok {
+		table := active.Table.Num
+		if int(table) >= len(self.tables) {
+			self.errorf(context, "unknown table %d", table)
+		} else if !matchType(elemValType(elemType), elemValType(self.tables[table].Elem)) {
+			self.errorf(context, "type mismatch")
+		}
+		self.checkConstExpr(context, active.Offset, I32, len(self.globals))
+	}
+
+	switch payload := elem.Payload.(type) {
+	case ElemPayloadIndices:
+		for _, index := range payload.Indices {
+			if int(index.Num) >= len(self.funcs) {
+				self.errorf(context, "unknown function %d", index.Num)
+			}
+		}
+	case ElemPayloadExprs:
+		for _, expr := range payload.Exprs {
+			if expr.IsSome() && int(expr.ToIndex().Num) >= len(self.funcs) {
+				self.errorf(context, "unknown function %d", expr.ToIndex().Num)
+			}
+		}
+	}
+}
+
+func (self *validator) checkData(context string, data Data) {
+	active, ok := data.Kind.(DataKindActive)
+	if !ok {
+		return
+	}
+	if int(active.Memory.Num) >= len(self.memories) {
+		self.errorf(context, "unknown memory %d", active.Memory.Num)
+		return
+	}
+	ty := self.memories[active.Memory.Num].indexType()
+	self.checkConstExpr(context, active.Offset, ty, len(self.globals))
+}
+
+// checkConstExpr checks an initializer, globals is the number of globals it
+// may read.
+func (self *validator) checkConstExpr(context string, expr Expression, ty ValType, globals int) {
+	for _, instr := range expr.Instrs {
+		switch inst := instr.(type) {
+		case *I32Const, *I64Const, *F32Const, *F64Const, *V128Const, *RefNull, *RefFunc:
+		case *GlobalGet:
+			if int(inst.Index.Num) >= globals {
+				self.errorf(context, "unknown global %d", inst.Index.Num)
+				return
+			}
+			if self.globals[inst.Index.Num].Mutable {
+				self.errorf(context, "constant expression required")
+				return
+			}
+		default:
+			self.errorf(context, "constant expression required")
+			return
+		}
+	}
+
+	fv := newFuncValidator(self, nil, []ValType{ty})
+	err := fv.check(expr.Instrs)
+	if err != nil {
+		self.errorf(context, "%s", err)
+	}
+}
+
+func (self *validator) checkFunc(context string, fun Func) {
+	inline, ok := fun.Kind.(FuncKindInline)
+	if !ok {
+		return
+	}
+	ty := self.types[fun.Type.Index.ToIndex().Num]
+
+	var locals []ValType
+	for _, param := range ty.Params {
+		locals = append(locals, param.Val)
+	}
+	for _, local := range inline.Locals {
+		locals = append(locals, local.ValType)
+	}
+
+	fv := newFuncValidator(self, locals, ty.Results)
+	err := fv.check(inline.Expr.Instrs)
+	if err != nil {
+		self.errorf(context, "%s", err)
+	}
+}
+
+// funcType returns the signature of a function, an unknown type index has
+// already been reported by checkFuncType.
+func (self *validator) funcType(index uint32) FunctionType {
+	ty := self.funcs[index]
+	if int(ty) >= len(self.types) {
+		return FunctionType{}
+	}
+	return self.types[ty]
+}
+
+func elemValType(ty TableElemType) ValType {
+	switch ty {
+	case FuncRef:
+		return Funcref
+	case NullRef:
+		return nullType
+	default:
+		return Anyref
+	}
+}
+
+func isRefType(ty ValType) bool {
+	return ty == Anyref || ty == Funcref || ty == nullType
+}
+
+// matchType reports whether a value of type actual can be used where expected
+// is required.
+func matchType(actual, expected ValType) bool {
+	switch {
+	case actual == expected || actual == unknownType || expected == unknownType:
+		return true
+	case expected == Anyref, expected == Funcref:
+		return actual == nullType
+	}
+	return false
+}
+
+func typeNames(types []ValType) string {
+	var names []string
+	for _, ty := range types {
+		names = append(names, ty.String())
+	}
+	return "[" + strings.Join(names, " ") + "]"
+}
+
+type ctrlFrame struct {
+	loop        bool
+	isIf        bool
+	isTry       bool
+	isCatch     bool
+	catchAll    bool
+	start       []ValType
+	end         []ValType
+	height      int
+	unreachable bool
+}
+
+// labelTypes are the types a branch to the frame carries.
+func (self *ctrlFrame) labelTypes() []ValType {
+	if self.loop {
+		return self.start
+	}
+	return self.end
+}
+
+// funcValidator is the operand and control stack algorithm of the validation
+// appendix of the spec.
+type funcValidator struct {
+	module *validator
+	locals []ValType
+	opds   []ValType
+	ctrls  []ctrlFrame
+}
+
+func newFuncValidator(module *validator, locals []ValType, results []ValType) *funcValidator {
+	fv := &funcValidator{module: module, locals: locals}
+	fv.pushCtrl(ctrlFrame{end: results})
+	return fv
+}
+
+func (self *funcValidator) push(ty ValType) {
+	self.opds = append(self.opds, ty)
+}
+
+func (self *funcValidator) pushTypes(types []ValType) {
+	for _, ty := range types {
+		self.push(ty)
+	}
+}
+
+func (self *funcValidator) pop() (ValType, error) {
+	frame := &self.ctrls[len(self.ctrls)-1]
+	if len(self.opds) == frame.height {
+		if frame.unreachable {
+			return unknownType, nil
+		}
+		return unknownType, fmt.Errorf("type mismatch: operand stack is empty")
+	}
+	ty := self.opds[len(self.opds)-1]
+	self.opds = self.opds[:len(self.opds)-1]
+	return ty, nil
+}
+
+func (self *funcValidator) popExpect(expected ValType) (ValType, error) {
+	actual, err := self.pop()
+	if err != nil {
+		return actual, fmt.Errorf("type mismatch: expected %s, found empty stack", expected)
+	}
+	if !matchType(actual, expected) {
+		return actual, fmt.Errorf("type mismatch: expected %s, found %s", expected, actual)
+	}
+	if actual == unknownType {
+		return expected, nil
+	}
+	return actual, nil
+}
+
+func (self *funcValidator) popTypes(types []ValType) error {
+	for i := len(types) - 1; i >= 0; i-- {
+		_, err := self.popExpect(types[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (self *funcValidator) pushCtrl(frame ctrlFrame) {
+	frame.height = len(self.opds)
+	self.ctrls = append(self.ctrls, frame)
+	self.pushTypes(frame.start)
+}
+
+func (self *funcValidator) popCtrl() (ctrlFrame, error) {
+	frame := self.ctrls[len(self.ctrls)-1]
+	err := self.popTypes(frame.end)
+	if err != nil {
+		return frame, err
+	}
+	if len(self.opds) != frame.height {
+		return frame, fmt.Errorf("type mismatch: block leaves %d values on the stack, expected %s",
+			len(self.opds)-frame.height+len(frame.end), typeNames(frame.end))
+	}
+	self.ctrls = self.ctrls[:len(self.ctrls)-1]
+	return frame, nil
+}
+
+func (self *funcValidator) setUnreachable() {
+	frame := &self.ctrls[len(self.ctrls)-1]
+	self.opds = self.opds[:frame.height]
+	frame.unreachable = true
+}
+
+func (self *funcValidator) label(index Index) (*ctrlFrame, error) {
+	if int(index.Num) >= len(self.ctrls) {
+		return nil, fmt.Errorf("unknown label %d", index.Num)
+	}
+	return &self.ctrls[len(self.ctrls)-1-int(index.Num)], nil
+}
+
+// check validates a function body or a constant expression, both without the
+// final `end`.
+func (self *funcValidator) check(instrs []Instruction) error {
+	for _, instr := range instrs {
+		if len(self.ctrls) == 0 {
+			return fmt.Errorf("unexpected %s after the end of the function", instr.String())
+		}
+		err := self.checkInstr(instr)
+		if err != nil {
+			return fmt.Errorf("%s (%s)", err, instr.String())
+		}
+	}
+	if len(self.ctrls) != 1 {
+		return fmt.Errorf("unclosed block")
+	}
+	_, err := self.popCtrl()
+	return err
+}
+
+func (self *funcValidator) blockType(ty BlockType) ([]ValType, []ValType, error) {
+	if ty.Ty.Index.IsSome() {
+		index := ty.Ty.Index.ToIndex().Num
+		if int(index) >= len(self.module.types) {
+			return nil, nil, fmt.Errorf("unknown type %d", index)
+		}
+		fn := self.module.types[index]
+		return paramTypes(fn), fn.Results, nil
+	}
+	return paramTypes(ty.Ty.Type), ty.Ty.Type.Results, nil
+}
+
+func paramTypes(fn FunctionType) []ValType {
+	var params []ValType
+	for _, param := range fn.Params {
+		params = append(params, param.Val)
+	}
+	return params
+}
+
+func (self *funcValidator) tag(index Index) (FunctionType, error) {
+	if int(index.Num) >= len(self.module.tags) {
+		return FunctionType{}, fmt.Errorf("unknown tag %d", index.Num)
+	}
+	return self.module.tagType(index.Num), nil
+}
+
+// popHandler closes the try block or the previous handler before a catch
+// clause.
+func (self *funcValidator) popHandler(name string) (ctrlFrame, error) {
+	frame := self.ctrls[len(self.ctrls)-1]
+	if !frame.isTry && !frame.isCatch || frame.catchAll {
+		return frame, fmt.Errorf("%s without matching try", name)
+	}
+	return self.popCtrl()
+}
+
+func (self *funcValidator) local(index Index) (ValType, error) {
+	if int(index.Num) >= len(self.locals) {
+		return unknownType, fmt.Errorf("unknown local %d", index.Num)
+	}
+	return self.locals[index.Num], nil
+}
+
+func (self *funcValidator) global(index Index) (GlobalValType, error) {
+	if int(index.Num) >= len(self.module.globals) {
+		return GlobalValType{}, fmt.Errorf("unknown global %d", index.Num)
+	}
+	return self.module.globals[index.Num], nil
+}
+
+func (self *funcValidator) table(index Index) (TableType, error) {
+	if int(index.Num) >= len(self.module.tables) {
+		return TableType{}, fmt.Errorf("unknown table %d", index.Num)
+	}
+	return self.module.tables[index.Num], nil
+}
+
+func (self *funcValidator) memory(index Index) (MemoryType, error) {
+	if int(index.Num) >= len(self.module.memories) {
+		return MemoryType{}, fmt.Errorf("unknown memory %d", index.Num)
+	}
+	return self.module.memories[index.Num], nil
+}
+
+// indexType is the type of the addresses and sizes of the memory.
+func (self MemoryType) indexType() ValType {
+	if self.Is64 {
+		return I64
+	}
+	return I32
+}
+
+func (self *funcValidator) checkCall(fn FunctionType) error {
+	err := self.popTypes(paramTypes(fn))
+	if err != nil {
+		return err
+	}
+	self.pushTypes(fn.Results)
+	return nil
+}
+
+func (self *funcValidator) checkCallIndirect(inner CallIndirectInner) (FunctionType, error) {
+	table, err := self.table(inner.Table)
+	if err != nil {
+		return FunctionType{}, err
+	}
+	if table.Elem != FuncRef {
+		return FunctionType{}, fmt.Errorf("type mismatch: call_indirect requires a funcref table")
+	}
+	index := inner.Type.Index.ToIndex().Num
+	if int(index) >= len(self.module.types) {
+		return FunctionType{}, fmt.Errorf("unknown type %d", index)
+	}
+	_, err = self.popExpect(I32)
+	if err != nil {
+		return FunctionType{}, err
+	}
+	fn := self.module.types[index]
+	return fn, self.checkCall(fn)
+}
+
+func (self *funcValidator) checkReturn(fn FunctionType) error {
+	if !sameTypes(fn.Results, self.ctrls[0].end) {
+		return fmt.Errorf("type mismatch: tail call results %s don't match the function results %s",
+			typeNames(fn.Results), typeNames(self.ctrls[0].end))
+	}
+	return nil
+}
+
+func sameTypes(a, b []ValType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (self *funcValidator) checkInstr(instr Instruction) error {
+	switch inst := instr.(type) {
+	case *Unreachable:
+		self.setUnreachable()
+	case *Nop:
+	case *Block:
+		start, end, err := self.blockType(inst.BlockType)
+		if err != nil {
+			return err
+		}
+		err = self.popTypes(start)
+		if err != nil {
+			return err
+		}
+		self.pushCtrl(ctrlFrame{start: start, end: end})
+	case *Loop:
+		start, end, err := self.blockType(inst.BlockType)
+		if err != nil {
+			return err
+		}
+		err = self.popTypes(start)
+		if err != nil {
+			return err
+		}
+		self.pushCtrl(ctrlFrame{loop: true, start: start, end: end})
+	case *If:
+		start, end, err := self.blockType(inst.BlockType)
+		if err != nil {
+			return err
+		}
+		_, err = self.popExpect(I32)
+		if err != nil {
+			return err
+		}
+		err = self.popTypes(start)
+		if err != nil {
+			return err
+		}
+		self.pushCtrl(ctrlFrame{isIf: true, start: start, end: end})
+	case *Try:
+		start, end, err := self.blockType(inst.BlockType)
+		if err != nil {
+			return err
+		}
+		err = self.popTypes(start)
+		if err != nil {
+			return err
+		}
+		self.pushCtrl(ctrlFrame{isTry: true, start: start, end: end})
+	case *Catch:
+		tag, err := self.tag(inst.Index)
+		if err != nil {
+			return err
+		}
+		frame, err := self.popHandler("catch")
+		if err != nil {
+			return err
+		}
+		self.pushCtrl(ctrlFrame{isCatch: true, start: paramTypes(tag), end: frame.end})
+	case *CatchAll:
+		frame, err := self.popHandler("catch_all")
+		if err != nil {
+			return err
+		}
+		self.pushCtrl(ctrlFrame{isCatch: true, catchAll: true, end: frame.end})
+	case *Delegate:
+		if !self.ctrls[len(self.ctrls)-1].isTry {
+			return fmt.Errorf("delegate without matching try")
+		}
+		frame, err := self.popCtrl()
+		if err != nil {
+			return err
+		}
+		// the label is relative to the blocks enclosing the try
+		_, err = self.label(inst.Index)
+		if err != nil {
+			return err
+		}
+		self.pushTypes(frame.end)
+	case *Throw:
+		tag, err := self.tag(inst.Index)
+		if err != nil {
+			return err
+		}
+		err = self.popTypes(paramTypes(tag))
+		if err != nil {
+			return err
+		}
+		self.setUnreachable()
+	case *Rethrow:
+		frame, err := self.label(inst.Index)
+		if err != nil {
+			return err
+		}
+		if !frame.isCatch {
+			return fmt.Errorf("invalid rethrow label")
+		}
+		self.setUnreachable()
+	case *Else:
+		if !self.ctrls[len(self.ctrls)-1].isIf {
+			return fmt.Errorf("else without matching if")
+		}
+		frame, err := self.popCtrl()
+		if err != nil {
+			return err
+		}
+		self.pushCtrl(ctrlFrame{start: frame.start, end: frame.end})
+	case *End:
+		if len(self.ctrls) == 1 {
+			return fmt.Errorf("end without matching block")
+		}
+		frame, err := self.popCtrl()
+		if err != nil {
+			return err
+		}
+		// an `if` without `else` passes its parameters through the missing
+		// branch
+		if frame.isIf && !sameTypes(frame.start, frame.end) {
+			return fmt.Errorf("type mismatch: if without else must not change the stack")
+		}
+		self.pushTypes(frame.end)
+	case *Br:
+		frame, err := self.label(inst.Index)
+		if err != nil {
+			return err
+		}
+		err = self.popTypes(frame.labelTypes())
+		if err != nil {
+			return err
+		}
+		self.setUnreachable()
+	case *BrIf:
+		frame, err := self.label(inst.Index)
+		if err != nil {
+			return err
+		}
+		_, err = self.popExpect(I32)
+		if err != nil {
+			return err
+		}
+		types := frame.labelTypes()
+		err = self.popTypes(types)
+		if err != nil {
+			return err
+		}
+		self.pushTypes(types)
+	case *BrTable:
+		_, err := self.popExpect(I32)
+		if err != nil {
+			return err
+		}
+		def, err := self.label(inst.Indices.Default)
+		if err != nil {
+			return err
+		}
+		arity := len(def.labelTypes())
+		for _, index := range inst.Indices.Labels {
+			frame, err := self.label(index)
+			if err != nil {
+				return err
+			}
+			if len(frame.labelTypes()) != arity {
+				return fmt.Errorf("type mismatch: br_table targets have different arities")
+			}
+			// every target checks the same operands
+			types := frame.labelTypes()
+			saved := append([]ValType(nil), self.opds...)
+			err = self.popTypes(types)
+			if err != nil {
+				return err
+			}
+			self.opds = saved
+		}
+		err = self.popTypes(def.labelTypes())
+		if err != nil {
+			return err
+		}
+		self.setUnreachable()
+	case *Return:
+		err := self.popTypes(self.ctrls[0].end)
+		if err != nil {
+			return err
+		}
+		self.setUnreachable()
+	case *Call:
+		if int(inst.Index.Num) >= len(self.module.funcs) {
+			return fmt.Errorf("unknown function %d", inst.Index.Num)
+		}
+		return self.checkCall(self.module.funcType(inst.Index.Num))
+	case *ReturnCall:
+		if int(inst.Index.Num) >= len(self.module.funcs) {
+			return fmt.Errorf("unknown function %d", inst.Index.Num)
+		}
+		fn := self.module.funcType(inst.Index.Num)
+		err := self.checkCall(fn)
+		if err != nil {
+			return err
+		}
+		err = self.checkReturn(fn)
+		if err != nil {
+			return err
+		}
+		self.setUnreachable()
+	case *CallIndirect:
+		_, err := self.checkCallIndirect(inst.Impl)
+		return err
+	case *ReturnCallIndirect:
+		fn, err := self.checkCallIndirect(inst.Impl)
+		if err != nil {
+			return err
+		}
+		err = self.checkReturn(fn)
+		if err != nil {
+			return err
+		}
+		self.setUnreachable()
+	case *Drop:
+		_, err := self.pop()
+		return err
+	case *Select:
+		return self.checkSelect(inst.SelectTypes)
+	case *LocalGet:
+		ty, err := self.local(inst.Index)
+		if err != nil {
+			return err
+		}
+		self.push(ty)
+	case *LocalSet:
+		ty, err := self.local(inst.Index)
+		if err != nil {
+			return err
+		}
+		_, err = self.popExpect(ty)
+		return err
+	case *LocalTee:
+		ty, err := self.local(inst.Index)
+		if err != nil {
+			return err
+		}
+		_, err = self.popExpect(ty)
+		if err != nil {
+			return err
+		}
+		self.push(ty)
+	case *GlobalGet:
+		global, err := self.global(inst.Index)
+		if err != nil {
+			return err
+		}
+		self.push(global.Type)
+	case *GlobalSet:
+		global, err := self.global(inst.Index)
+		if err != nil {
+			return err
+		}
+		if !global.Mutable {
+			return fmt.Errorf("global is immutable")
+		}
+		_, err = self.popExpect(global.Type)
+		return err
+	case *TableGet:
+		table, err := self.table(inst.Index)
+		if err != nil {
+			return err
+		}
+		return self.checkSig([]ValType{I32}, []ValType{elemValType(table.Elem)})
+	case *TableSet:
+		table, err := self.table(inst.Index)
+		if err != nil {
+			return err
+		}
+		return self.checkSig([]ValType{I32, elemValType(table.Elem)}, nil)
+	case *TableSize:
+		_, err := self.table(inst.Index)
+		if err != nil {
+			return err
+		}
+		self.push(I32)
+	case *TableGrow:
+		table, err := self.table(inst.Index)
+		if err != nil {
+			return err
+		}
+		return self.checkSig([]ValType{elemValType(table.Elem), I32}, []ValType{I32})
+	case *TableFill:
+		table, err := self.table(inst.Index)
+		if err != nil {
+			return err
+		}
+		return self.checkSig([]ValType{I32, elemValType(table.Elem), I32}, nil)
+	case *TableCopy:
+		dst, err := self.table(inst.Impl.Dst)
+		if err != nil {
+			return err
+		}
+		src, err := self.table(inst.Impl.Src)
+		if err != nil {
+			return err
+		}
+		if !matchType(elemValType(src.Elem), elemValType(dst.Elem)) {
+			return fmt.Errorf("type mismatch: table.copy between %s and %s tables", src.Elem, dst.Elem)
+		}
+		return self.checkSig([]ValType{I32, I32, I32}, nil)
+	case *TableInit:
+		table, err := self.table(inst.Impl.Table)
+		if err != nil {
+			return err
+		}
+		if int(inst.Impl.Elem.Num) >= len(self.module.elems) {
+			return fmt.Errorf("unknown elem segment %d", inst.Impl.Elem.Num)
+		}
+		elem := self.module.elems[inst.Impl.Elem.Num]
+		if !matchType(elemValType(elem), elemValType(table.Elem)) {
+			return fmt.Errorf("type mismatch: table.init of %s segment into %s table", elem, table.Elem)
+		}
+		return self.checkSig([]ValType{I32, I32, I32}, nil)
+	case *ElemDrop:
+		if int(inst.Index.Num) >= len(self.module.elems) {
+			return fmt.Errorf("unknown elem segment %d", inst.Index.Num)
+		}
+	case *MemorySize:
+		mem, err := self.memory(inst.Mem)
+		if err != nil {
+			return err
+		}
+		self.push(mem.indexType())
+	case *MemoryGrow:
+		mem, err := self.memory(inst.Mem)
+		if err != nil {
+			return err
+		}
+		ty := mem.indexType()
+		return self.checkSig([]ValType{ty}, []ValType{ty})
+	case *MemoryFill:
+		mem, err := self.memory(inst.Mem)
+		if err != nil {
+			return err
+		}
+		ty := mem.indexType()
+		return self.checkSig([]ValType{ty, I32, ty}, nil)
+	case *MemoryCopy:
+		dst, err := self.memory(inst.Impl.Dst)
+		if err != nil {
+			return err
+		}
+		src, err := self.memory(inst.Impl.Src)
+		if err != nil {
+			return err
+		}
+		// the length is 64-bit only if both memories are
+		length := I32
+		if dst.Is64 && src.Is64 {
+			length = I64
+		}
+		return self.checkSig([]ValType{dst.indexType(), src.indexType(), length}, nil)
+	case *MemoryInit:
+		mem, err := self.memory(inst.Impl.Memory)
+		if err != nil {
+			return err
+		}
+		if int(inst.Impl.Data.Num) >= self.module.datas {
+			return fmt.Errorf("unknown data segment %d", inst.Impl.Data.Num)
+		}
+		return self.checkSig([]ValType{mem.indexType(), I32, I32}, nil)
+	case *DataDrop:
+		if int(inst.Index.Num) >= self.module.datas {
+			return fmt.Errorf("unknown data segment %d", inst.Index.Num)
+		}
+	case *RefNull:
+		self.push(inst.Type.ValType())
+	case *RefIsNull:
+		ty, err := self.pop()
+		if err != nil {
+			return err
+		}
+		if ty != unknownType && !isRefType(ty) {
+			return fmt.Errorf("type mismatch: expected a reference, found %s", ty)
+		}
+		self.push(I32)
+	case *RefFunc:
+		if int(inst.Index.Num) >= len(self.module.funcs) {
+			return fmt.Errorf("unknown function %d", inst.Index.Num)
+		}
+		if !self.module.refs[inst.Index.Num] {
+			return fmt.Errorf("undeclared function reference")
+		}
+		self.push(Funcref)
+	case *RefHost:
+		self.push(Anyref)
+	default:
+		return self.checkOperator(instr)
+	}
+
+	return nil
+}
+
+func (self *funcValidator) checkSig(params, results []ValType) error {
+	err := self.popTypes(params)
+	if err != nil {
+		return err
+	}
+	self.pushTypes(results)
+	return nil
+}
+
+func (self *funcValidator) checkSelect(types SelectTypes) error {
+	if len(types.Types) > 1 {
+		return fmt.Errorf("invalid result arity")
+	}
+	_, err := self.popExpect(I32)
+	if err != nil {
+		return err
+	}
+	if len(types.Types) == 1 {
+		return self.checkSig([]ValType{types.Types[0], types.Types[0]}, types.Types)
+	}
+
+	// the untyped form is restricted to numeric operands
+	t1, err := self.pop()
+	if err != nil {
+		return err
+	}
+	t2, err := self.popExpect(t1)
+	if err != nil {
+		return err
+	}
+	if isRefType(t1) || isRefType(t2) {
+		return fmt.Errorf("type mismatch: select without type requires numeric operands")
+	}
+	if t1 == unknownType {
+		t1 = t2
+	}
+	self.push(t1)
+	return nil
+}
+
+type memoryInstr interface {
+	memArg() (*MemArg, uint32)
+}
+
+type laneInstr interface {
+	lane() (uint8, uint8)
+}
+
+// checkOperator checks the instructions which only pop and push a fixed
+// signature.
+func (self *funcValidator) checkOperator(instr Instruction) error {
+	name := instr.String()
+	sig, ok := operatorSigs[name]
+	if !ok {
+		return fmt.Errorf("unknown operator")
+	}
+	params := sig.params
+	if inst, ok := instr.(memoryInstr); ok {
+		arg, natural := inst.memArg()
+		mem, err := self.memory(arg.Memory)
+		if err != nil {
+			return err
+		}
+		if mem.Is64 {
+			// the address operand comes first
+			params = append([]ValType{I64}, params[1:]...)
+		} else if arg.Offset > math.MaxUint32 {
+			return fmt.Errorf("offset out of range")
+		}
+		if strings.Contains(name, "atomic") && name != "atomic.fence" {
+			if arg.Align != natural {
+				return fmt.Errorf("alignment must be exactly natural")
+			}
+		} else if arg.Align > natural {
+			return fmt.Errorf("alignment must not be larger than natural")
+		}
+	}
+	if inst, ok := instr.(laneInstr); ok {
+		lane, lanes := inst.lane()
+		if lane >= lanes {
+			return fmt.Errorf("invalid lane index")
+		}
+	}
+	if inst, ok := instr.(*V8x16Shuffle); ok {
+		for _, lane := range inst.Lanes.Lanes {
+			if lane >= 32 {
+				return fmt.Errorf("invalid lane index")
+			}
+		}
+	}
+	return self.checkSig(params, sig.results)
+}
+
+type operatorSig struct {
+	params  []ValType
+	results []ValType
+}
+
+var operatorSigs = make(map[string]operatorSig)
+
+func addOperators(params, results []ValType, prefix string, names ...string) {
+	for _, name := range names {
+		operatorSigs[prefix+name] = operatorSig{params: params, results: results}
+	}
+}
+
+func init() {
+	i32, i64, f32, f64, v128 := []ValType{I32}, []ValType{I64}, []ValType{F32}, []ValType{F64}, []ValType{V128}
+	pair := func(ty ValType) []ValType { return []ValType{ty, ty} }
+
+	addOperators(nil, i32, "", "i32.const")
+	addOperators(nil, i64, "", "i64.const")
+	addOperators(nil, f32, "", "f32.const")
+	addOperators(nil, f64, "", "f64.const")
+	addOperators(nil, nil, "", "atomic.fence")
+
+	for _, ty := range []ValType{I32, I64} {
+		prefix, single := ty.String()+".", []ValType{ty}
+		addOperators(single, single, prefix, "clz", "ctz", "popcnt", "extend8_s", "extend16_s")
+		addOperators(pair(ty), single, prefix, "add", "sub", "mul", "div_s", "div_u", "rem_s",
+			"rem_u", "and", "or", "xor", "shl", "shr_s", "shr_u", "rotl", "rotr")
+		addOperators(single, i32, prefix, "eqz")
+		addOperators(pair(ty), i32, prefix, "eq", "ne", "lt_s", "lt_u", "gt_s", "gt_u", "le_s",
+			"le_u", "ge_s", "ge_u")
+
+		addOperators(i32, single, prefix, "load", "load8_s", "load8_u", "load16_s", "load16_u",
+			"atomic.load", "atomic.load8_u", "atomic.load16_u")
+		addOperators([]ValType{I32, ty}, nil, prefix, "store", "store8", "store16",
+			"atomic.store", "atomic.store8", "atomic.store16")
+		for _, rmw := range []string{"rmw", "rmw8", "rmw16", "rmw32"} {
+			suffix := "_u"
+			if rmw == "rmw" {
+				suffix = ""
+			}
+			addOperators([]ValType{I32, ty}, single, prefix+"atomic."+rmw+".",
+				"add"+suffix, "sub"+suffix, "and"+suffix, "or"+suffix, "xor"+suffix, "xchg"+suffix)
+			addOperators([]ValType{I32, ty, ty}, single, prefix+"atomic."+rmw+".", "cmpxchg"+suffix)
+		}
+		addOperators([]ValType{I32, ty, I64}, i32, prefix, "atomic.wait")
+	}
+	addOperators(i64, i64, "i64.", "extend32_s")
+	addOperators(i32, i64, "i64.", "load32_s", "load32_u", "atomic.load32_u")
+	addOperators([]ValType{I32, I64}, nil, "i64.", "store32", "atomic.store32")
+	addOperators(pair(I32), i32, "", "atomic.notify")
+
+	for _, ty := range []ValType{F32, F64} {
+		prefix, single := ty.String()+".", []ValType{ty}
+		addOperators(single, single, prefix, "abs", "neg", "ceil", "floor", "trunc", "nearest", "sqrt")
+		addOperators(pair(ty), single, prefix, "add", "sub", "mul", "div", "min", "max", "copysign")
+		addOperators(pair(ty), i32, prefix, "eq", "ne", "lt", "gt", "le", "ge")
+		addOperators(i32, single, prefix, "load")
+		addOperators([]ValType{I32, ty}, nil, prefix, "store")
+	}
+
+	addOperators(i64, i32, "i32.", "wrap_i64")
+	addOperators(f32, i32, "i32.", "trunc_f32_s", "trunc_f32_u", "trunc_sat_f32_s", "trunc_sat_f32_u", "reinterpret_f32")
+	addOperators(f64, i32, "i32.", "trunc_f64_s", "trunc_f64_u", "trunc_sat_f64_s", "trunc_sat_f64_u")
+	addOperators(i32, i64, "i64.", "extend_i32_s", "extend_i32_u")
+	addOperators(f32, i64, "i64.", "trunc_f32_s", "trunc_f32_u", "trunc_sat_f32_s", "trunc_sat_f32_u")
+	addOperators(f64, i64, "i64.", "trunc_f64_s", "trunc_f64_u", "trunc_sat_f64_s", "trunc_sat_f64_u", "reinterpret_f64")
+	addOperators(i32, f32, "f32.", "convert_i32_s", "convert_i32_u", "reinterpret_i32")
+	addOperators(i64, f32, "f32.", "convert_i64_s", "convert_i64_u")
+	addOperators(f64, f32, "f32.", "demote_f64")
+	addOperators(i32, f64, "f64.", "convert_i32_s", "convert_i32_u")
+	addOperators(i64, f64, "f64.", "convert_i64_s", "convert_i64_u", "reinterpret_i64")
+	addOperators(f32, f64, "f64.", "promote_f32")
+
+	addOperators(i32, v128, "", "v128.load", "v8x16.load_splat", "v16x8.load_splat", "v32x4.load_splat",
+		"v64x2.load_splat", "i16x8.load8x8_s", "i16x8.load8x8_u", "i32x4.load16x4_s", "i32x4.load16x4_u",
+		"i64x2.load32x2_s", "i64x2.load32x2_u")
+	addOperators([]ValType{I32, V128}, nil, "", "v128.store")
+	addOperators(v128, v128, "v128.", "not")
+	addOperators(pair(V128), v128, "v128.", "and", "or", "xor", "andnot")
+	addOperators([]ValType{V128, V128, V128}, v128, "v128.", "bitselect")
+	addOperators(pair(V128), v128, "v8x16.", "swizzle")
+	for _, shape := range []string{"i8x16.", "i16x8.", "i32x4.", "i64x2."} {
+		addOperators(pair(V128), v128, shape, "eq", "ne", "lt_s", "lt_u", "gt_s", "gt_u", "le_s",
+			"le_u", "ge_s", "ge_u", "add", "sub", "mul", "add_saturate_s", "add_saturate_u",
+			"sub_saturate_s", "sub_saturate_u")
+		addOperators(v128, v128, shape, "neg")
+		addOperators(v128, i32, shape, "any_true", "all_true")
+		addOperators([]ValType{V128, I32}, v128, shape, "shl", "shr_s", "shr_u")
+	}
+	for _, shape := range []string{"f32x4.", "f64x2."} {
+		addOperators(pair(V128), v128, shape, "eq", "ne", "lt", "gt", "le", "ge", "add", "sub",
+			"mul", "div", "min", "max")
+		addOperators(v128, v128, shape, "abs", "neg", "sqrt")
+	}
+	addOperators(v128, v128, "", "i32x4.trunc_sat_f32x4_s", "i32x4.trunc_sat_f32x4_u",
+		"i64x2.trunc_sat_f64x2_s", "i64x2.trunc_sat_f64x2_u", "f32x4.convert_i32x4_s",
+		"f32x4.convert_i32x4_u", "f64x2.convert_i64x2_s", "f64x2.convert_i64x2_u",
+		"i16x8.widen_low_i8x16_s", "i16x8.widen_high_i8x16_s", "i16x8.widen_low_i8x16_u",
+		"i16x8.widen_high_i8x16_u", "i32x4.widen_low_i16x8_s", "i32x4.widen_high_i16x8_s",
+		"i32x4.widen_low_i16x8_u", "i32x4.widen_high_i16x8_u")
+	addOperators(pair(V128), v128, "", "i8x16.narrow_i16x8_s", "i8x16.narrow_i16x8_u",
+		"i16x8.narrow_i32x4_s", "i16x8.narrow_i32x4_u")
+	addOperators(nil, v128, "", "v128.const")
+	addOperators(pair(V128), v128, "", "v8x16.shuffle")
+	for _, shape := range []struct {
+		name string
+		lane ValType
+	}{{"i8x16.", I32}, {"i16x8.", I32}, {"i32x4.", I32}, {"i64x2.", I64}, {"f32x4.", F32}, {"f64x2.", F64}} {
+		lane := []ValType{shape.lane}
+		addOperators(lane, v128, shape.name, "splat")
+		addOperators([]ValType{V128, shape.lane}, v128, shape.name, "replace_lane")
+		if shape.name == "i8x16." || shape.name == "i16x8." {
+			addOperators(v128, lane, shape.name, "extract_lane_s", "extract_lane_u")
+		} else {
+			addOperators(v128, lane, shape.name, "extract_lane")
+		}
+	}
+}
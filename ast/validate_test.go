@@ -0,0 +1,119 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateValid(t *testing.T) {
+	module := parseWat(t, `
+(module
+  (import "env" "g" (global $g i32))
+  (memory 1 2)
+  (table 2 funcref)
+  (global $m (mut i64) (i64.const 0))
+  (global i32 (global.get $g))
+  (func $add (export "add") (param $a i32) (param $b i32) (result i32)
+    (i32.add (local.get $a) (local.get $b)))
+  (func $main (export "main") (result i32)
+    (local $x f64)
+    (block $done (result i32)
+      (loop $again
+        (drop (br_if $done (i32.const 7) (i32.eqz (i32.const 0))))
+        (global.set $m (i64.extend_i32_u (i32.load offset=4 (i32.const 0))))
+        (br $again))
+      (i32.const 0))
+    (if (result i32) (i32.const 1)
+      (then (call $add (i32.const 1) (i32.const 2)))
+      (else (unreachable)))
+    i32.add
+    drop
+    (call_indirect (param i32 i32) (result i32) (i32.const 1) (i32.const 2) (i32.const 0)))
+  (func $start)
+  (start $start)
+  (func (result funcref) (drop (ref.func $add)) (ref.func $declared))
+  (func $declared)
+  (elem declare func $declared)
+  (elem (global.get $g) $add $main)
+  (data (i32.const 0) "hi")
+)
+`)
+	assert.Empty(t, Validate(&module))
+}
+
+func TestValidateErrors(t *testing.T) {
+	for _, test := range []struct {
+		source string
+		msg    string
+	}{
+		{`(module (func (result i32) (i32.add (i32.const 1) (f64.const 2))))`, "type mismatch"},
+		{`(module (func (result i32)))`, "type mismatch"},
+		{`(module (func (i32.const 1)))`, "type mismatch"},
+		{`(module (func (local.get 2)))`, "unknown local 2"},
+		{`(module (func (result i32) (block (result i32) (f32.const 0))))`, "type mismatch"},
+		{`(module (func (result i32) (if (result i32) (i32.const 0) (then (i32.const 1)))))`, "type mismatch"},
+		{`(module (memory 1) (func (drop (i32.load align=8 (i32.const 0)))))`, "alignment must not be larger than natural"},
+		{`(module (func (drop (i32.load (i32.const 0)))))`, "unknown memory 0"},
+		{`(module (global i32 (i32.const 0)) (func (global.set 0 (i32.const 1))))`, "global is immutable"},
+		{`(module (memory 2 1))`, "size minimum must not be greater than maximum"},
+		{`(module (memory 65537))`, "memory size must be at most 65536 pages (4GiB)"},
+		{`(module (memory 1 0))`, "size minimum must not be greater than maximum"},
+		{`(module (memory i64 0x1000000000001))`, "memory size must be at most 2^48 pages"},
+		{`(module (memory 1) (func (drop (i32.load offset=0x100000000 (i32.const 0)))))`, "offset out of range"},
+		{`(module (memory i64 1) (func (drop (i32.load (i32.const 0)))))`, "type mismatch"},
+		{`(module (memory 1) (func (drop (i32.load 1 (i32.const 0)))))`, "unknown memory 1"},
+		{`(module (func $f (param i32)) (start $f))`, "start function"},
+		{`(module (func $f) (export "a" (func $f)) (export "a" (func $f)))`, "duplicate export name"},
+		{`(module (global i32 (i32.add (i32.const 1) (i32.const 2))))`, "constant expression required"},
+		{`(module (global i64 (i32.const 1)))`, "type mismatch"},
+		{`(module (global i32 (i32.const 0)) (global i32 (global.get 0)))`, "unknown global 0"},
+		{`(module (memory 1) (data (i64.const 0) ""))`, "type mismatch"},
+		{`(module (table 1 funcref) (elem (i32.const 0) 3))`, "unknown function 3"},
+		{`(module (table 1 funcref) (table 1 externref) (func (table.copy 0 1 (i32.const 0) (i32.const 0) (i32.const 0))))`, "type mismatch"},
+		{`(module (memory 1) (func (memory.init 0 (i32.const 0) (i32.const 0) (i32.const 0))))`, "unknown data segment 0"},
+		{`(module (tag (param i32) (result i32)))`, "non-empty tag result type"},
+		{`(module (tag (param i32)) (func (throw 0 (i64.const 0))))`, "type mismatch"},
+		{`(module (func (block (rethrow 0))))`, "invalid rethrow label"},
+		{`(module (func (block catch_all end)))`, "catch_all without matching try"},
+		{`(module (func (type 5)))`, "unknown type 5"},
+		{`(module (func (block (type 5))))`, "unknown type 5"},
+		{`(module (table 1 funcref) (func (call_indirect (type 5) (i32.const 0))))`, "unknown type 5"},
+		{`(module (func (select (i32.const 0) (i64.const 1) (i32.const 1)) drop))`, "type mismatch"},
+		{`(module (func $f (drop (ref.func $f))))`, "undeclared function reference"},
+		{`(module (func $f) (start $f) (func (drop (ref.func $f))))`, "undeclared function reference"},
+	} {
+		module := parseWat(t, test.source)
+		errs := Validate(&module)
+		if assert.NotEmpty(t, errs, test.source) {
+			assert.Contains(t, errs[0].Error(), test.msg, test.source)
+		}
+	}
+}
+
+func TestValidateUnknownType(t *testing.T) {
+	// an unknown type index is left to the validator by the resolver
+	module := parseWat(t, `(module (func (type 5)))`)
+	assert.Nil(t, module.Resolve())
+	errs := Validate(&module)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "func 0: unknown type 5", errs[0].Error())
+	}
+}
+
+func TestValidateEmpty(t *testing.T) {
+	assert.Empty(t, Validate(&Module{}))
+}
+
+func TestValidateAllErrors(t *testing.T) {
+	module := parseWat(t, `
+(module
+  (func (result i32) (f32.const 0))
+  (func (result i64) (i32.const 0))
+  (func (result i32) (i32.const 0)))
+`)
+	errs := Validate(&module)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "func 0", errs[0].(*ValidationError).Context)
+	assert.Equal(t, "func 1", errs[1].(*ValidationError).Context)
+}
@@ -1,8 +1,6 @@
 package ast
 
 import (
-	"errors"
-	"fmt"
 	"github.com/ontio/wast-parser/lexer"
 	"github.com/ontio/wast-parser/parser"
 	"strings"
@@ -48,55 +46,200 @@ type Wast struct {
 
 func (self *Wast) Parse(ps *parser.ParserBuffer) error {
 	if isWastDirectiveToken(ps.Peek2Token()) {
-		for !ps.Empty() {
-			err := ps.Parens(func(ps *parser.ParserBuffer) error {
-				dir, err := parseWastDirective(ps)
-				if err != nil {
-					return err
-				}
-				self.Directives = append(self.Directives, dir)
-				return nil
-			})
-			if err != nil {
-				return err
-			}
+		var err error
+		self.Directives, err = parseWastDirectives(ps)
+		if err != nil {
+			return err
 		}
 	} else {
+		line := ps.Pos().Line
 		var wat Wat
 		err := wat.Parse(ps)
 		if err != nil {
 			return err
 		}
-		self.Directives = append(self.Directives, wat.Module)
+		self.Directives = append(self.Directives, withLine(wat.Module, line))
 	}
 
 	return nil
 }
 
+func parseWastDirectives(ps *parser.ParserBuffer) ([]WastDirective, error) {
+	var dirs []WastDirective
+	for !ps.Empty() {
+		line := ps.Pos().Line
+		err := ps.Parens(func(ps *parser.ParserBuffer) error {
+			dir, err := parseWastDirective(ps)
+			if err != nil {
+				return err
+			}
+			dirs = append(dirs, withLine(dir, line))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dirs, nil
+}
+
 func isWastDirectiveToken(token lexer.Token) bool {
 	if token == nil || token.Type() != lexer.KeywordType {
 		return false
 	}
-	kw := token.(lexer.Keyword).Val
-	if strings.HasPrefix(kw, "assert_") || kw == "module" || kw == "register" || kw == "invoke" {
+	switch kw := token.(lexer.Keyword).Val; kw {
+	case "module", "register", "invoke", "get", "script", "input", "output":
 		return true
+	default:
+		return strings.HasPrefix(kw, "assert_")
 	}
-
-	return false
 }
 
+// WastDirective is a command of a wast script. Modules are the directives
+// Module, Quote, ModuleDefinitionDirective and ModuleInstanceDirective,
+// actions WastInvoke and WastExecuteGet, the rest are assertions and meta
+// commands.
 type WastDirective interface {
 	wastDirective()
+	Line() int
 }
 
-type implWastDirective struct{}
+type implWastDirective struct {
+	line int
+}
 
 func (self implWastDirective) wastDirective() {}
 
-type AssertInvalidDirective struct {
+// Line is the source line the directive starts on, 0 if it was not parsed.
+func (self implWastDirective) Line() int {
+	return self.line
+}
+
+func withLine(dir WastDirective, line int) WastDirective {
+	at := implWastDirective{line: line}
+	switch val := dir.(type) {
+	case Module:
+		val.implWastDirective = at
+		return val
+	case Quote:
+		val.implWastDirective = at
+		return val
+	case ModuleDefinitionDirective:
+		val.implWastDirective = at
+		return val
+	case ModuleInstanceDirective:
+		val.implWastDirective = at
+		return val
+	case RegisterDirective:
+		val.implWastDirective = at
+		return val
+	case WastInvoke:
+		val.implWastDirective = at
+		return val
+	case WastExecuteGet:
+		val.implWastDirective = at
+		return val
+	case AssertReturnDirective:
+		val.implWastDirective = at
+		return val
+	case AssertReturnCanonicalNanDirective:
+		val.implWastDirective = at
+		return val
+	case AssertReturnArithmeticNanDirective:
+		val.implWastDirective = at
+		return val
+	case AssertTrapDirective:
+		val.implWastDirective = at
+		return val
+	case AssertExhaustionDirective:
+		val.implWastDirective = at
+		return val
+	case AssertExceptionDirective:
+		val.implWastDirective = at
+		return val
+	case AssertMalformedDirective:
+		val.implWastDirective = at
+		return val
+	case AssertInvalidDirective:
+		val.implWastDirective = at
+		return val
+	case AssertUnlinkableDirective:
+		val.implWastDirective = at
+		return val
+	case AssertUninstantiableDirective:
+		val.implWastDirective = at
+		return val
+	case ScriptDirective:
+		val.implWastDirective = at
+		return val
+	case InputDirective:
+		val.implWastDirective = at
+		return val
+	case OutputDirective:
+		val.implWastDirective = at
+		return val
+	}
+
+	return dir
+}
+
+// ModuleDefinitionDirective is `(module definition ...)`, it defines a
+// module without instantiating it.
+type ModuleDefinitionDirective struct {
 	implWastDirective
-	Module Module
-	Msg    string
+	Module QuoteModule
+}
+
+// ModuleInstanceDirective is `(module instance $instance $definition)`, it
+// instantiates a module definition, the last one if Definition is none.
+type ModuleInstanceDirective struct {
+	implWastDirective
+	Instance   OptionId
+	Definition OptionId
+}
+
+type RegisterDirective struct {
+	implWastDirective
+	Name   string
+	Module OptionId
+}
+
+type AssertReturnDirective struct {
+	implWastDirective
+	Exec    WastExecute
+	Results []WastValue
+}
+
+// AssertReturnCanonicalNanDirective is the legacy form of an assert_return
+// with a `nan:canonical` result of either float type.
+type AssertReturnCanonicalNanDirective struct {
+	implWastDirective
+	Exec WastExecute
+}
+
+// Match reports whether actual is a canonical NaN of either width, the
+// legacy directive does not tell which.
+func (self AssertReturnCanonicalNanDirective) Match(actual WastValue) bool {
+	return WastF32{Nan: NanCanonical}.Match(actual) || WastF64{Nan: NanCanonical}.Match(actual)
+}
+
+// AssertReturnArithmeticNanDirective is the legacy form of an assert_return
+// with a `nan:arithmetic` result of either float type.
+type AssertReturnArithmeticNanDirective struct {
+	implWastDirective
+	Exec WastExecute
+}
+
+// Match reports whether actual is an arithmetic NaN of either width.
+func (self AssertReturnArithmeticNanDirective) Match(actual WastValue) bool {
+	return WastF32{Nan: NanArithmetic}.Match(actual) || WastF64{Nan: NanArithmetic}.Match(actual)
+}
+
+type AssertTrapDirective struct {
+	implWastDirective
+	Exec WastExecute
+	Msg  string
 }
 
 type AssertExhaustionDirective struct {
@@ -105,10 +248,11 @@ type AssertExhaustionDirective struct {
 	Message string
 }
 
-type AssertUnlinkableDirective struct {
+// AssertExceptionDirective expects the action to throw an exception of the
+// exception handling proposal.
+type AssertExceptionDirective struct {
 	implWastDirective
-	Module Module
-	Msg    string
+	Exec WastExecute
 }
 
 type AssertMalformedDirective struct {
@@ -117,27 +261,49 @@ type AssertMalformedDirective struct {
 	Msg    string
 }
 
-type RegisterDirective struct {
+type AssertInvalidDirective struct {
 	implWastDirective
-	Name   string
-	Module OptionId
+	Module QuoteModule
+	Msg    string
 }
 
-type AssertTrapDirective struct {
+type AssertUnlinkableDirective struct {
 	implWastDirective
-	Exec WastExecute
-	msg  string
+	Module QuoteModule
+	Msg    string
 }
 
-type AssertReturnDirective struct {
+// AssertUninstantiableDirective expects the start function or a segment
+// initialization of the module to trap, it is also written as an
+// assert_trap on a module.
+type AssertUninstantiableDirective struct {
 	implWastDirective
-	Exec    WastExecute
-	Results []Expression
+	Module QuoteModule
+	Msg    string
 }
 
-type AssertReturnCanonicalNanDirective struct {
+// ScriptDirective is the meta command `(script $name? cmd*)` naming a
+// nested script.
+type ScriptDirective struct {
+	implWastDirective
+	Name       OptionId
+	Directives []WastDirective
+}
+
+// InputDirective is the meta command `(input $name? "file")` which runs the
+// script of another file.
+type InputDirective struct {
+	implWastDirective
+	Name OptionId
+	File string
+}
+
+// OutputDirective is the meta command `(output $name? "file"?)` which
+// writes a module, to stdout if File is empty.
+type OutputDirective struct {
 	implWastDirective
-	Invoke WastInvoke
+	Module OptionId
+	File   string
 }
 
 type WastExecute interface {
@@ -152,10 +318,11 @@ type WastInvoke struct {
 	implWastExecute
 	Module OptionId
 	Name   string
-	Args   []Expression
+	Args   []WastValue
 }
 
 type WastExecuteGet struct {
+	implWastDirective
 	implWastExecute
 	Module OptionId
 	Global string
@@ -173,25 +340,25 @@ func (self *WastInvoke) Parse(ps *parser.ParserBuffer) error {
 		return err
 	}
 
-	for !ps.Empty() {
-		err := ps.Parens(func(ps *parser.ParserBuffer) error {
-			var expr Expression
-			err := expr.Parse(ps)
-			if err != nil {
-				return err
-			}
+	self.Args, err = parseWastValues(ps, false)
 
-			self.Args = append(self.Args, expr)
-			return nil
-		})
-		if err != nil {
-			return err
-		}
+	return err
+}
+
+func (self *WastExecuteGet) Parse(ps *parser.ParserBuffer) error {
+	err := ps.ExpectKeywordMatch("get")
+	if err != nil {
+		return err
 	}
 
-	return nil
+	self.Module.Parse(ps)
+	self.Global, err = ps.ExpectString()
+
+	return err
 }
 
+// QuoteModule is a module of a script, a Module or a Quote whose text is
+// only parsed when the module is used.
 type QuoteModule interface {
 	quoteModule()
 }
@@ -200,19 +367,23 @@ type implQuoteModule struct{}
 
 func (self implQuoteModule) quoteModule() {}
 
+// Quote is `(module $name? quote "text"*)`, the module is the concatenation
+// of the strings.
 type Quote struct {
+	implWastDirective
 	implQuoteModule
+	Name OptionId
 	Data []string
 }
 
+// parseQuoteModule reads a module of a script, the `module` keyword is read
+// already.
 func parseQuoteModule(ps *parser.ParserBuffer) (QuoteModule, error) {
-	if matchKeyword(ps.Peek2Token(), "quote") {
-		err := ps.ExpectKeywordMatch("module")
-		if err != nil {
-			return nil, err
-		}
+	var name OptionId
+	name.Parse(ps)
+	if matchKeyword(ps.PeekToken(), "quote") {
 		_ = ps.ExpectKeywordMatch("quote")
-		var quote Quote
+		quote := Quote{Name: name}
 		for !ps.Empty() {
 			str, err := ps.ExpectString()
 			if err != nil {
@@ -223,12 +394,47 @@ func parseQuoteModule(ps *parser.ParserBuffer) (QuoteModule, error) {
 		return quote, nil
 	}
 
-	var module Module
-	err := module.Parse(ps)
+	module := Module{Name: name}
+	err := module.parseBody(ps)
 
 	return module, err
 }
 
+// parseAssertModule reads the parenthesized module and the failure message
+// of assert_malformed and its relatives.
+func parseAssertModule(ps *parser.ParserBuffer) (QuoteModule, string, error) {
+	var module QuoteModule
+	err := ps.Parens(func(ps *parser.ParserBuffer) error {
+		err := ps.ExpectKeywordMatch("module")
+		if err != nil {
+			return err
+		}
+		module, err = parseQuoteModule(ps)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	msg, err := ps.ExpectString()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return module, msg, nil
+}
+
+// parseAction reads the parenthesized invoke or get of an assertion.
+func parseAction(ps *parser.ParserBuffer) (WastExecute, error) {
+	var exec WastExecute
+	err := ps.Parens(func(ps *parser.ParserBuffer) error {
+		var err error
+		exec, err = parseWastExecute(ps)
+		return err
+	})
+
+	return exec, err
+}
+
 func parseWastDirective(ps *parser.ParserBuffer) (WastDirective, error) {
 	kw, err := ps.ExpectKeyword()
 	if err != nil {
@@ -236,35 +442,26 @@ func parseWastDirective(ps *parser.ParserBuffer) (WastDirective, error) {
 	}
 	switch kw {
 	case "module":
-		ps.StepBack(1)
-		var module Module
-		err := module.Parse(ps)
-		return module, err
-	case "assert_malformed":
-		var result AssertMalformedDirective
-		err = ps.Parens(func(ps *parser.ParserBuffer) error {
-			result.Module, err = parseQuoteModule(ps)
-			return err
-		})
-		if err != nil {
-			return nil, err
-		}
-		result.Msg, err = ps.ExpectString()
-		if err != nil {
-			return nil, err
+		if matchKeyword(ps.PeekToken(), "definition") {
+			_ = ps.ExpectKeywordMatch("definition")
+			module, err := parseQuoteModule(ps)
+			if err != nil {
+				return nil, err
+			}
+			return ModuleDefinitionDirective{Module: module}, nil
 		}
-		return result, nil
-	case "assert_invalid":
-		var result AssertInvalidDirective
-		err = ps.Parens(result.Module.Parse)
-		if err != nil {
-			return nil, err
+		if matchKeyword(ps.PeekToken(), "instance") {
+			_ = ps.ExpectKeywordMatch("instance")
+			var result ModuleInstanceDirective
+			result.Instance.Parse(ps)
+			result.Definition.Parse(ps)
+			return result, nil
 		}
-		result.Msg, err = ps.ExpectString()
+		module, err := parseQuoteModule(ps)
 		if err != nil {
 			return nil, err
 		}
-		return result, nil
+		return module.(WastDirective), nil
 	case "register":
 		var result RegisterDirective
 		result.Name, err = ps.ExpectString()
@@ -281,104 +478,151 @@ func parseWastDirective(ps *parser.ParserBuffer) (WastDirective, error) {
 			return nil, err
 		}
 		return invoke, nil
-	case "assert_trap":
-		var trap AssertTrapDirective
-		err := ps.Parens(func(ps *parser.ParserBuffer) error {
-			trap.Exec, err = parseWastExecute(ps)
-			return err
-		})
+	case "get":
+		ps.StepBack(1)
+		var get WastExecuteGet
+		err := get.Parse(ps)
 		if err != nil {
 			return nil, err
 		}
-
-		trap.msg, err = ps.ExpectString()
+		return get, nil
+	case "assert_return":
+		var ret AssertReturnDirective
+		ret.Exec, err = parseAction(ps)
 		if err != nil {
 			return nil, err
 		}
-
-		return trap, nil
-	case "assert_return":
-		var ret AssertReturnDirective
-		err := ps.Parens(func(ps *parser.ParserBuffer) error {
-			ret.Exec, err = parseWastExecute(ps)
-			return err
-		})
+		ret.Results, err = parseWastValues(ps, true)
 		if err != nil {
 			return nil, err
 		}
-		for !ps.Empty() {
-			err := ps.Parens(func(ps *parser.ParserBuffer) error {
-				var expr Expression
-				err := expr.Parse(ps)
-				if err != nil {
-					return err
-				}
-				ret.Results = append(ret.Results, expr)
-				return nil
-			})
+		return ret, nil
+	case "assert_return_canonical_nan":
+		var ret AssertReturnCanonicalNanDirective
+		ret.Exec, err = parseAction(ps)
+		if err != nil {
+			return nil, err
+		}
+		return ret, nil
+	case "assert_return_arithmetic_nan":
+		var ret AssertReturnArithmeticNanDirective
+		ret.Exec, err = parseAction(ps)
+		if err != nil {
+			return nil, err
+		}
+		return ret, nil
+	case "assert_trap":
+		if matchKeyword(ps.Peek2Token(), "module") {
+			var result AssertUninstantiableDirective
+			result.Module, result.Msg, err = parseAssertModule(ps)
 			if err != nil {
 				return nil, err
 			}
+			return result, nil
 		}
-
-		return ret, nil
+		var trap AssertTrapDirective
+		trap.Exec, err = parseAction(ps)
+		if err != nil {
+			return nil, err
+		}
+		trap.Msg, err = ps.ExpectString()
+		if err != nil {
+			return nil, err
+		}
+		return trap, nil
+	case "assert_exhaustion":
+		var result AssertExhaustionDirective
+		err := ps.Parens(result.Call.Parse)
+		if err != nil {
+			return nil, err
+		}
+		result.Message, err = ps.ExpectString()
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	case "assert_exception":
+		var result AssertExceptionDirective
+		result.Exec, err = parseAction(ps)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	case "assert_malformed":
+		var result AssertMalformedDirective
+		result.Module, result.Msg, err = parseAssertModule(ps)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	case "assert_invalid":
+		var result AssertInvalidDirective
+		result.Module, result.Msg, err = parseAssertModule(ps)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
 	case "assert_unlinkable":
 		var result AssertUnlinkableDirective
-		err = ps.Parens(result.Module.Parse)
+		result.Module, result.Msg, err = parseAssertModule(ps)
 		if err != nil {
 			return nil, err
 		}
-		result.Msg, err = ps.ExpectString()
+		return result, nil
+	case "assert_uninstantiable":
+		var result AssertUninstantiableDirective
+		result.Module, result.Msg, err = parseAssertModule(ps)
 		if err != nil {
 			return nil, err
 		}
 		return result, nil
-	case "assert_exhaustion":
-		var invoke WastInvoke
-		err := ps.Parens(invoke.Parse)
+	case "script":
+		var result ScriptDirective
+		result.Name.Parse(ps)
+		result.Directives, err = parseWastDirectives(ps)
 		if err != nil {
 			return nil, err
 		}
-		msg, err := ps.ExpectString()
+		return result, nil
+	case "input":
+		var result InputDirective
+		result.Name.Parse(ps)
+		result.File, err = ps.ExpectString()
 		if err != nil {
 			return nil, err
 		}
-		aed := AssertExhaustionDirective{
-			Call:    invoke,
-			Message: msg,
+		return result, nil
+	case "output":
+		var result OutputDirective
+		result.Module.Parse(ps)
+		if !ps.Empty() {
+			result.File, err = ps.ExpectString()
+			if err != nil {
+				return nil, err
+			}
 		}
-		return aed, nil
+		return result, nil
 	default:
-		return nil, fmt.Errorf("parse wast directive error: unexpected keyword %s", kw)
+		return nil, ps.UnexpectedPrev("directive")
 	}
 }
 
 func parseWastExecute(ps *parser.ParserBuffer) (WastExecute, error) {
-	kw, err := ps.ExpectKeyword()
+	kw, err := ps.PeekKeyword()
 	if err != nil {
 		return nil, err
 	}
 	switch kw {
 	case "invoke":
-		ps.StepBack(1)
 		var invoke WastInvoke
 		err := invoke.Parse(ps)
 		return invoke, err
-	case "module":
-		ps.StepBack(1)
-		var module Module
-		err := module.Parse(ps)
-		return module, err
 	case "get":
 		var get WastExecuteGet
-		get.Module.Parse(ps)
-		get.Global, err = ps.ExpectString()
-		if err != nil {
-			return nil, err
-		}
-
-		return get, nil
+		err := get.Parse(ps)
+		return get, err
 	default:
-		return nil, errors.New("parse wast execute error")
+		_, _ = ps.ExpectKeyword()
+		return nil, ps.UnexpectedPrev("invoke", "get")
 	}
 }
@@ -44,19 +44,9 @@ func TestWastParsing(t *testing.T) {
 	notTestFile := map[string]bool{
 		"call.wast":              true,
 		"conversions.wast":       true,
-		"const.wast":             true,
 		"endianness.wast":        true,
-		"f32_cmp.wast":           true,
 		"memory_redundancy.wast": true,
 		"call_indirect.wast":     true,
-		"f64_cmp.wast":           true,
-		"f32_bitwise.wast":       true,
-		"float_exprs.wast":       true,
-		"float_literals.wast":    true,
-		"f32.wast":               true,
-		"f64.wast":               true,
-		"f64_bitwise.wast":       true,
-		"float_misc.wast":        true,
 	}
 	for name, content := range wasts {
 		if notTestFile[name] {
@@ -83,3 +73,108 @@ func TestWastParsing2(t *testing.T) {
 		assert.Nil(t, err, fmt.Errorf("parse %s error", name))
 	}
 }
+
+func TestWastValues(t *testing.T) {
+	ps, err := parser.NewParserBuffer(`
+(assert_return (invoke "f" (i32.const -1) (f32.const 1.5) (v128.const i8x16 -1 0 0 0 0 0 0 0 0 0 0 0 0 0 0 1))
+  (f32.const nan:canonical) (f64.const nan:arithmetic) (v128.const f32x4 1 nan:canonical 0 -0)
+  (ref.null extern) (ref.extern) (ref.func))
+(invoke "g" (f32.const nan:canonical))
+`)
+	assert.Nil(t, err)
+	var wast Wast
+	err = wast.Parse(ps)
+	assert.EqualError(t, err, "5:24: unexpected token nan:canonical, expected float")
+
+	ps, err = parser.NewParserBuffer(`
+(assert_return (invoke "f" (i32.const -1) (f32.const 1.5) (v128.const i8x16 -1 0 0 0 0 0 0 0 0 0 0 0 0 0 0 1))
+  (f32.const nan:canonical) (f64.const nan:arithmetic) (v128.const f32x4 1 nan:canonical 0 -0)
+  (ref.null extern) (ref.extern) (ref.func))
+`)
+	assert.Nil(t, err)
+	wast = Wast{}
+	assert.Nil(t, wast.Parse(ps))
+	ret := wast.Directives[0].(AssertReturnDirective)
+	assert.Equal(t, []WastValue{WastI32{Val: 0xffffffff}, WastF32{Bits: 0x3fc00000},
+		NewWastV128("i8x16", [16]byte{0xff, 15: 1})}, ret.Exec.(WastInvoke).Args)
+	assert.Equal(t, "(v128.const i8x16 255 0 0 0 0 0 0 0 0 0 0 0 0 0 0 1)", ret.Exec.(WastInvoke).Args[2].String())
+
+	expected := ret.Results
+	assert.Equal(t, 6, len(expected))
+	assert.True(t, expected[0].Match(WastF32{Bits: 0xffc00000}))
+	assert.False(t, expected[0].Match(WastF32{Bits: 0x7fc00001}))
+	assert.False(t, expected[0].Match(WastF64{Bits: 0x7ff8000000000000}))
+	assert.True(t, expected[1].Match(WastF64{Bits: 0x7ff8000000000001}))
+	assert.False(t, expected[1].Match(WastF64{Bits: 0x7ff0000000000001}))
+
+	lanes := WastV128{Shape: "f32x4", Lanes: []WastValue{WastF32{Bits: 0x3f800000},
+		WastF32{Bits: 0x7fc00000}, WastF32{}, WastF32{Bits: 0x80000000}}}
+	assert.True(t, expected[2].Match(NewWastV128("i32x4", lanes.Bytes())))
+	lanes.Lanes[3] = WastF32{}
+	assert.False(t, expected[2].Match(lanes))
+
+	assert.True(t, expected[3].Match(WastRefNull{Type: HeapExtern}))
+	assert.False(t, expected[3].Match(WastRefNull{Type: HeapFunc}))
+	assert.True(t, expected[4].Match(WastRefExtern{Val: 3}))
+	assert.True(t, expected[5].Match(WastRefFunc{Index: 1}))
+	assert.False(t, expected[5].Match(WastRefNull{Type: HeapFunc}))
+
+	assert.True(t, AssertReturnCanonicalNanDirective{}.Match(WastF64{Bits: 0x7ff8000000000000}))
+}
+
+func TestWastDirectives(t *testing.T) {
+	ps, err := parser.NewParserBuffer(`(module $a (func (export "f")))
+(module $q quote "(func)")
+(module definition $d binary "\00asm\01\00\00\00")
+(module instance $i $d)
+(register "a" $a)
+(invoke $a "f")
+(get $a "g")
+(assert_return_canonical_nan (invoke "f"))
+(assert_return_arithmetic_nan (get $a "g"))
+(assert_trap (invoke "f") "unreachable")
+(assert_trap (module (start 0) (func unreachable)) "unreachable")
+(assert_uninstantiable (module quote "(start 0)") "unknown function")
+(assert_exhaustion (invoke "f") "call stack exhausted")
+(assert_exception (invoke $a "f"))
+(assert_invalid (module quote "(func (result i32))") "type mismatch")
+(assert_unlinkable (module (import "x" "y" (func))) "unknown import")
+(script $s (module) (invoke "f"))
+(input $in "other.wast")
+(output $a "out.wasm")
+(output)
+`)
+	assert.Nil(t, err)
+	var wast Wast
+	assert.Nil(t, wast.Parse(ps))
+
+	var types []string
+	for i, dir := range wast.Directives {
+		assert.Equal(t, i+1, dir.Line())
+		types = append(types, fmt.Sprintf("%T", dir))
+	}
+	assert.Equal(t, []string{"ast.Module", "ast.Quote", "ast.ModuleDefinitionDirective",
+		"ast.ModuleInstanceDirective", "ast.RegisterDirective", "ast.WastInvoke", "ast.WastExecuteGet",
+		"ast.AssertReturnCanonicalNanDirective", "ast.AssertReturnArithmeticNanDirective",
+		"ast.AssertTrapDirective", "ast.AssertUninstantiableDirective", "ast.AssertUninstantiableDirective",
+		"ast.AssertExhaustionDirective", "ast.AssertExceptionDirective", "ast.AssertInvalidDirective",
+		"ast.AssertUnlinkableDirective", "ast.ScriptDirective", "ast.InputDirective",
+		"ast.OutputDirective", "ast.OutputDirective"}, types)
+
+	dirs := wast.Directives
+	assert.Equal(t, "q", dirs[1].(Quote).Name.ToId().Name)
+	def := dirs[2].(ModuleDefinitionDirective).Module.(Module)
+	assert.Equal(t, "d", def.Name.ToId().Name)
+	inst := dirs[3].(ModuleInstanceDirective)
+	assert.Equal(t, "i", inst.Instance.ToId().Name)
+	assert.Equal(t, "d", inst.Definition.ToId().Name)
+	assert.Equal(t, "a", dirs[8].(AssertReturnArithmeticNanDirective).Exec.(WastExecuteGet).Module.ToId().Name)
+	assert.Equal(t, "unreachable", dirs[9].(AssertTrapDirective).Msg)
+	assert.Equal(t, "unknown function", dirs[11].(AssertUninstantiableDirective).Msg)
+	script := dirs[16].(ScriptDirective)
+	assert.Equal(t, 2, len(script.Directives))
+	assert.Equal(t, "other.wast", dirs[17].(InputDirective).File)
+	assert.Equal(t, "out.wasm", dirs[18].(OutputDirective).File)
+	output := dirs[19].(OutputDirective)
+	assert.False(t, output.Module.IsSome())
+}
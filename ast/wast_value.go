@@ -0,0 +1,405 @@
+package ast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/ontio/wast-parser/parser"
+)
+
+// WastValue is a constant of a wast script, an argument of an invoke or the
+// expected result of an assertion. Results may be patterns, e.g. any
+// canonical NaN, which Match compares a value computed by a runtime to.
+type WastValue interface {
+	// Match reports whether actual satisfies self as an expected result.
+	Match(actual WastValue) bool
+	// String spells the value the way the script writes it.
+	String() string
+}
+
+// NanPattern is the kind of NaN an expected float matches instead of an exact
+// value.
+type NanPattern byte
+
+const (
+	NanNone NanPattern = iota
+	// NanCanonical matches a NaN with only the top bit of the payload set.
+	NanCanonical
+	// NanArithmetic matches a NaN with the top bit of the payload set.
+	NanArithmetic
+)
+
+func (self NanPattern) String() string {
+	switch self {
+	case NanCanonical:
+		return "nan:canonical"
+	case NanArithmetic:
+		return "nan:arithmetic"
+	}
+
+	return ""
+}
+
+type WastI32 struct {
+	Val uint32
+}
+
+func (self WastI32) Match(actual WastValue) bool {
+	return actual == WastValue(self)
+}
+
+func (self WastI32) String() string {
+	return fmt.Sprintf("(i32.const %d)", int32(self.Val))
+}
+
+type WastI64 struct {
+	Val uint64
+}
+
+func (self WastI64) Match(actual WastValue) bool {
+	return actual == WastValue(self)
+}
+
+func (self WastI64) String() string {
+	return fmt.Sprintf("(i64.const %d)", int64(self.Val))
+}
+
+// WastF32 holds the bits of an f32, or the NaN pattern of an expected
+// result with Bits unused.
+type WastF32 struct {
+	Bits uint32
+	Nan  NanPattern
+}
+
+func (self WastF32) Match(actual WastValue) bool {
+	val, ok := actual.(WastF32)
+	if !ok {
+		return false
+	}
+	switch self.Nan {
+	case NanCanonical:
+		return val.Bits&0x7fffffff == 0x7fc00000
+	case NanArithmetic:
+		return val.Bits&0x7fc00000 == 0x7fc00000
+	}
+
+	return val.Bits == self.Bits
+}
+
+func (self WastF32) String() string {
+	if self.Nan != NanNone {
+		return fmt.Sprintf("(f32.const %s)", self.Nan)
+	}
+	var p printer
+	Float32{Bits: self.Bits}.print(&p)
+
+	return "(f32.const " + p.buf.String() + ")"
+}
+
+// WastF64 holds the bits of an f64, or the NaN pattern of an expected
+// result with Bits unused.
+type WastF64 struct {
+	Bits uint64
+	Nan  NanPattern
+}
+
+func (self WastF64) Match(actual WastValue) bool {
+	val, ok := actual.(WastF64)
+	if !ok {
+		return false
+	}
+	switch self.Nan {
+	case NanCanonical:
+		return val.Bits&0x7fffffffffffffff == 0x7ff8000000000000
+	case NanArithmetic:
+		return val.Bits&0x7ff8000000000000 == 0x7ff8000000000000
+	}
+
+	return val.Bits == self.Bits
+}
+
+func (self WastF64) String() string {
+	if self.Nan != NanNone {
+		return fmt.Sprintf("(f64.const %s)", self.Nan)
+	}
+	var p printer
+	Float64{Bits: self.Bits}.print(&p)
+
+	return "(f64.const " + p.buf.String() + ")"
+}
+
+// WastV128 is a v128 given lane by lane in one of the shapes, e.g. `i32x4`.
+// Integer lanes are WastI32, or WastI64 for `i64x2`, and float lanes may be
+// NaN patterns.
+type WastV128 struct {
+	Shape string
+	Lanes []WastValue
+}
+
+var laneCounts = map[string]int{
+	"i8x16": 16, "i16x8": 8, "i32x4": 4, "i64x2": 2, "f32x4": 4, "f64x2": 2,
+}
+
+// NewWastV128 splits the bytes of a v128 into the lanes of shape.
+func NewWastV128(shape string, bytes [16]byte) WastV128 {
+	result := WastV128{Shape: shape}
+	count := laneCounts[shape]
+	if count == 0 {
+		return result
+	}
+	size := 16 / count
+	for i := 0; i < count; i++ {
+		lane := bytes[i*size : (i+1)*size]
+		var val WastValue
+		switch shape {
+		case "i8x16":
+			val = WastI32{Val: uint32(lane[0])}
+		case "i16x8":
+			val = WastI32{Val: uint32(binary.LittleEndian.Uint16(lane))}
+		case "i32x4":
+			val = WastI32{Val: binary.LittleEndian.Uint32(lane)}
+		case "i64x2":
+			val = WastI64{Val: binary.LittleEndian.Uint64(lane)}
+		case "f32x4":
+			val = WastF32{Bits: binary.LittleEndian.Uint32(lane)}
+		case "f64x2":
+			val = WastF64{Bits: binary.LittleEndian.Uint64(lane)}
+		}
+		result.Lanes = append(result.Lanes, val)
+	}
+
+	return result
+}
+
+// Bytes joins the lanes back into a v128, NaN pattern lanes are zero.
+func (self WastV128) Bytes() [16]byte {
+	var bytes [16]byte
+	buf := bytes[:0]
+	size := 16 / len(self.Lanes)
+	for _, lane := range self.Lanes {
+		switch val := lane.(type) {
+		case WastI32:
+			buf = appendUint64(buf, uint64(val.Val), size)
+		case WastI64:
+			buf = appendUint64(buf, val.Val, size)
+		case WastF32:
+			buf = appendUint64(buf, uint64(val.Bits), size)
+		case WastF64:
+			buf = appendUint64(buf, val.Bits, size)
+		}
+	}
+
+	return bytes
+}
+
+// Match compares actual lane by lane in the shape of self.
+func (self WastV128) Match(actual WastValue) bool {
+	val, ok := actual.(WastV128)
+	if !ok || len(self.Lanes) == 0 || len(val.Lanes) == 0 {
+		return false
+	}
+	lanes := NewWastV128(self.Shape, val.Bytes()).Lanes
+	for i, lane := range self.Lanes {
+		if !lane.Match(lanes[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (self WastV128) String() string {
+	var lanes []string
+	for _, lane := range self.Lanes {
+		// keep the literal of "(i32.const 1)"
+		str := lane.String()
+		lanes = append(lanes, str[strings.IndexByte(str, ' ')+1:len(str)-1])
+	}
+
+	return fmt.Sprintf("(v128.const %s %s)", self.Shape, strings.Join(lanes, " "))
+}
+
+type WastRefNull struct {
+	Type HeapType
+}
+
+func (self WastRefNull) Match(actual WastValue) bool {
+	return actual == WastValue(self)
+}
+
+func (self WastRefNull) String() string {
+	return fmt.Sprintf("(ref.null %s)", self.Type)
+}
+
+// WastRefExtern is the host reference `ref.extern n` of the script, Any is
+// the result pattern `(ref.extern)` matching every non-null one.
+type WastRefExtern struct {
+	Val uint32
+	Any bool
+}
+
+func (self WastRefExtern) Match(actual WastValue) bool {
+	val, ok := actual.(WastRefExtern)
+	return ok && (self.Any || val.Val == self.Val)
+}
+
+func (self WastRefExtern) String() string {
+	if self.Any {
+		return "(ref.extern)"
+	}
+
+	return fmt.Sprintf("(ref.extern %d)", self.Val)
+}
+
+// WastRefFunc is a non-null function reference, only results write it as
+// the pattern `(ref.func)`. Index tells runtime references apart.
+type WastRefFunc struct {
+	Index uint32
+	Any   bool
+}
+
+func (self WastRefFunc) Match(actual WastValue) bool {
+	val, ok := actual.(WastRefFunc)
+	return ok && (self.Any || val.Index == self.Index)
+}
+
+func (self WastRefFunc) String() string {
+	return "(ref.func)"
+}
+
+// parseWastValue reads a constant without its parens, patterns are accepted
+// for expected results only.
+func parseWastValue(ps *parser.ParserBuffer, result bool) (WastValue, error) {
+	kw, err := ps.ExpectKeyword()
+	if err != nil {
+		return nil, err
+	}
+	switch kw {
+	case "i32.const":
+		val, err := ps.ExpectI32()
+		return WastI32{Val: val}, err
+	case "i64.const":
+		val, err := ps.ExpectInt64()
+		return WastI64{Val: uint64(val)}, err
+	case "f32.const":
+		if nan := parseNanPattern(ps, result); nan != NanNone {
+			return WastF32{Nan: nan}, nil
+		}
+		var val Float32
+		err := val.Parse(ps)
+		return WastF32{Bits: val.Bits}, err
+	case "f64.const":
+		if nan := parseNanPattern(ps, result); nan != NanNone {
+			return WastF64{Nan: nan}, nil
+		}
+		var val Float64
+		err := val.Parse(ps)
+		return WastF64{Bits: val.Bits}, err
+	case "v128.const":
+		return parseWastV128(ps, result)
+	case "ref.null":
+		var val WastRefNull
+		err := val.Type.Parse(ps)
+		return val, err
+	case "ref.extern", "ref.host":
+		if result && ps.Empty() {
+			return WastRefExtern{Any: true}, nil
+		}
+		val, err := ps.ExpectUint32()
+		return WastRefExtern{Val: val}, err
+	case "ref.func":
+		if result && ps.Empty() {
+			return WastRefFunc{Any: true}, nil
+		}
+	}
+
+	return nil, ps.UnexpectedPrev("constant")
+}
+
+func parseNanPattern(ps *parser.ParserBuffer, result bool) NanPattern {
+	if !result {
+		return NanNone
+	}
+	token := ps.PeekToken()
+	switch {
+	case matchKeyword(token, "nan:canonical"):
+		_, _ = ps.ExpectKeyword()
+		return NanCanonical
+	case matchKeyword(token, "nan:arithmetic"):
+		_, _ = ps.ExpectKeyword()
+		return NanArithmetic
+	}
+
+	return NanNone
+}
+
+func parseWastV128(ps *parser.ParserBuffer, result bool) (WastValue, error) {
+	shape, err := ps.ExpectKeyword()
+	if err != nil {
+		return nil, err
+	}
+	count, ok := laneCounts[shape]
+	if !ok {
+		return nil, ps.UnexpectedPrev("i8x16", "i16x8", "i32x4", "i64x2", "f32x4", "f64x2")
+	}
+	val := WastV128{Shape: shape}
+	for i := 0; i < count; i++ {
+		var lane WastValue
+		switch shape {
+		case "i8x16":
+			v, e := ps.ExpectI8()
+			lane, err = WastI32{Val: uint32(v)}, e
+		case "i16x8":
+			v, e := ps.ExpectI16()
+			lane, err = WastI32{Val: uint32(v)}, e
+		case "i32x4":
+			v, e := ps.ExpectI32()
+			lane, err = WastI32{Val: v}, e
+		case "i64x2":
+			v, e := ps.ExpectInt64()
+			lane, err = WastI64{Val: uint64(v)}, e
+		case "f32x4":
+			if nan := parseNanPattern(ps, result); nan != NanNone {
+				lane = WastF32{Nan: nan}
+				break
+			}
+			var f Float32
+			err = f.Parse(ps)
+			lane = WastF32{Bits: f.Bits}
+		case "f64x2":
+			if nan := parseNanPattern(ps, result); nan != NanNone {
+				lane = WastF64{Nan: nan}
+				break
+			}
+			var f Float64
+			err = f.Parse(ps)
+			lane = WastF64{Bits: f.Bits}
+		}
+		if err != nil {
+			return nil, err
+		}
+		val.Lanes = append(val.Lanes, lane)
+	}
+
+	return val, nil
+}
+
+func parseWastValues(ps *parser.ParserBuffer, result bool) ([]WastValue, error) {
+	var values []WastValue
+	for !ps.Empty() {
+		err := ps.Parens(func(ps *parser.ParserBuffer) error {
+			val, err := parseWastValue(ps, result)
+			if err != nil {
+				return err
+			}
+			values = append(values, val)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
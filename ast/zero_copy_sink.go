@@ -25,6 +25,30 @@ import (
 
 type ZeroCopySink struct {
 	buf []byte
+	err error
+	// padLEB writes every WriteUint32 in the 5 byte form
+	padLEB bool
+}
+
+// sub returns an empty sink with the settings of self, for nested parts
+// written with their size in front.
+func (self *ZeroCopySink) sub() *ZeroCopySink {
+	sink := NewZeroCopySink(nil)
+	sink.padLEB = self.padLEB
+	return sink
+}
+
+// Fail records an encoding error, only the first one is kept. Encoders keep
+// writing after a failure, callers check Err once the whole value is written.
+func (self *ZeroCopySink) Fail(err error) {
+	if self.err == nil {
+		self.err = err
+	}
+}
+
+// Err returns the first error recorded by Fail.
+func (self *ZeroCopySink) Err() error {
+	return self.err
 }
 
 // tryGrowByReslice is a inlineable version of grow for the fast-case where the
@@ -89,19 +113,34 @@ func (self *ZeroCopySink) WriteUint8(data uint8) {
 	buf[0] = data
 }
 
-func (self *ZeroCopySink) WriteByte(c byte) {
+func (self *ZeroCopySink) WriteByte(c byte) error {
 	self.WriteUint8(c)
+	return nil
 }
 
 func (self *ZeroCopySink) WriteUint32(data uint32) {
+	if self.padLEB {
+		buf := self.NextBytes(5)
+		for i := 0; i < 4; i++ {
+			buf[i] = byte(data>>(7*uint(i)))&0x7f | 0x80
+		}
+		buf[4] = byte(data >> 28)
+		return
+	}
 	var leb []byte
 	leb = AppendUleb128(leb, uint64(data))
 	self.WriteBytes(leb)
 }
 
+func (self *ZeroCopySink) WriteUint64(data uint64) {
+	var leb []byte
+	leb = AppendUleb128(leb, data)
+	self.WriteBytes(leb)
+}
+
 func (self *ZeroCopySink) WriteInt32(data uint32) {
 	var leb []byte
-	leb = AppendSleb128(leb, int64(data))
+	leb = AppendSleb128(leb, int64(int32(data)))
 	self.WriteBytes(leb)
 }
 
@@ -136,12 +175,15 @@ func NewZeroCopySink(b []byte) *ZeroCopySink {
 	if b == nil {
 		b = make([]byte, 0, 512)
 	}
-	return &ZeroCopySink{b}
+	return &ZeroCopySink{buf: b}
 }
 
 func (self *ZeroCopySink) Bytes() []byte { return self.buf }
 
-func (self *ZeroCopySink) Reset() { self.buf = self.buf[:0] }
+func (self *ZeroCopySink) Reset() {
+	self.buf = self.buf[:0]
+	self.err = nil
+}
 
 var ErrTooLarge = errors.New("bytes.Buffer: too large")
 
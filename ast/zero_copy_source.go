@@ -0,0 +1,249 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package ast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unicode/utf8"
+)
+
+// DecodeError is returned by the binary decoder, Offset is the position of
+// the offending byte in the decoded input.
+type DecodeError struct {
+	Offset uint64
+	Msg    string
+}
+
+func (self *DecodeError) Error() string {
+	return fmt.Sprintf("decode error at offset 0x%x: %s", self.Offset, self.Msg)
+}
+
+type ZeroCopySource struct {
+	s    []byte
+	off  uint64
+	base uint64 // offset of s[0] in the whole input
+}
+
+// NewZeroCopySource returns a new ZeroCopySource reading from b.
+func NewZeroCopySource(b []byte) *ZeroCopySource {
+	return &ZeroCopySource{s: b}
+}
+
+func (self *ZeroCopySource) Size() uint64 { return uint64(len(self.s)) }
+
+func (self *ZeroCopySource) Len() uint64 {
+	return self.Size() - self.off
+}
+
+// Pos returns the offset of the next byte to read in the whole input.
+func (self *ZeroCopySource) Pos() uint64 {
+	return self.base + self.off
+}
+
+func (self *ZeroCopySource) Errorf(offset uint64, format string, a ...interface{}) error {
+	return &DecodeError{Offset: offset, Msg: fmt.Sprintf(format, a...)}
+}
+
+// Backs up a number of bytes, so that the next call to ReadXXX() returns data again
+// that was already returned by the last call to ReadXXX().
+func (self *ZeroCopySource) BackUp(n uint64) {
+	if n > self.off {
+		n = self.off
+	}
+	self.off -= n
+}
+
+func (self *ZeroCopySource) NextBytes(n uint64) ([]byte, error) {
+	if n > self.Len() {
+		return nil, self.Errorf(self.Pos(), "unexpected end")
+	}
+	data := self.s[self.off : self.off+n]
+	self.off += n
+	return data, nil
+}
+
+// SubSource splits the next n bytes off as a source of their own, offsets
+// reported by it are still relative to the whole input.
+func (self *ZeroCopySource) SubSource(n uint64) (*ZeroCopySource, error) {
+	base := self.Pos()
+	data, err := self.NextBytes(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ZeroCopySource{s: data, base: base}, nil
+}
+
+func (self *ZeroCopySource) ReadByte() (byte, error) {
+	if self.Len() == 0 {
+		return 0, self.Errorf(self.Pos(), "unexpected end")
+	}
+	b := self.s[self.off]
+	self.off += 1
+	return b, nil
+}
+
+func (self *ZeroCopySource) ReadUint8() (uint8, error) {
+	return self.ReadByte()
+}
+
+func (self *ZeroCopySource) ReadUint32() (uint32, error) {
+	val, err := self.readUleb(32)
+	return uint32(val), err
+}
+
+func (self *ZeroCopySource) ReadUint64() (uint64, error) {
+	return self.readUleb(64)
+}
+
+func (self *ZeroCopySource) ReadInt32() (int32, error) {
+	val, err := self.readSleb(32)
+	return int32(val), err
+}
+
+func (self *ZeroCopySource) ReadInt33() (int64, error) {
+	return self.readSleb(33)
+}
+
+func (self *ZeroCopySource) ReadInt64() (int64, error) {
+	return self.readSleb(64)
+}
+
+func (self *ZeroCopySource) ReadFloat32() (uint32, error) {
+	buf, err := self.NextBytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(buf), nil
+}
+
+func (self *ZeroCopySource) ReadFloat64() (uint64, error) {
+	buf, err := self.NextBytes(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(buf), nil
+}
+
+func (self *ZeroCopySource) ReadVarBytes() ([]byte, error) {
+	n, err := self.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+	if uint64(n) > self.Len() {
+		return nil, self.Errorf(self.Pos(), "length out of bounds")
+	}
+	return self.NextBytes(uint64(n))
+}
+
+func (self *ZeroCopySource) ReadString() (string, error) {
+	pos := self.Pos()
+	data, err := self.ReadVarBytes()
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(data) {
+		return "", self.Errorf(pos, "malformed UTF-8 encoding")
+	}
+	return string(data), nil
+}
+
+// ReadCount reads the length of a vector, making sure the source holds at
+// least one byte per element.
+func (self *ZeroCopySource) ReadCount() (uint32, error) {
+	pos := self.Pos()
+	n, err := self.ReadUint32()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(n) > self.Len() {
+		return 0, self.Errorf(pos, "length out of bounds")
+	}
+	return n, nil
+}
+
+// readReserved consumes count bytes which are reserved to be zero.
+func (self *ZeroCopySource) readReserved(count int) error {
+	for i := 0; i < count; i++ {
+		pos := self.Pos()
+		b, err := self.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != 0 {
+			return self.Errorf(pos, "zero byte expected")
+		}
+	}
+	return nil
+}
+
+func (self *ZeroCopySource) readUleb(bits uint) (uint64, error) {
+	pos := self.Pos()
+	maxBytes := (bits + 6) / 7
+	var result uint64
+	for i := uint(0); ; i++ {
+		b, err := self.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		shift := 7 * i
+		if i == maxBytes-1 {
+			if b&0x80 != 0 {
+				return 0, self.Errorf(pos, "integer representation too long")
+			}
+			if uint64(b)>>(bits-shift) != 0 {
+				return 0, self.Errorf(pos, "integer too large")
+			}
+		}
+		result |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return result, nil
+		}
+	}
+}
+
+func (self *ZeroCopySource) readSleb(bits uint) (int64, error) {
+	pos := self.Pos()
+	maxBytes := (bits + 6) / 7
+	var result int64
+	for i := uint(0); ; i++ {
+		b, err := self.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		shift := 7 * i
+		if i == maxBytes-1 {
+			if b&0x80 != 0 {
+				return 0, self.Errorf(pos, "integer representation too long")
+			}
+			// the unused high bits must be a sign extension of the last used one
+			payload := int64(b&0x7f) << 57 >> 57
+			if rest := payload >> (bits - shift - 1); rest != 0 && rest != -1 {
+				return 0, self.Errorf(pos, "integer too large")
+			}
+		}
+		result |= int64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			if shift+7 < 64 && b&0x40 != 0 {
+				result |= -1 << (shift + 7)
+			}
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Command spectest runs .wast spec test scripts with the interpreter and
+// reports the result of every file, directories are searched for scripts.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ontio/wast-parser/spectest"
+)
+
+func main() {
+	verbose := flag.Bool("v", false, "show the output of the spectest print functions")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: spectest [-v] file.wast|dir ...")
+		os.Exit(2)
+	}
+
+	runner := &spectest.Runner{Out: ioutil.Discard}
+	if *verbose {
+		runner.Out = os.Stdout
+	}
+	ok := true
+	for _, arg := range flag.Args() {
+		files, err := scripts(arg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", arg, err)
+			os.Exit(1)
+		}
+		for _, file := range files {
+			result, err := runner.RunFile(file)
+			if err != nil {
+				fmt.Printf("FAIL %s\n", err)
+				ok = false
+				continue
+			}
+			fmt.Println(result)
+			ok = ok && result.Ok()
+		}
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// scripts lists the .wast files of path when it is a directory.
+func scripts(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	return filepath.Glob(filepath.Join(path, "*.wast"))
+}
@@ -0,0 +1,54 @@
+// Command wast2json converts a .wast spec test script to a JSON manifest and
+// the module files it refers to, like the tool of the reference interpreter.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/ontio/wast-parser/ast"
+	"github.com/ontio/wast-parser/parser"
+	"github.com/ontio/wast-parser/wast2json"
+)
+
+func main() {
+	out := flag.String("o", ".", "output directory")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: wast2json [-o dir] file.wast")
+		os.Exit(2)
+	}
+
+	err := convert(flag.Arg(0), *out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", flag.Arg(0), err)
+		os.Exit(1)
+	}
+}
+
+func convert(source, out string) error {
+	raw, err := ioutil.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	ps, err := parser.NewParserBuffer(string(raw))
+	if err != nil {
+		return err
+	}
+	var wast ast.Wast
+	err = wast.Parse(ps)
+	if err != nil {
+		return err
+	}
+	script, err := wast2json.Convert(&wast, source)
+	if err != nil {
+		return err
+	}
+	for _, warning := range script.Warnings {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", source, warning)
+	}
+
+	return script.WriteDir(out)
+}
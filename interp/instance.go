@@ -0,0 +1,376 @@
+// Package interp is a reference interpreter executing resolved modules. It
+// covers the MVP along with sign extension, saturating truncation,
+// multi-value, bulk memory, reference types and tail calls, other proposals
+// are rejected at instantiation.
+package interp
+
+import (
+	"fmt"
+
+	"github.com/ontio/wast-parser/ast"
+)
+
+// Imports provides the items a module imports by module and field name.
+type Imports map[string]map[string]Extern
+
+// LinkError reports an import which can not be satisfied, Msg is worded
+// like the messages of the reference interpreter so `assert_unlinkable` can
+// match on it.
+type LinkError struct {
+	Module string
+	Field  string
+	Msg    string
+}
+
+func (self *LinkError) Error() string {
+	return fmt.Sprintf("%s: import %q %q", self.Msg, self.Module, self.Field)
+}
+
+// Instance is an instantiated module.
+type Instance struct {
+	types    []ast.FunctionType
+	funcs    []*Function
+	tables   []*Table
+	memories []*Memory
+	globals  []*Global
+	elems    [][]interface{}
+	datas    [][]byte
+	exports  map[string]Extern
+}
+
+// Instantiate validates module, links its imports, initializes its tables
+// and memories with the active segments and runs its start function. A
+// failure of validation returns the *ast.ValidationError, a missing or
+// mismatched import a *LinkError and a table or memory too large to allocate
+// or a trap during initialization a *Trap.
+func Instantiate(module *ast.Module, imports Imports) (*Instance, error) {
+	if bin, ok := module.Kind.(ast.ModuleKindBinary); ok {
+		var data []byte
+		for _, b := range bin.Bins {
+			data = append(data, b...)
+		}
+		decoded, err := ast.DecodeModule(data)
+		if err != nil {
+			return nil, err
+		}
+		module = decoded
+	}
+	if errs := ast.Validate(module); len(errs) != 0 {
+		return nil, errs[0]
+	}
+
+	inst := &Instance{exports: make(map[string]Extern)}
+	// a module without a kind is empty, as for ast.Validate
+	text, _ := module.Kind.(ast.ModuleKindText)
+	fields := text.Fields
+	// implicit types follow the other fields
+	for _, field := range fields {
+		if ty, ok := field.(ast.Type); ok {
+			inst.types = append(inst.types, ty.Func)
+		}
+	}
+
+	err := inst.allocate(fields, imports)
+	if err != nil {
+		return nil, err
+	}
+	err = inst.initialize(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return inst, nil
+}
+
+func (self *Instance) allocate(fields []ast.ModuleField, imports Imports) error {
+	for _, field := range fields {
+		switch val := field.(type) {
+		case ast.Import:
+			err := self.link(val, imports[val.Module][val.Field])
+			if err != nil {
+				return err
+			}
+		case ast.Func:
+			ty := self.types[val.Type.Index.ToIndex().Num]
+			fn := &Function{Type: ty, id: nextFuncId(), inst: self}
+			self.funcs = append(self.funcs, fn)
+		case ast.Table:
+			normal, ok := val.Kind.(ast.TableKindNormal)
+			if !ok {
+				return fmt.Errorf("unexpanded table")
+			}
+			table, err := NewTable(normal.Type)
+			if err != nil {
+				return err
+			}
+			self.tables = append(self.tables, table)
+		case ast.Memory:
+			normal, ok := val.Kind.(*ast.MemoryKindNormal)
+			if !ok {
+				return fmt.Errorf("unexpanded memory")
+			}
+			if normal.Type.Shared {
+				return fmt.Errorf("shared memory is not supported")
+			}
+			mem, err := NewMemory(normal.Type)
+			if err != nil {
+				return err
+			}
+			self.memories = append(self.memories, mem)
+		case ast.Tag:
+			return fmt.Errorf("tags are not supported")
+		}
+	}
+
+	// initializers may take a reference to any function, so the globals
+	// follow once all the functions exist. They only read the globals
+	// defined before.
+	for _, field := range fields {
+		global, ok := field.(ast.Global)
+		if !ok {
+			continue
+		}
+		inline, ok := global.Kind.(ast.GlobalKindInline)
+		if !ok {
+			return fmt.Errorf("unexpanded global")
+		}
+		self.globals = append(self.globals, &Global{Type: global.ValType, val: self.evalConst(inline.Expr)})
+	}
+
+	// functions refer to each other, so the code is compiled once all of
+	// them exist
+	var index int
+	for _, field := range fields {
+		fun, ok := field.(ast.Func)
+		if !ok {
+			continue
+		}
+		for self.funcs[index].inst != self {
+			index += 1
+		}
+		inline, ok := fun.Kind.(ast.FuncKindInline)
+		if !ok {
+			return fmt.Errorf("unexpanded func")
+		}
+		code, err := self.compile(inline)
+		if err != nil {
+			return fmt.Errorf("func %d: %s", index, err)
+		}
+		self.funcs[index].code = code
+		index += 1
+	}
+
+	for _, field := range fields {
+		if export, ok := field.(ast.Export); ok {
+			self.exports[export.Name] = self.export(export)
+		}
+	}
+
+	return nil
+}
+
+// link adds an imported item to its index space.
+func (self *Instance) link(imp ast.Import, item Extern) error {
+	if item == nil {
+		return &LinkError{Module: imp.Module, Field: imp.Field, Msg: "unknown import"}
+	}
+	incompatible := &LinkError{Module: imp.Module, Field: imp.Field, Msg: "incompatible import type"}
+	switch ty := imp.Item.(type) {
+	case ast.ImportFunc:
+		fn, ok := item.(*Function)
+		if !ok || !sameFuncType(fn.Type, self.types[ty.TypeUse.Index.ToIndex().Num]) {
+			return incompatible
+		}
+		self.funcs = append(self.funcs, fn)
+	case ast.ImportTable:
+		table, ok := item.(*Table)
+		if !ok || table.Elem != ty.Table.Elem ||
+			!matchLimits(uint64(table.Size()), uint64(table.Max), table.HasMax, ty.Table.Limits) {
+			return incompatible
+		}
+		self.tables = append(self.tables, table)
+	case ast.ImportMemory:
+		mem, ok := item.(*Memory)
+		if !ok || ty.Mem.Shared || ty.Mem.Is64 ||
+			!matchLimits(uint64(mem.Size()), uint64(mem.Max), mem.HasMax, ty.Mem.Limits) {
+			return incompatible
+		}
+		self.memories = append(self.memories, mem)
+	case ast.ImportGlobal:
+		global, ok := item.(*Global)
+		if !ok || global.Type != ty.Global {
+			return incompatible
+		}
+		self.globals = append(self.globals, global)
+	default:
+		return incompatible
+	}
+
+	return nil
+}
+
+// matchLimits reports whether an item of the current size and maximum may
+// be imported as one of type limits.
+func matchLimits(size, max uint64, hasMax bool, limits ast.Limits) bool {
+	if size < limits.Min {
+		return false
+	}
+	if limits.HasMax {
+		return hasMax && max <= limits.Max
+	}
+
+	return true
+}
+
+func (self *Instance) export(export ast.Export) Extern {
+	index := export.Index.Num
+	switch export.Type {
+	case ast.ExportFunc:
+		return self.funcs[index]
+	case ast.ExportTable:
+		return self.tables[index]
+	case ast.ExportMemory:
+		return self.memories[index]
+	case ast.ExportGlobal:
+		return self.globals[index]
+	}
+
+	return nil
+}
+
+// initialize applies the active segments, elements first, and runs the
+// start function. The segments applied before a trap stay in place.
+func (self *Instance) initialize(fields []ast.ModuleField) (err error) {
+	defer recoverError(&err)
+
+	var start *Function
+	for _, field := range fields {
+		switch val := field.(type) {
+		case ast.Elem:
+			self.elems = append(self.elems, self.elemRefs(val.Payload))
+		case ast.Data:
+			var data []byte
+			for _, b := range val.Val {
+				data = append(data, b...)
+			}
+			self.datas = append(self.datas, data)
+		case ast.StartField:
+			start = self.funcs[val.Index.Num]
+		}
+	}
+
+	var elems, datas uint32
+	for _, field := range fields {
+		switch val := field.(type) {
+		case ast.Elem:
+			switch kind := val.Kind.(type) {
+			case ast.ElemKindActive:
+				offset := self.evalConst(kind.Offset)
+				self.tableInit(self.tables[kind.Table.Num], elems, offset.bits, 0, uint64(len(self.elems[elems])))
+				self.elems[elems] = nil
+			case ast.ElemKindDeclared:
+				self.elems[elems] = nil
+			}
+			elems += 1
+		}
+	}
+	for _, field := range fields {
+		switch val := field.(type) {
+		case ast.Data:
+			if kind, ok := val.Kind.(ast.DataKindActive); ok {
+				offset := self.evalConst(kind.Offset)
+				self.memoryInit(self.memories[kind.Memory.Num], datas, offset.bits, 0, uint64(len(self.datas[datas])))
+				self.datas[datas] = nil
+			}
+			datas += 1
+		}
+	}
+
+	if start != nil {
+		m := &machine{}
+		m.call(start, nil)
+	}
+
+	return nil
+}
+
+func (self *Instance) elemRefs(payload ast.ElemPayload) []interface{} {
+	var refs []interface{}
+	switch val := payload.(type) {
+	case ast.ElemPayloadIndices:
+		for _, index := range val.Indices {
+			refs = append(refs, self.funcs[index.Num])
+		}
+	case ast.ElemPayloadExprs:
+		for _, expr := range val.Exprs {
+			if expr.IsSome() {
+				refs = append(refs, self.funcs[expr.ToIndex().Num])
+			} else {
+				refs = append(refs, nil)
+			}
+		}
+	}
+
+	return refs
+}
+
+// evalConst computes an initializer, validation leaves only constants and
+// reads of globals.
+func (self *Instance) evalConst(expr ast.Expression) value {
+	var val value
+	for _, instr := range expr.Instrs {
+		switch inst := instr.(type) {
+		case *ast.I32Const:
+			val = value{bits: uint64(inst.Val)}
+		case *ast.I64Const:
+			val = value{bits: uint64(inst.Val)}
+		case *ast.F32Const:
+			val = value{bits: uint64(inst.Val.Bits)}
+		case *ast.F64Const:
+			val = value{bits: inst.Val.Bits}
+		case *ast.RefNull:
+			val = value{}
+		case *ast.RefFunc:
+			val = value{ref: self.funcs[inst.Index.Num]}
+		case *ast.GlobalGet:
+			val = self.globals[inst.Index.Num].val
+		}
+	}
+
+	return val
+}
+
+// Exports returns the exported items by name, the imports of another module
+// may refer to them.
+func (self *Instance) Exports() map[string]Extern {
+	return self.exports
+}
+
+// Memory returns a memory by index, nil if there is none.
+func (self *Instance) Memory(index uint32) *Memory {
+	if int(index) >= len(self.memories) {
+		return nil
+	}
+
+	return self.memories[index]
+}
+
+// Invoke calls the exported function name.
+func (self *Instance) Invoke(name string, args ...ast.WastValue) ([]ast.WastValue, error) {
+	fn, ok := self.exports[name].(*Function)
+	if !ok {
+		return nil, fmt.Errorf("unknown function export %q", name)
+	}
+
+	return fn.Call(args...)
+}
+
+// GetGlobal reads the exported global name.
+func (self *Instance) GetGlobal(name string) (ast.WastValue, error) {
+	global, ok := self.exports[name].(*Global)
+	if !ok {
+		return nil, fmt.Errorf("unknown global export %q", name)
+	}
+
+	return global.Get(), nil
+}
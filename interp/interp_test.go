@@ -0,0 +1,195 @@
+package interp
+
+import (
+	"testing"
+
+	"github.com/ontio/wast-parser/ast"
+	"github.com/ontio/wast-parser/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func instantiate(t *testing.T, source string, imports Imports) (*Instance, error) {
+	ps, err := parser.NewParserBuffer(source)
+	assert.Nil(t, err)
+	var wat ast.Wat
+	assert.Nil(t, wat.Parse(ps))
+	return Instantiate(&wat.Module, imports)
+}
+
+func TestExecute(t *testing.T) {
+	inst, err := instantiate(t, `(module
+  (memory (export "mem") 1)
+  (table 2 funcref)
+  (elem (i32.const 0) $fac $div)
+  (global $count (export "count") (mut i32) (i32.const 0))
+  (data (i32.const 8) "\2a\00\00\00")
+  (func $fac (export "fac") (param i64) (result i64)
+    (global.set $count (i32.add (global.get $count) (i32.const 1)))
+    (if (result i64) (i64.eqz (local.get 0))
+      (then (i64.const 1))
+      (else (i64.mul (local.get 0) (call $fac (i64.sub (local.get 0) (i64.const 1)))))))
+  (func $div (export "div") (param i32 i32) (result i32)
+    (i32.div_s (local.get 0) (local.get 1)))
+  (func (export "sum") (param i32) (result i32) (local i32)
+    (block $done
+      (loop $next
+        (br_if $done (i32.eqz (local.get 0)))
+        (local.set 1 (i32.add (local.get 1) (local.get 0)))
+        (local.set 0 (i32.sub (local.get 0) (i32.const 1)))
+        (br $next)))
+    (local.get 1))
+  (func (export "load") (param i32) (result i32)
+    (i32.load offset=8 (local.get 0)))
+  (func (export "call") (param i32) (result i32)
+    (call_indirect (param i32 i32) (result i32) (i32.const 7) (i32.const 2) (local.get 0)))
+  (func (export "trunc") (param f32) (result i32)
+    (i32.trunc_f32_s (local.get 0)))
+  (func (export "swap") (param i32 i64) (result i64 i32)
+    (local.get 1) (local.get 0))
+  (func (export "loop") (call 7))
+  (func (call 7)))`, nil)
+	assert.Nil(t, err)
+
+	results, err := inst.Invoke("fac", ast.WastI64{Val: 20})
+	assert.Nil(t, err)
+	assert.Equal(t, []ast.WastValue{ast.WastI64{Val: 2432902008176640000}}, results)
+	count, err := inst.GetGlobal("count")
+	assert.Nil(t, err)
+	assert.Equal(t, ast.WastI32{Val: 21}, count)
+
+	results, err = inst.Invoke("sum", ast.WastI32{Val: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, []ast.WastValue{ast.WastI32{Val: 5050}}, results)
+	results, err = inst.Invoke("load", ast.WastI32{Val: 0})
+	assert.Nil(t, err)
+	assert.Equal(t, []ast.WastValue{ast.WastI32{Val: 42}}, results)
+	results, err = inst.Invoke("call", ast.WastI32{Val: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, []ast.WastValue{ast.WastI32{Val: 3}}, results)
+	results, err = inst.Invoke("swap", ast.WastI32{Val: 1}, ast.WastI64{Val: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, []ast.WastValue{ast.WastI64{Val: 2}, ast.WastI32{Val: 1}}, results)
+
+	for _, trap := range []struct {
+		name string
+		args []ast.WastValue
+		msg  string
+	}{
+		{"div", []ast.WastValue{ast.WastI32{Val: 1}, ast.WastI32{Val: 0}}, "integer divide by zero"},
+		{"div", []ast.WastValue{ast.WastI32{Val: 0x80000000}, ast.WastI32{Val: 0xffffffff}}, "integer overflow"},
+		{"load", []ast.WastValue{ast.WastI32{Val: 65530}}, "out of bounds memory access"},
+		{"call", []ast.WastValue{ast.WastI32{Val: 0}}, "indirect call type mismatch"},
+		{"call", []ast.WastValue{ast.WastI32{Val: 2}}, "undefined element"},
+		{"trunc", []ast.WastValue{ast.WastF32{Bits: 0x7fc00000}}, "invalid conversion to integer"},
+		{"trunc", []ast.WastValue{ast.WastF32{Bits: 0x4f000000}}, "integer overflow"},
+		{"loop", nil, "call stack exhausted"},
+	} {
+		_, err := inst.Invoke(trap.name, trap.args...)
+		assert.Equal(t, &Trap{Msg: trap.msg}, err, trap.name)
+	}
+
+	_, err = inst.Invoke("div", ast.WastI32{Val: 1})
+	assert.EqualError(t, err, "wrong number of arguments: expected 2, found 1")
+}
+
+func TestTailCalls(t *testing.T) {
+	inst, err := instantiate(t, `(module
+  (table 1 funcref)
+  (elem (i32.const 0) $even)
+  (func $even (export "even") (param i64) (result i32)
+    (if (result i32) (i64.eqz (local.get 0))
+      (then (i32.const 1))
+      (else (return_call $odd (i64.sub (local.get 0) (i64.const 1))))))
+  (func $odd (param i64) (result i32)
+    (if (result i32) (i64.eqz (local.get 0))
+      (then (i32.const 0))
+      (else
+        ;; left below the arguments, the tail call drops it
+        (i32.const 9)
+        (return_call_indirect (param i64) (result i32)
+          (i64.sub (local.get 0) (i64.const 1)) (i32.const 0))))))`, nil)
+	assert.Nil(t, err)
+
+	// far deeper than maxCallDepth, the frames are replaced
+	results, err := inst.Invoke("even", ast.WastI64{Val: 1000001})
+	assert.Nil(t, err)
+	assert.Equal(t, []ast.WastValue{ast.WastI32{Val: 0}}, results)
+	results, err = inst.Invoke("even", ast.WastI64{Val: 1000000})
+	assert.Nil(t, err)
+	assert.Equal(t, []ast.WastValue{ast.WastI32{Val: 1}}, results)
+}
+
+func TestGlobalRefFunc(t *testing.T) {
+	// the initializer refers to a function defined after the global
+	inst, err := instantiate(t, `(module
+  (global $g funcref (ref.func $f))
+  (type $t (func (result i32)))
+  (table 1 funcref)
+  (func (export "call") (result i32)
+    (table.set (i32.const 0) (global.get $g))
+    (call_indirect (type $t) (i32.const 0)))
+  (func $f (result i32) (i32.const 7)))`, nil)
+	assert.Nil(t, err)
+
+	results, err := inst.Invoke("call")
+	assert.Nil(t, err)
+	assert.Equal(t, []ast.WastValue{ast.WastI32{Val: 7}}, results)
+}
+
+func TestInstantiateEmpty(t *testing.T) {
+	inst, err := Instantiate(&ast.Module{}, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, inst.Exports())
+}
+
+func TestAllocationLimits(t *testing.T) {
+	_, err := instantiate(t, `(module (table 0xffffffff funcref))`, nil)
+	assert.Equal(t, &Trap{Msg: "out of memory"}, err)
+	_, err = instantiate(t, `(module (memory 65536))`, nil)
+	assert.Equal(t, &Trap{Msg: "out of memory"}, err)
+}
+
+func TestHostImports(t *testing.T) {
+	var logged []ast.WastValue
+	log := NewHostFunction(ast.FunctionType{Params: []ast.FuncParam{{Val: ast.I32}, {Val: ast.I32}}},
+		func(caller *Instance, args []ast.WastValue) ([]ast.WastValue, error) {
+			ptr, size := args[0].(ast.WastI32).Val, args[1].(ast.WastI32).Val
+			logged = append(logged, args...)
+			mem := caller.Memory(0)
+			assert.Equal(t, "hello", string(mem.Data[ptr:ptr+size]))
+			return nil, nil
+		})
+	height, err := NewGlobal(ast.GlobalValType{Type: ast.I64}, ast.WastI64{Val: 99})
+	assert.Nil(t, err)
+	imports := Imports{"env": {"log": log, "height": height}}
+
+	source := `(module
+  (import "env" "log" (func $log (param i32 i32)))
+  (import "env" "height" (global $height i64))
+  (memory 1)
+  (data (i32.const 16) "hello")
+  (func (export "run") (result i64)
+    (call $log (i32.const 16) (i32.const 5))
+    (global.get $height)))`
+	inst, err := instantiate(t, source, imports)
+	assert.Nil(t, err)
+	results, err := inst.Invoke("run")
+	assert.Nil(t, err)
+	assert.Equal(t, []ast.WastValue{ast.WastI64{Val: 99}}, results)
+	assert.Equal(t, []ast.WastValue{ast.WastI32{Val: 16}, ast.WastI32{Val: 5}}, logged)
+
+	_, err = instantiate(t, source, Imports{"env": {"log": log}})
+	assert.EqualError(t, err, `unknown import: import "env" "height"`)
+	_, err = instantiate(t, source, Imports{"env": {"log": height, "height": height}})
+	assert.EqualError(t, err, `incompatible import type: import "env" "log"`)
+
+	// the active segments applied before a trap stay in place
+	mem, err := NewMemory(ast.MemoryType{Limits: ast.Limits{Min: 1}})
+	assert.Nil(t, err)
+	_, err = instantiate(t, `(module
+  (import "env" "mem" (memory 1))
+  (data (i32.const 0) "ok")
+  (data (i32.const 65535) "oops"))`, Imports{"env": {"mem": mem}})
+	assert.Equal(t, &Trap{Msg: "out of bounds memory access"}, err)
+	assert.Equal(t, "ok", string(mem.Data[:2]))
+}
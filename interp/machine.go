@@ -0,0 +1,513 @@
+package interp
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ontio/wast-parser/ast"
+)
+
+// maxCallDepth is the depth of nested calls after which the execution traps
+// with "call stack exhausted".
+const maxCallDepth = 10000
+
+// code is a function body prepared for execution. The body is flat, ops
+// holds for each instruction the targets of its block and the operator it
+// runs.
+type code struct {
+	locals int
+	instrs []ast.Instruction
+	ops    []op
+}
+
+// op completes an instruction: a block, loop or if knows its matching end
+// and the arity of its type, an if also its else and an else its end. Fn
+// runs the instructions without immediates.
+type op struct {
+	end     int
+	els     int
+	params  int
+	results int
+	fn      func(m *machine)
+}
+
+func (self *Instance) compile(fun ast.FuncKindInline) (*code, error) {
+	instrs := fun.Expr.Instrs
+	result := &code{locals: len(fun.Locals), instrs: instrs, ops: make([]op, len(instrs))}
+	var blocks []int
+	for pc, instr := range instrs {
+		switch inst := instr.(type) {
+		case *ast.Block, *ast.Loop, *ast.If:
+			var ty ast.BlockType
+			switch inst := inst.(type) {
+			case *ast.Block:
+				ty = inst.BlockType
+			case *ast.Loop:
+				ty = inst.BlockType
+			case *ast.If:
+				ty = inst.BlockType
+			}
+			fn := ty.Ty.Type
+			if ty.Ty.Index.IsSome() {
+				fn = self.types[ty.Ty.Index.ToIndex().Num]
+			}
+			result.ops[pc].params = len(fn.Params)
+			result.ops[pc].results = len(fn.Results)
+			blocks = append(blocks, pc)
+		case *ast.Else:
+			// an else never comes first, so 0 means none
+			result.ops[blocks[len(blocks)-1]].els = pc
+		case *ast.End:
+			open := blocks[len(blocks)-1]
+			blocks = blocks[:len(blocks)-1]
+			result.ops[open].end = pc
+			if els := result.ops[open].els; els != 0 {
+				result.ops[els].end = pc
+			}
+		case *ast.Unreachable, *ast.Nop, *ast.Br, *ast.BrIf, *ast.BrTable, *ast.Return,
+			*ast.Call, *ast.CallIndirect, *ast.ReturnCall, *ast.ReturnCallIndirect,
+			*ast.Drop, *ast.Select, *ast.LocalGet, *ast.LocalSet, *ast.LocalTee,
+			*ast.GlobalGet, *ast.GlobalSet, *ast.TableGet, *ast.TableSet, *ast.TableSize,
+			*ast.TableGrow, *ast.TableFill, *ast.TableCopy, *ast.TableInit, *ast.ElemDrop,
+			*ast.MemorySize, *ast.MemoryGrow, *ast.MemoryFill, *ast.MemoryCopy,
+			*ast.MemoryInit, *ast.DataDrop, *ast.RefNull, *ast.RefIsNull, *ast.RefFunc,
+			*ast.RefHost, *ast.I32Const, *ast.I64Const, *ast.F32Const, *ast.F64Const,
+			*ast.I32Load, *ast.I64Load, *ast.F32Load, *ast.F64Load, *ast.I32Load8s,
+			*ast.I32Load8u, *ast.I32Load16s, *ast.I32Load16u, *ast.I64Load8s, *ast.I64Load8u,
+			*ast.I64Load16s, *ast.I64Load16u, *ast.I64Load32s, *ast.I64Load32u,
+			*ast.I32Store, *ast.I64Store, *ast.F32Store, *ast.F64Store, *ast.I32Store8,
+			*ast.I32Store16, *ast.I64Store8, *ast.I64Store16, *ast.I64Store32:
+		default:
+			fn, ok := operators[instr.String()]
+			if !ok {
+				return nil, fmt.Errorf("unsupported instruction %s", instr.String())
+			}
+			result.ops[pc].fn = fn
+		}
+	}
+
+	return result, nil
+}
+
+// machine holds the operand stack shared by the nested calls of one
+// execution.
+type machine struct {
+	stack []value
+	depth int
+}
+
+// label is the target of a branch: the stack is cut to height plus the arity
+// values carried over and execution goes on after cont.
+type label struct {
+	height int
+	arity  int
+	cont   int
+	loop   bool
+}
+
+// call pops the arguments of fn and pushes its results, caller is the
+// instance making the call. A tail call made by fn replaces its frame, the
+// callee runs in the same iteration at the same depth.
+func (self *machine) call(fn *Function, caller *Instance) {
+	for fn != nil {
+		params := len(fn.Type.Params)
+		args := self.stack[len(self.stack)-params:]
+		if fn.host != nil {
+			results, err := fn.host(caller, toWasts(paramTypes(fn.Type), args))
+			if err != nil {
+				panic(hostError{err: err})
+			}
+			vals, err := fromWasts(fn.Type.Results, results)
+			if err != nil {
+				panic(hostError{err: fmt.Errorf("host function results: %s", err)})
+			}
+			self.stack = append(self.stack[:len(self.stack)-params], vals...)
+			return
+		}
+
+		if self.depth >= maxCallDepth {
+			trap(trapExhaustion)
+		}
+		locals := make([]value, params+fn.code.locals)
+		copy(locals, args)
+		self.stack = self.stack[:len(self.stack)-params]
+		self.depth += 1
+		next := self.exec(fn, locals)
+		self.depth -= 1
+		fn, caller = next, fn.inst
+	}
+}
+
+// tailCall leaves only the arguments of fn on the stack of the frame
+// starting at height and returns fn for the caller of the frame to run.
+func (self *machine) tailCall(height int, fn *Function) *Function {
+	params := len(fn.Type.Params)
+	copy(self.stack[height:], self.stack[len(self.stack)-params:])
+	self.stack = self.stack[:height+params]
+
+	return fn
+}
+
+// branch unwinds to the label depth levels out and returns the instruction
+// before the one to go on with.
+func (self *machine) branch(labels *[]label, depth int) int {
+	ls := *labels
+	target := ls[len(ls)-1-depth]
+	copy(self.stack[target.height:], self.stack[len(self.stack)-target.arity:])
+	self.stack = self.stack[:target.height+target.arity]
+	if target.loop {
+		*labels = ls[:len(ls)-depth]
+	} else {
+		*labels = ls[:len(ls)-1-depth]
+	}
+
+	return target.cont
+}
+
+// exec runs the body of fn, it returns the function of a tail call ending the
+// body, nil if the body returned.
+func (self *machine) exec(fn *Function, locals []value) *Function {
+	inst, code := fn.inst, fn.code
+	instrs := code.instrs
+	// the body is a block ending after the last instruction
+	labels := []label{{height: len(self.stack), arity: len(fn.Type.Results), cont: len(instrs)}}
+	for pc := 0; pc < len(instrs); pc++ {
+		op := &code.ops[pc]
+		switch in := instrs[pc].(type) {
+		case *ast.Unreachable:
+			trap(trapUnreachable)
+		case *ast.Nop:
+		case *ast.Block:
+			labels = append(labels, label{height: len(self.stack) - op.params, arity: op.results, cont: op.end})
+		case *ast.Loop:
+			labels = append(labels, label{height: len(self.stack) - op.params, arity: op.params, cont: pc, loop: true})
+		case *ast.If:
+			cond := self.popI32()
+			block := label{height: len(self.stack) - op.params, arity: op.results, cont: op.end}
+			switch {
+			case cond != 0:
+				labels = append(labels, block)
+			case op.els != 0:
+				labels = append(labels, block)
+				pc = op.els
+			default:
+				pc = op.end
+			}
+		case *ast.Else:
+			labels = labels[:len(labels)-1]
+			pc = op.end
+		case *ast.End:
+			labels = labels[:len(labels)-1]
+		case *ast.Br:
+			pc = self.branch(&labels, int(in.Index.Num))
+		case *ast.BrIf:
+			if self.popI32() != 0 {
+				pc = self.branch(&labels, int(in.Index.Num))
+			}
+		case *ast.BrTable:
+			index := self.popI32()
+			target := in.Indices.Default
+			if index < uint32(len(in.Indices.Labels)) {
+				target = in.Indices.Labels[index]
+			}
+			pc = self.branch(&labels, int(target.Num))
+		case *ast.Return:
+			pc = self.branch(&labels, len(labels)-1)
+		case *ast.Call:
+			self.call(inst.funcs[in.Index.Num], inst)
+		case *ast.ReturnCall:
+			return self.tailCall(labels[0].height, inst.funcs[in.Index.Num])
+		case *ast.CallIndirect:
+			self.call(self.indirect(inst, in.Impl), inst)
+		case *ast.ReturnCallIndirect:
+			return self.tailCall(labels[0].height, self.indirect(inst, in.Impl))
+		case *ast.Drop:
+			self.pop()
+		case *ast.Select:
+			cond := self.popI32()
+			second := self.pop()
+			first := self.pop()
+			if cond == 0 {
+				first = second
+			}
+			self.push(first)
+		case *ast.LocalGet:
+			self.push(locals[in.Index.Num])
+		case *ast.LocalSet:
+			locals[in.Index.Num] = self.pop()
+		case *ast.LocalTee:
+			locals[in.Index.Num] = self.stack[len(self.stack)-1]
+		case *ast.GlobalGet:
+			self.push(inst.globals[in.Index.Num].val)
+		case *ast.GlobalSet:
+			inst.globals[in.Index.Num].val = self.pop()
+		case *ast.TableGet:
+			table := inst.tables[in.Index.Num]
+			index := self.popI32()
+			if index >= table.Size() {
+				trap(trapTableBounds)
+			}
+			self.push(value{ref: table.elems[index]})
+		case *ast.TableSet:
+			table := inst.tables[in.Index.Num]
+			val := self.pop()
+			index := self.popI32()
+			if index >= table.Size() {
+				trap(trapTableBounds)
+			}
+			table.elems[index] = val.ref
+		case *ast.TableSize:
+			self.pushI32(inst.tables[in.Index.Num].Size())
+		case *ast.TableGrow:
+			delta := self.popI32()
+			init := self.pop()
+			old, ok := inst.tables[in.Index.Num].grow(delta, init.ref)
+			if !ok {
+				old = 0xffffffff
+			}
+			self.pushI32(old)
+		case *ast.TableFill:
+			table := inst.tables[in.Index.Num]
+			n := uint64(self.popI32())
+			val := self.pop()
+			dst := uint64(self.popI32())
+			if !inBounds(dst, n, uint64(table.Size())) {
+				trap(trapTableBounds)
+			}
+			for i := dst; i < dst+n; i++ {
+				table.elems[i] = val.ref
+			}
+		case *ast.TableCopy:
+			n := uint64(self.popI32())
+			src := uint64(self.popI32())
+			dst := uint64(self.popI32())
+			dstTable, srcTable := inst.tables[in.Impl.Dst.Num], inst.tables[in.Impl.Src.Num]
+			if !inBounds(src, n, uint64(srcTable.Size())) || !inBounds(dst, n, uint64(dstTable.Size())) {
+				trap(trapTableBounds)
+			}
+			copy(dstTable.elems[dst:dst+n], srcTable.elems[src:src+n])
+		case *ast.TableInit:
+			n := uint64(self.popI32())
+			src := uint64(self.popI32())
+			dst := uint64(self.popI32())
+			inst.tableInit(inst.tables[in.Impl.Table.Num], in.Impl.Elem.Num, dst, src, n)
+		case *ast.ElemDrop:
+			inst.elems[in.Index.Num] = nil
+		case *ast.MemorySize:
+			self.push(value{bits: uint64(inst.memories[in.Mem.Num].Size())})
+		case *ast.MemoryGrow:
+			mem := inst.memories[in.Mem.Num]
+			delta := self.pop().bits
+			old, ok := uint32(0), false
+			if delta <= maxMemoryPages {
+				old, ok = mem.Grow(uint32(delta))
+			}
+			switch {
+			case ok:
+				self.push(value{bits: uint64(old)})
+			case mem.is64:
+				self.push(value{bits: ^uint64(0)})
+			default:
+				self.pushI32(0xffffffff)
+			}
+		case *ast.MemoryFill:
+			mem := inst.memories[in.Mem.Num]
+			n := self.pop().bits
+			val := byte(self.popI32())
+			dst := self.pop().bits
+			if !inBounds(dst, n, uint64(len(mem.Data))) {
+				trap(trapMemoryBounds)
+			}
+			for i := dst; i < dst+n; i++ {
+				mem.Data[i] = val
+			}
+		case *ast.MemoryCopy:
+			n := self.pop().bits
+			src := self.pop().bits
+			dst := self.pop().bits
+			dstMem, srcMem := inst.memories[in.Impl.Dst.Num], inst.memories[in.Impl.Src.Num]
+			if !inBounds(src, n, uint64(len(srcMem.Data))) || !inBounds(dst, n, uint64(len(dstMem.Data))) {
+				trap(trapMemoryBounds)
+			}
+			copy(dstMem.Data[dst:dst+n], srcMem.Data[src:src+n])
+		case *ast.MemoryInit:
+			n := uint64(self.popI32())
+			src := uint64(self.popI32())
+			dst := self.pop().bits
+			inst.memoryInit(inst.memories[in.Impl.Memory.Num], in.Impl.Data.Num, dst, src, n)
+		case *ast.DataDrop:
+			inst.datas[in.Index.Num] = nil
+		case *ast.RefNull:
+			self.push(value{})
+		case *ast.RefIsNull:
+			self.pushBool(self.pop().ref == nil)
+		case *ast.RefFunc:
+			self.push(value{ref: inst.funcs[in.Index.Num]})
+		case *ast.RefHost:
+			self.push(value{ref: externRef(in.Val)})
+		case *ast.I32Const:
+			self.pushI32(in.Val)
+		case *ast.I64Const:
+			self.pushI64(uint64(in.Val))
+		case *ast.F32Const:
+			self.pushI32(in.Val.Bits)
+		case *ast.F64Const:
+			self.pushI64(in.Val.Bits)
+		case *ast.I32Load:
+			self.pushI32(binary.LittleEndian.Uint32(self.load(inst, in.MemArg, 4)))
+		case *ast.I64Load:
+			self.pushI64(binary.LittleEndian.Uint64(self.load(inst, in.MemArg, 8)))
+		case *ast.F32Load:
+			self.pushI32(binary.LittleEndian.Uint32(self.load(inst, in.MemArg, 4)))
+		case *ast.F64Load:
+			self.pushI64(binary.LittleEndian.Uint64(self.load(inst, in.MemArg, 8)))
+		case *ast.I32Load8s:
+			self.pushI32(uint32(int8(self.load(inst, in.MemArg, 1)[0])))
+		case *ast.I32Load8u:
+			self.pushI32(uint32(self.load(inst, in.MemArg, 1)[0]))
+		case *ast.I32Load16s:
+			self.pushI32(uint32(int16(binary.LittleEndian.Uint16(self.load(inst, in.MemArg, 2)))))
+		case *ast.I32Load16u:
+			self.pushI32(uint32(binary.LittleEndian.Uint16(self.load(inst, in.MemArg, 2))))
+		case *ast.I64Load8s:
+			self.pushI64(uint64(int8(self.load(inst, in.MemArg, 1)[0])))
+		case *ast.I64Load8u:
+			self.pushI64(uint64(self.load(inst, in.MemArg, 1)[0]))
+		case *ast.I64Load16s:
+			self.pushI64(uint64(int16(binary.LittleEndian.Uint16(self.load(inst, in.MemArg, 2)))))
+		case *ast.I64Load16u:
+			self.pushI64(uint64(binary.LittleEndian.Uint16(self.load(inst, in.MemArg, 2))))
+		case *ast.I64Load32s:
+			self.pushI64(uint64(int32(binary.LittleEndian.Uint32(self.load(inst, in.MemArg, 4)))))
+		case *ast.I64Load32u:
+			self.pushI64(uint64(binary.LittleEndian.Uint32(self.load(inst, in.MemArg, 4))))
+		case *ast.I32Store:
+			val, buf := self.store(inst, in.MemArg, 4)
+			binary.LittleEndian.PutUint32(buf, uint32(val))
+		case *ast.I64Store:
+			val, buf := self.store(inst, in.MemArg, 8)
+			binary.LittleEndian.PutUint64(buf, val)
+		case *ast.F32Store:
+			val, buf := self.store(inst, in.MemArg, 4)
+			binary.LittleEndian.PutUint32(buf, uint32(val))
+		case *ast.F64Store:
+			val, buf := self.store(inst, in.MemArg, 8)
+			binary.LittleEndian.PutUint64(buf, val)
+		case *ast.I32Store8:
+			val, buf := self.store(inst, in.MemArg, 1)
+			buf[0] = byte(val)
+		case *ast.I32Store16:
+			val, buf := self.store(inst, in.MemArg, 2)
+			binary.LittleEndian.PutUint16(buf, uint16(val))
+		case *ast.I64Store8:
+			val, buf := self.store(inst, in.MemArg, 1)
+			buf[0] = byte(val)
+		case *ast.I64Store16:
+			val, buf := self.store(inst, in.MemArg, 2)
+			binary.LittleEndian.PutUint16(buf, uint16(val))
+		case *ast.I64Store32:
+			val, buf := self.store(inst, in.MemArg, 4)
+			binary.LittleEndian.PutUint32(buf, uint32(val))
+		default:
+			op.fn(self)
+		}
+	}
+
+	return nil
+}
+
+// indirect pops the element index of a call_indirect and returns the
+// function it refers to.
+func (self *machine) indirect(inst *Instance, impl ast.CallIndirectInner) *Function {
+	table := inst.tables[impl.Table.Num]
+	index := self.popI32()
+	if index >= table.Size() {
+		trap(trapUndefinedElement)
+	}
+	fn, ok := table.elems[index].(*Function)
+	if !ok {
+		trap(trapUninitialized)
+	}
+	if !sameFuncType(fn.Type, inst.types[impl.Type.Index.ToIndex().Num]) {
+		trap(trapIndirectCallType)
+	}
+
+	return fn
+}
+
+// inBounds reports whether n items from offset fit in size.
+func inBounds(offset, n, size uint64) bool {
+	return offset <= size && n <= size-offset
+}
+
+// access returns the size bytes of memory accessed by arg at addr.
+func access(mem *Memory, addr uint64, arg ast.MemArg, size uint64) []byte {
+	ea := addr + arg.Offset
+	if ea < addr || !inBounds(ea, size, uint64(len(mem.Data))) {
+		trap(trapMemoryBounds)
+	}
+
+	return mem.Data[ea : ea+size]
+}
+
+func (self *machine) load(inst *Instance, arg ast.MemArg, size uint64) []byte {
+	addr := self.pop().bits
+	return access(inst.memories[arg.Memory.Num], addr, arg, size)
+}
+
+// store pops the operands of a store, it returns the bits to write and the
+// bytes they go to.
+func (self *machine) store(inst *Instance, arg ast.MemArg, size uint64) (uint64, []byte) {
+	val := self.pop().bits
+	addr := self.pop().bits
+	return val, access(inst.memories[arg.Memory.Num], addr, arg, size)
+}
+
+// tableInit copies n references of the element segment seg from src to dst
+// of table.
+func (self *Instance) tableInit(table *Table, seg uint32, dst, src, n uint64) {
+	elems := self.elems[seg]
+	if !inBounds(src, n, uint64(len(elems))) || !inBounds(dst, n, uint64(table.Size())) {
+		trap(trapTableBounds)
+	}
+	copy(table.elems[dst:dst+n], elems[src:src+n])
+}
+
+// memoryInit copies n bytes of the data segment seg from src to dst of mem.
+func (self *Instance) memoryInit(mem *Memory, seg uint32, dst, src, n uint64) {
+	data := self.datas[seg]
+	if !inBounds(src, n, uint64(len(data))) || !inBounds(dst, n, uint64(len(mem.Data))) {
+		trap(trapMemoryBounds)
+	}
+	copy(mem.Data[dst:dst+n], data[src:src+n])
+}
+
+func (self *machine) push(val value) {
+	self.stack = append(self.stack, val)
+}
+
+func (self *machine) pop() value {
+	val := self.stack[len(self.stack)-1]
+	self.stack = self.stack[:len(self.stack)-1]
+	return val
+}
+
+func (self *machine) pushI32(val uint32) {
+	self.stack = append(self.stack, value{bits: uint64(val)})
+}
+
+func (self *machine) popI32() uint32 {
+	return uint32(self.pop().bits)
+}
+
+func (self *machine) pushI64(val uint64) {
+	self.stack = append(self.stack, value{bits: val})
+}
+
+func (self *machine) pushBool(val bool) {
+	if val {
+		self.pushI32(1)
+	} else {
+		self.pushI32(0)
+	}
+}
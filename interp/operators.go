@@ -0,0 +1,374 @@
+package interp
+
+import (
+	"math"
+	"math/bits"
+)
+
+// operators runs the instructions without immediates by name, they only pop
+// their operands and push their result.
+var operators = make(map[string]func(m *machine))
+
+const (
+	canonicalNan32 = 0x7fc00000
+	canonicalNan64 = 0x7ff8000000000000
+)
+
+// f32Bits returns the bits of a computed f32, every NaN result becomes the
+// canonical NaN. It is arithmetic as well, so it satisfies both kinds of NaN
+// patterns of the scripts.
+func f32Bits(val float32) uint64 {
+	if val != val {
+		return canonicalNan32
+	}
+	return uint64(math.Float32bits(val))
+}
+
+func f64Bits(val float64) uint64 {
+	if val != val {
+		return canonicalNan64
+	}
+	return math.Float64bits(val)
+}
+
+func f32(bits uint64) float32 {
+	return math.Float32frombits(uint32(bits))
+}
+
+func f64(bits uint64) float64 {
+	return math.Float64frombits(bits)
+}
+
+func boolBits(val bool) uint64 {
+	if val {
+		return 1
+	}
+	return 0
+}
+
+// addUnary registers operators of one operand as functions of its bits.
+func addUnary(names map[string]func(uint64) uint64) {
+	for name, fn := range names {
+		fn := fn
+		operators[name] = func(m *machine) {
+			top := &m.stack[len(m.stack)-1]
+			*top = value{bits: fn(top.bits)}
+		}
+	}
+}
+
+// addBinary registers operators of two operands as functions of their bits.
+func addBinary(names map[string]func(a, b uint64) uint64) {
+	for name, fn := range names {
+		fn := fn
+		operators[name] = func(m *machine) {
+			b := m.pop()
+			top := &m.stack[len(m.stack)-1]
+			*top = value{bits: fn(top.bits, b.bits)}
+		}
+	}
+}
+
+func init() {
+	i32 := func(fn func(a, b uint32) uint32) func(a, b uint64) uint64 {
+		return func(a, b uint64) uint64 { return uint64(fn(uint32(a), uint32(b))) }
+	}
+	i32Rel := func(fn func(a, b uint32) bool) func(a, b uint64) uint64 {
+		return func(a, b uint64) uint64 { return boolBits(fn(uint32(a), uint32(b))) }
+	}
+	i64Rel := func(fn func(a, b uint64) bool) func(a, b uint64) uint64 {
+		return func(a, b uint64) uint64 { return boolBits(fn(a, b)) }
+	}
+
+	addUnary(map[string]func(uint64) uint64{
+		"i32.clz":        func(a uint64) uint64 { return uint64(bits.LeadingZeros32(uint32(a))) },
+		"i32.ctz":        func(a uint64) uint64 { return uint64(bits.TrailingZeros32(uint32(a))) },
+		"i32.popcnt":     func(a uint64) uint64 { return uint64(bits.OnesCount32(uint32(a))) },
+		"i32.extend8_s":  func(a uint64) uint64 { return uint64(uint32(int8(a))) },
+		"i32.extend16_s": func(a uint64) uint64 { return uint64(uint32(int16(a))) },
+		"i32.eqz":        func(a uint64) uint64 { return boolBits(uint32(a) == 0) },
+		"i64.clz":        func(a uint64) uint64 { return uint64(bits.LeadingZeros64(a)) },
+		"i64.ctz":        func(a uint64) uint64 { return uint64(bits.TrailingZeros64(a)) },
+		"i64.popcnt":     func(a uint64) uint64 { return uint64(bits.OnesCount64(a)) },
+		"i64.extend8_s":  func(a uint64) uint64 { return uint64(int8(a)) },
+		"i64.extend16_s": func(a uint64) uint64 { return uint64(int16(a)) },
+		"i64.extend32_s": func(a uint64) uint64 { return uint64(int32(a)) },
+		"i64.eqz":        func(a uint64) uint64 { return boolBits(a == 0) },
+	})
+
+	addBinary(map[string]func(a, b uint64) uint64{
+		"i32.add": i32(func(a, b uint32) uint32 { return a + b }),
+		"i32.sub": i32(func(a, b uint32) uint32 { return a - b }),
+		"i32.mul": i32(func(a, b uint32) uint32 { return a * b }),
+		"i32.div_s": i32(func(a, b uint32) uint32 {
+			if b == 0 {
+				trap(trapDivideByZero)
+			}
+			if int32(a) == math.MinInt32 && int32(b) == -1 {
+				trap(trapIntegerOverflow)
+			}
+			return uint32(int32(a) / int32(b))
+		}),
+		"i32.div_u": i32(func(a, b uint32) uint32 {
+			if b == 0 {
+				trap(trapDivideByZero)
+			}
+			return a / b
+		}),
+		"i32.rem_s": i32(func(a, b uint32) uint32 {
+			if b == 0 {
+				trap(trapDivideByZero)
+			}
+			if int32(b) == -1 {
+				return 0
+			}
+			return uint32(int32(a) % int32(b))
+		}),
+		"i32.rem_u": i32(func(a, b uint32) uint32 {
+			if b == 0 {
+				trap(trapDivideByZero)
+			}
+			return a % b
+		}),
+		"i32.and":   i32(func(a, b uint32) uint32 { return a & b }),
+		"i32.or":    i32(func(a, b uint32) uint32 { return a | b }),
+		"i32.xor":   i32(func(a, b uint32) uint32 { return a ^ b }),
+		"i32.shl":   i32(func(a, b uint32) uint32 { return a << (b & 31) }),
+		"i32.shr_s": i32(func(a, b uint32) uint32 { return uint32(int32(a) >> (b & 31)) }),
+		"i32.shr_u": i32(func(a, b uint32) uint32 { return a >> (b & 31) }),
+		"i32.rotl":  i32(func(a, b uint32) uint32 { return bits.RotateLeft32(a, int(b&31)) }),
+		"i32.rotr":  i32(func(a, b uint32) uint32 { return bits.RotateLeft32(a, -int(b&31)) }),
+		"i32.eq":    i32Rel(func(a, b uint32) bool { return a == b }),
+		"i32.ne":    i32Rel(func(a, b uint32) bool { return a != b }),
+		"i32.lt_s":  i32Rel(func(a, b uint32) bool { return int32(a) < int32(b) }),
+		"i32.lt_u":  i32Rel(func(a, b uint32) bool { return a < b }),
+		"i32.gt_s":  i32Rel(func(a, b uint32) bool { return int32(a) > int32(b) }),
+		"i32.gt_u":  i32Rel(func(a, b uint32) bool { return a > b }),
+		"i32.le_s":  i32Rel(func(a, b uint32) bool { return int32(a) <= int32(b) }),
+		"i32.le_u":  i32Rel(func(a, b uint32) bool { return a <= b }),
+		"i32.ge_s":  i32Rel(func(a, b uint32) bool { return int32(a) >= int32(b) }),
+		"i32.ge_u":  i32Rel(func(a, b uint32) bool { return a >= b }),
+
+		"i64.add": func(a, b uint64) uint64 { return a + b },
+		"i64.sub": func(a, b uint64) uint64 { return a - b },
+		"i64.mul": func(a, b uint64) uint64 { return a * b },
+		"i64.div_s": func(a, b uint64) uint64 {
+			if b == 0 {
+				trap(trapDivideByZero)
+			}
+			if int64(a) == math.MinInt64 && int64(b) == -1 {
+				trap(trapIntegerOverflow)
+			}
+			return uint64(int64(a) / int64(b))
+		},
+		"i64.div_u": func(a, b uint64) uint64 {
+			if b == 0 {
+				trap(trapDivideByZero)
+			}
+			return a / b
+		},
+		"i64.rem_s": func(a, b uint64) uint64 {
+			if b == 0 {
+				trap(trapDivideByZero)
+			}
+			if int64(b) == -1 {
+				return 0
+			}
+			return uint64(int64(a) % int64(b))
+		},
+		"i64.rem_u": func(a, b uint64) uint64 {
+			if b == 0 {
+				trap(trapDivideByZero)
+			}
+			return a % b
+		},
+		"i64.and":   func(a, b uint64) uint64 { return a & b },
+		"i64.or":    func(a, b uint64) uint64 { return a | b },
+		"i64.xor":   func(a, b uint64) uint64 { return a ^ b },
+		"i64.shl":   func(a, b uint64) uint64 { return a << (b & 63) },
+		"i64.shr_s": func(a, b uint64) uint64 { return uint64(int64(a) >> (b & 63)) },
+		"i64.shr_u": func(a, b uint64) uint64 { return a >> (b & 63) },
+		"i64.rotl":  func(a, b uint64) uint64 { return bits.RotateLeft64(a, int(b&63)) },
+		"i64.rotr":  func(a, b uint64) uint64 { return bits.RotateLeft64(a, -int(b&63)) },
+		"i64.eq":    i64Rel(func(a, b uint64) bool { return a == b }),
+		"i64.ne":    i64Rel(func(a, b uint64) bool { return a != b }),
+		"i64.lt_s":  i64Rel(func(a, b uint64) bool { return int64(a) < int64(b) }),
+		"i64.lt_u":  i64Rel(func(a, b uint64) bool { return a < b }),
+		"i64.gt_s":  i64Rel(func(a, b uint64) bool { return int64(a) > int64(b) }),
+		"i64.gt_u":  i64Rel(func(a, b uint64) bool { return a > b }),
+		"i64.le_s":  i64Rel(func(a, b uint64) bool { return int64(a) <= int64(b) }),
+		"i64.le_u":  i64Rel(func(a, b uint64) bool { return a <= b }),
+		"i64.ge_s":  i64Rel(func(a, b uint64) bool { return int64(a) >= int64(b) }),
+		"i64.ge_u":  i64Rel(func(a, b uint64) bool { return a >= b }),
+	})
+
+	initFloat()
+	initConversions()
+}
+
+func initFloat() {
+	f32Un := func(fn func(float64) float64) func(uint64) uint64 {
+		return func(a uint64) uint64 { return f32Bits(float32(fn(float64(f32(a))))) }
+	}
+	f64Un := func(fn func(float64) float64) func(uint64) uint64 {
+		return func(a uint64) uint64 { return f64Bits(fn(f64(a))) }
+	}
+	f32Bin := func(fn func(a, b float32) float32) func(a, b uint64) uint64 {
+		return func(a, b uint64) uint64 { return f32Bits(fn(f32(a), f32(b))) }
+	}
+	f64Bin := func(fn func(a, b float64) float64) func(a, b uint64) uint64 {
+		return func(a, b uint64) uint64 { return f64Bits(fn(f64(a), f64(b))) }
+	}
+	f32Rel := func(fn func(a, b float32) bool) func(a, b uint64) uint64 {
+		return func(a, b uint64) uint64 { return boolBits(fn(f32(a), f32(b))) }
+	}
+	f64Rel := func(fn func(a, b float64) bool) func(a, b uint64) uint64 {
+		return func(a, b uint64) uint64 { return boolBits(fn(f64(a), f64(b))) }
+	}
+	// the operators on the sign work on the bits and keep NaN payloads
+	const sign32, sign64 = 1 << 31, 1 << 63
+
+	addUnary(map[string]func(uint64) uint64{
+		"f32.abs":     func(a uint64) uint64 { return a &^ sign32 },
+		"f32.neg":     func(a uint64) uint64 { return a ^ sign32 },
+		"f32.ceil":    f32Un(math.Ceil),
+		"f32.floor":   f32Un(math.Floor),
+		"f32.trunc":   f32Un(math.Trunc),
+		"f32.nearest": f32Un(math.RoundToEven),
+		// the square root of the exact f64 rounds to the right f32
+		"f32.sqrt":    f32Un(math.Sqrt),
+		"f64.abs":     func(a uint64) uint64 { return a &^ sign64 },
+		"f64.neg":     func(a uint64) uint64 { return a ^ sign64 },
+		"f64.ceil":    f64Un(math.Ceil),
+		"f64.floor":   f64Un(math.Floor),
+		"f64.trunc":   f64Un(math.Trunc),
+		"f64.nearest": f64Un(math.RoundToEven),
+		"f64.sqrt":    f64Un(math.Sqrt),
+	})
+
+	addBinary(map[string]func(a, b uint64) uint64{
+		"f32.add": f32Bin(func(a, b float32) float32 { return a + b }),
+		"f32.sub": f32Bin(func(a, b float32) float32 { return a - b }),
+		"f32.mul": f32Bin(func(a, b float32) float32 { return a * b }),
+		"f32.div": f32Bin(func(a, b float32) float32 { return a / b }),
+		"f32.min": f32Bin(func(a, b float32) float32 { return float32(math.Min(float64(a), float64(b))) }),
+		"f32.max": f32Bin(func(a, b float32) float32 { return float32(math.Max(float64(a), float64(b))) }),
+		"f32.copysign": func(a, b uint64) uint64 {
+			return a&^sign32 | b&sign32
+		},
+		"f32.eq": f32Rel(func(a, b float32) bool { return a == b }),
+		"f32.ne": f32Rel(func(a, b float32) bool { return a != b }),
+		"f32.lt": f32Rel(func(a, b float32) bool { return a < b }),
+		"f32.gt": f32Rel(func(a, b float32) bool { return a > b }),
+		"f32.le": f32Rel(func(a, b float32) bool { return a <= b }),
+		"f32.ge": f32Rel(func(a, b float32) bool { return a >= b }),
+
+		"f64.add": f64Bin(func(a, b float64) float64 { return a + b }),
+		"f64.sub": f64Bin(func(a, b float64) float64 { return a - b }),
+		"f64.mul": f64Bin(func(a, b float64) float64 { return a * b }),
+		"f64.div": f64Bin(func(a, b float64) float64 { return a / b }),
+		"f64.min": f64Bin(math.Min),
+		"f64.max": f64Bin(math.Max),
+		"f64.copysign": func(a, b uint64) uint64 {
+			return a&^sign64 | b&sign64
+		},
+		"f64.eq": f64Rel(func(a, b float64) bool { return a == b }),
+		"f64.ne": f64Rel(func(a, b float64) bool { return a != b }),
+		"f64.lt": f64Rel(func(a, b float64) bool { return a < b }),
+		"f64.gt": f64Rel(func(a, b float64) bool { return a > b }),
+		"f64.le": f64Rel(func(a, b float64) bool { return a <= b }),
+		"f64.ge": f64Rel(func(a, b float64) bool { return a >= b }),
+	})
+}
+
+// saturate converts an x out of the range [min, max] of an integer type,
+// the saturating form clamps where the other traps.
+func saturate(x float64, min, max uint64, sat bool) uint64 {
+	switch {
+	case !sat && x != x:
+		trap(trapInvalidConversion)
+	case !sat:
+		trap(trapIntegerOverflow)
+	case x != x:
+		return 0
+	case x < 0:
+		return min
+	}
+
+	return max
+}
+
+func truncS32(x float64, sat bool) uint64 {
+	if x != x || x <= -2147483649 || x >= 2147483648 {
+		return saturate(x, 1<<31, math.MaxInt32, sat)
+	}
+	return uint64(uint32(int32(x)))
+}
+
+func truncU32(x float64, sat bool) uint64 {
+	if x != x || x <= -1 || x >= 4294967296 {
+		return saturate(x, 0, math.MaxUint32, sat)
+	}
+	return uint64(uint32(x))
+}
+
+func truncS64(x float64, sat bool) uint64 {
+	// -2^63 is exact, the float below it truncates out of range
+	if x != x || x < -9223372036854775808 || x >= 9223372036854775808 {
+		return saturate(x, 1<<63, math.MaxInt64, sat)
+	}
+	return uint64(int64(x))
+}
+
+func truncU64(x float64, sat bool) uint64 {
+	if x != x || x <= -1 || x >= 18446744073709551616 {
+		return saturate(x, 0, math.MaxUint64, sat)
+	}
+	return uint64(math.Trunc(x))
+}
+
+func initConversions() {
+	fromF32 := func(fn func(float64, bool) uint64, sat bool) func(uint64) uint64 {
+		return func(a uint64) uint64 { return fn(float64(f32(a)), sat) }
+	}
+	fromF64 := func(fn func(float64, bool) uint64, sat bool) func(uint64) uint64 {
+		return func(a uint64) uint64 { return fn(f64(a), sat) }
+	}
+	identity := func(a uint64) uint64 { return a }
+
+	addUnary(map[string]func(uint64) uint64{
+		"i32.wrap_i64":        func(a uint64) uint64 { return uint64(uint32(a)) },
+		"i32.trunc_f32_s":     fromF32(truncS32, false),
+		"i32.trunc_f32_u":     fromF32(truncU32, false),
+		"i32.trunc_f64_s":     fromF64(truncS32, false),
+		"i32.trunc_f64_u":     fromF64(truncU32, false),
+		"i32.trunc_sat_f32_s": fromF32(truncS32, true),
+		"i32.trunc_sat_f32_u": fromF32(truncU32, true),
+		"i32.trunc_sat_f64_s": fromF64(truncS32, true),
+		"i32.trunc_sat_f64_u": fromF64(truncU32, true),
+		"i32.reinterpret_f32": identity,
+		"i64.extend_i32_s":    func(a uint64) uint64 { return uint64(int32(a)) },
+		"i64.extend_i32_u":    func(a uint64) uint64 { return uint64(uint32(a)) },
+		"i64.trunc_f32_s":     fromF32(truncS64, false),
+		"i64.trunc_f32_u":     fromF32(truncU64, false),
+		"i64.trunc_f64_s":     fromF64(truncS64, false),
+		"i64.trunc_f64_u":     fromF64(truncU64, false),
+		"i64.trunc_sat_f32_s": fromF32(truncS64, true),
+		"i64.trunc_sat_f32_u": fromF32(truncU64, true),
+		"i64.trunc_sat_f64_s": fromF64(truncS64, true),
+		"i64.trunc_sat_f64_u": fromF64(truncU64, true),
+		"i64.reinterpret_f64": identity,
+		"f32.convert_i32_s":   func(a uint64) uint64 { return f32Bits(float32(int32(a))) },
+		"f32.convert_i32_u":   func(a uint64) uint64 { return f32Bits(float32(uint32(a))) },
+		"f32.convert_i64_s":   func(a uint64) uint64 { return f32Bits(float32(int64(a))) },
+		"f32.convert_i64_u":   func(a uint64) uint64 { return f32Bits(float32(a)) },
+		"f32.demote_f64":      func(a uint64) uint64 { return f32Bits(float32(f64(a))) },
+		"f32.reinterpret_i32": identity,
+		"f64.convert_i32_s":   func(a uint64) uint64 { return f64Bits(float64(int32(a))) },
+		"f64.convert_i32_u":   func(a uint64) uint64 { return f64Bits(float64(uint32(a))) },
+		"f64.convert_i64_s":   func(a uint64) uint64 { return f64Bits(float64(int64(a))) },
+		"f64.convert_i64_u":   func(a uint64) uint64 { return f64Bits(float64(a)) },
+		"f64.promote_f32":     func(a uint64) uint64 { return f64Bits(float64(f32(a))) },
+		"f64.reinterpret_i64": identity,
+	})
+}
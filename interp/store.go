@@ -0,0 +1,192 @@
+package interp
+
+import (
+	"fmt"
+
+	"github.com/ontio/wast-parser/ast"
+)
+
+// PageSize is the size of a page of linear memory.
+const PageSize = 65536
+
+// maxMemoryPages bounds every memory, the spec allows a memory to grow up to
+// 4GiB but the interpreter keeps it in one slice.
+const maxMemoryPages = 16384
+
+// maxTableSize bounds every table, like maxMemoryPages for memories.
+const maxTableSize = 10000000
+
+// Extern is an item a module imports or exports: a *Function, *Table, *Memory
+// or *Global.
+type Extern interface {
+	// ExternType is the kind of the item in the text format, e.g. `func`.
+	ExternType() string
+}
+
+// HostFunc implements a function in Go. Caller is the instance whose code
+// made the call, nil for a call from the host, so the function can reach its
+// memory. A returned error aborts the whole execution, the call from the host
+// returns it.
+type HostFunc func(caller *Instance, args []ast.WastValue) ([]ast.WastValue, error)
+
+// Function is a function of a module instance or one of the host.
+type Function struct {
+	Type ast.FunctionType
+
+	id   uint32
+	host HostFunc
+	inst *Instance
+	code *code
+}
+
+// NewHostFunction makes a function of signature ty to be imported by
+// modules, fn receives arguments of the parameter types and must return
+// results of the result types.
+func NewHostFunction(ty ast.FunctionType, fn HostFunc) *Function {
+	return &Function{Type: ty, id: nextFuncId(), host: fn}
+}
+
+func (self *Function) ExternType() string { return "func" }
+
+// Call invokes the function from the host.
+func (self *Function) Call(args ...ast.WastValue) (results []ast.WastValue, err error) {
+	vals, err := fromWasts(paramTypes(self.Type), args)
+	if err != nil {
+		return nil, err
+	}
+
+	defer recoverError(&err)
+	m := &machine{stack: vals}
+	m.call(self, nil)
+
+	return toWasts(self.Type.Results, m.stack), nil
+}
+
+// Memory is a linear memory, Data holds its current pages.
+type Memory struct {
+	Data   []byte
+	Max    uint32
+	HasMax bool
+
+	is64 bool
+}
+
+// NewMemory allocates a memory of the minimum size of ty, a size past the
+// limit of the interpreter fails with an "out of memory" *Trap.
+func NewMemory(ty ast.MemoryType) (*Memory, error) {
+	if ty.Limits.Min > maxMemoryPages {
+		return nil, &Trap{Msg: trapOutOfMemory}
+	}
+
+	return &Memory{
+		Data:   make([]byte, ty.Limits.Min*PageSize),
+		Max:    uint32(ty.Limits.Max),
+		HasMax: ty.Limits.HasMax,
+		is64:   ty.Is64,
+	}, nil
+}
+
+func (self *Memory) ExternType() string { return "memory" }
+
+// Size is the number of pages of the memory.
+func (self *Memory) Size() uint32 {
+	return uint32(len(self.Data) / PageSize)
+}
+
+// Grow adds delta pages and returns the previous size, it fails past the
+// maximum of the memory.
+func (self *Memory) Grow(delta uint32) (uint32, bool) {
+	old := self.Size()
+	size := uint64(old) + uint64(delta)
+	if size > maxMemoryPages || (self.HasMax && size > uint64(self.Max)) {
+		return old, false
+	}
+	self.Data = append(self.Data, make([]byte, uint64(delta)*PageSize)...)
+
+	return old, true
+}
+
+// Table is a table of references, Elem tells whether it holds functions or
+// host references.
+type Table struct {
+	Elem   ast.TableElemType
+	Max    uint32
+	HasMax bool
+
+	elems []interface{}
+}
+
+// NewTable allocates a table of the minimum size of ty filled with null, a
+// size past the limit of the interpreter fails with an "out of memory" *Trap.
+func NewTable(ty ast.TableType) (*Table, error) {
+	if ty.Limits.Min > maxTableSize {
+		return nil, &Trap{Msg: trapOutOfMemory}
+	}
+
+	return &Table{
+		Elem:   ty.Elem,
+		Max:    uint32(ty.Limits.Max),
+		HasMax: ty.Limits.HasMax,
+		elems:  make([]interface{}, ty.Limits.Min),
+	}, nil
+}
+
+func (self *Table) ExternType() string { return "table" }
+
+// Size is the number of elements of the table.
+func (self *Table) Size() uint32 {
+	return uint32(len(self.elems))
+}
+
+// grow adds delta elements set to init and returns the previous size, it
+// fails past the maximum of the table.
+func (self *Table) grow(delta uint32, init interface{}) (uint32, bool) {
+	old := self.Size()
+	size := uint64(old) + uint64(delta)
+	if size > maxTableSize || (self.HasMax && size > uint64(self.Max)) {
+		return old, false
+	}
+	for i := uint32(0); i < delta; i++ {
+		self.elems = append(self.elems, init)
+	}
+
+	return old, true
+}
+
+// Global is a global variable.
+type Global struct {
+	Type ast.GlobalValType
+
+	val value
+}
+
+// NewGlobal makes a global of type ty holding val.
+func NewGlobal(ty ast.GlobalValType, val ast.WastValue) (*Global, error) {
+	conv, err := fromWast(ty.Type, val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Global{Type: ty, val: conv}, nil
+}
+
+func (self *Global) ExternType() string { return "global" }
+
+// Get reads the value of the global.
+func (self *Global) Get() ast.WastValue {
+	return toWast(self.Type.Type, self.val)
+}
+
+// Set writes the value of a mutable global.
+func (self *Global) Set(val ast.WastValue) error {
+	if !self.Type.Mutable {
+		return fmt.Errorf("global is immutable")
+	}
+	conv, err := fromWast(self.Type.Type, val)
+	if err != nil {
+		return err
+	}
+	self.val = conv
+
+	return nil
+}
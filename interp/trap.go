@@ -0,0 +1,52 @@
+package interp
+
+// Trap aborts the execution of a module, Msg is worded like the messages of
+// the reference interpreter so `assert_trap` can match on it.
+type Trap struct {
+	Msg string
+}
+
+func (self *Trap) Error() string {
+	return self.Msg
+}
+
+const (
+	trapUnreachable       = "unreachable"
+	trapDivideByZero      = "integer divide by zero"
+	trapIntegerOverflow   = "integer overflow"
+	trapInvalidConversion = "invalid conversion to integer"
+	trapMemoryBounds      = "out of bounds memory access"
+	trapTableBounds       = "out of bounds table access"
+	trapUndefinedElement  = "undefined element"
+	trapUninitialized     = "uninitialized element"
+	trapIndirectCallType  = "indirect call type mismatch"
+	trapOutOfMemory       = "out of memory"
+	// trapExhaustion is the message of `assert_exhaustion`.
+	trapExhaustion = "call stack exhausted"
+)
+
+// trap unwinds the machine up to the call from the host, which returns the
+// Trap as its error.
+func trap(msg string) {
+	panic(&Trap{Msg: msg})
+}
+
+// hostError carries an error returned by a host function through the
+// unwinding.
+type hostError struct {
+	err error
+}
+
+// recoverError turns the unwinding of a trap or a failed host function into
+// err, other panics go on.
+func recoverError(err *error) {
+	switch val := recover().(type) {
+	case nil:
+	case *Trap:
+		*err = val
+	case hostError:
+		*err = val.err
+	default:
+		panic(val)
+	}
+}
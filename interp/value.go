@@ -0,0 +1,139 @@
+package interp
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/ontio/wast-parser/ast"
+)
+
+// value is an operand of the stack, a local or the content of a global.
+// Numbers are kept as bits in bits, i32 and f32 zero extended, references in
+// ref: nil for null, a *Function or an externRef.
+type value struct {
+	bits uint64
+	ref  interface{}
+}
+
+// externRef is the host reference `ref.extern n` of a script.
+type externRef uint32
+
+// funcIds numbers every function ever created, it gives the Index of the
+// WastRefFunc a reference turns into.
+var funcIds uint32
+
+func nextFuncId() uint32 {
+	return atomic.AddUint32(&funcIds, 1)
+}
+
+// toWast converts a value of type ty to the constant a script compares
+// results to.
+func toWast(ty ast.ValType, val value) ast.WastValue {
+	switch ty {
+	case ast.I32:
+		return ast.WastI32{Val: uint32(val.bits)}
+	case ast.I64:
+		return ast.WastI64{Val: val.bits}
+	case ast.F32:
+		return ast.WastF32{Bits: uint32(val.bits)}
+	case ast.F64:
+		return ast.WastF64{Bits: val.bits}
+	}
+
+	switch ref := val.ref.(type) {
+	case *Function:
+		return ast.WastRefFunc{Index: ref.id}
+	case externRef:
+		return ast.WastRefExtern{Val: uint32(ref)}
+	}
+	if ty == ast.Funcref {
+		return ast.WastRefNull{Type: ast.HeapFunc}
+	}
+
+	return ast.WastRefNull{Type: ast.HeapExtern}
+}
+
+// fromWast converts a constant of a script to a value of type ty.
+func fromWast(ty ast.ValType, val ast.WastValue) (value, error) {
+	switch val := val.(type) {
+	case ast.WastI32:
+		if ty == ast.I32 {
+			return value{bits: uint64(val.Val)}, nil
+		}
+	case ast.WastI64:
+		if ty == ast.I64 {
+			return value{bits: val.Val}, nil
+		}
+	case ast.WastF32:
+		if ty == ast.F32 && val.Nan == ast.NanNone {
+			return value{bits: uint64(val.Bits)}, nil
+		}
+	case ast.WastF64:
+		if ty == ast.F64 && val.Nan == ast.NanNone {
+			return value{bits: val.Bits}, nil
+		}
+	case ast.WastRefNull:
+		if ty == val.Type.ValType() {
+			return value{}, nil
+		}
+	case ast.WastRefExtern:
+		if ty == ast.Anyref && !val.Any {
+			return value{ref: externRef(val.Val)}, nil
+		}
+	}
+
+	return value{}, fmt.Errorf("type mismatch: %s is not a value of type %s", val, ty)
+}
+
+func fromWasts(types []ast.ValType, vals []ast.WastValue) ([]value, error) {
+	if len(vals) != len(types) {
+		return nil, fmt.Errorf("wrong number of arguments: expected %d, found %d", len(types), len(vals))
+	}
+	result := make([]value, len(vals))
+	for i, val := range vals {
+		conv, err := fromWast(types[i], val)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = conv
+	}
+
+	return result, nil
+}
+
+func toWasts(types []ast.ValType, vals []value) []ast.WastValue {
+	result := make([]ast.WastValue, len(vals))
+	for i, val := range vals {
+		result[i] = toWast(types[i], val)
+	}
+
+	return result
+}
+
+func paramTypes(ty ast.FunctionType) []ast.ValType {
+	types := make([]ast.ValType, len(ty.Params))
+	for i, param := range ty.Params {
+		types[i] = param.Val
+	}
+
+	return types
+}
+
+// sameFuncType compares signatures ignoring the names of the parameters.
+func sameFuncType(a, b ast.FunctionType) bool {
+	if len(a.Params) != len(b.Params) || len(a.Results) != len(b.Results) {
+		return false
+	}
+	for i := range a.Params {
+		if a.Params[i].Val != b.Params[i].Val {
+			return false
+		}
+	}
+	for i := range a.Results {
+		if a.Results[i] != b.Results[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -2,10 +2,12 @@ package lexer
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 type TokenType byte
@@ -19,22 +21,57 @@ const (
 	KeywordType
 	IdType
 	ReservedType
+	AnnotationType
 )
 
 type Lexer struct {
 	buf *bytes.Buffer
+
+	source string
+	// position of source[pos.Offset], advanced lazily by Pos
+	pos Span
+	// whether the last token was a `(`, an annotation must follow it
+	// immediately
+	afterLParen bool
 }
 
 func NewLexer(source string) *Lexer {
-	return &Lexer{buf: bytes.NewBufferString(source)}
+	return &Lexer{
+		buf:    bytes.NewBufferString(source),
+		source: source,
+		pos:    Span{Line: 1, Column: 1},
+	}
+}
+
+// Span locates a token in the source. Offset is in bytes from the start of
+// the source, Line and Column count from 1 and Column is in characters.
+type Span struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+func (self Span) String() string {
+	return fmt.Sprintf("%d:%d", self.Line, self.Column)
 }
 
 type Token interface {
 	String() string
 	Type() TokenType
+	Span() Span
 }
 
-type LParen struct{}
+type implSpan struct {
+	span Span
+}
+
+func (self implSpan) Span() Span {
+	return self.span
+}
+
+type LParen struct {
+	implSpan
+}
 
 func (self LParen) String() string {
 	return "("
@@ -45,6 +82,7 @@ func (self LParen) Type() TokenType {
 }
 
 type String struct {
+	implSpan
 	Val string
 }
 
@@ -56,7 +94,9 @@ func (self String) Type() TokenType {
 	return StringType
 }
 
-type RParen struct{}
+type RParen struct {
+	implSpan
+}
 
 func (self RParen) String() string {
 	return ")"
@@ -67,6 +107,7 @@ func (self RParen) Type() TokenType {
 }
 
 type Identifier struct {
+	implSpan
 	Val string
 }
 
@@ -79,6 +120,7 @@ func (self Identifier) Type() TokenType {
 }
 
 type Keyword struct {
+	implSpan
 	Val string
 }
 
@@ -91,6 +133,7 @@ func (self Keyword) Type() TokenType {
 }
 
 type Reserved struct {
+	implSpan
 	Val string
 }
 
@@ -102,7 +145,23 @@ func (self Reserved) String() string {
 	return fmt.Sprintf("reserved(%s)", self.Val)
 }
 
+// Annotation is the `@name` directly following a `(` which starts an
+// annotation of the annotations proposal, Val is the name without the `@`.
+type Annotation struct {
+	implSpan
+	Val string
+}
+
+func (self Annotation) Type() TokenType {
+	return AnnotationType
+}
+
+func (self Annotation) String() string {
+	return fmt.Sprintf("annotation(@%s)", self.Val)
+}
+
 type Integer struct {
+	implSpan
 	Val string
 	Hex bool
 }
@@ -149,7 +208,9 @@ type Float interface {
 	Token
 	ImplementFloat()
 }
-type implFloat struct{}
+type implFloat struct {
+	implSpan
+}
 
 func (self implFloat) ImplementFloat() {}
 func (self implFloat) String() string {
@@ -167,11 +228,33 @@ type Nan struct {
 	Neg     bool
 }
 
+func (self Nan) String() string {
+	sign := ""
+	if self.Neg {
+		sign = "-"
+	}
+	if self.SpecBit {
+		return fmt.Sprintf("%snan:0x%x", sign, self.Val)
+	}
+
+	return sign + "nan"
+}
+
 type Inf struct {
 	implFloat
 	Neg bool
 }
 
+func (self Inf) String() string {
+	if self.Neg {
+		return "-inf"
+	}
+
+	return "inf"
+}
+
+// FloatVal is a finite float literal with the underscores removed, Integral
+// carries the sign.
 type FloatVal struct {
 	implFloat
 	Hex      bool
@@ -180,6 +263,31 @@ type FloatVal struct {
 	Exponent string
 }
 
+func (self FloatVal) String() string {
+	integral := self.Integral
+	sign := ""
+	if strings.HasPrefix(integral, "-") {
+		sign = "-"
+		integral = integral[1:]
+	}
+	if self.Hex {
+		integral = "0x" + integral
+	}
+	s := sign + integral
+	if self.Decimal != "" {
+		s += "." + self.Decimal
+	}
+	if self.Exponent != "" {
+		if self.Hex {
+			s += "p" + self.Exponent
+		} else {
+			s += "e" + self.Exponent
+		}
+	}
+
+	return s
+}
+
 func number(num string) Token {
 	negative := false
 	if strings.HasPrefix(num, "+") {
@@ -263,10 +371,13 @@ func number(num string) Token {
 	}
 
 	decimal := ""
-	if num[0] == '.' && len(num) > 1 && valid(num[1]) {
-		num, decimal = skipUnderscore(num[1:], false, valid)
-		if val == "" {
-			return nil
+	if num[0] == '.' {
+		num = num[1:]
+		if len(num) > 0 && valid(num[0]) {
+			num, decimal = skipUnderscore(num, false, valid)
+			if decimal == "" {
+				return nil
+			}
 		}
 	}
 
@@ -330,11 +441,17 @@ func (self *Lexer) StartWith(pref string) bool {
 	return bytes.HasPrefix(self.buf.Bytes(), []byte(pref))
 }
 
+// ErrInvalidUTF8 is returned for source text which is not valid UTF-8.
+var ErrInvalidUTF8 = errors.New("malformed UTF-8 encoding")
+
 func (self *Lexer) ReadChar() (rune, error) {
-	r, _, err := self.buf.ReadRune()
+	r, size, err := self.buf.ReadRune()
 	if err != nil {
 		return r, err
 	}
+	if r == utf8.RuneError && size == 1 {
+		return r, ErrInvalidUTF8
+	}
 
 	return r, nil
 }
@@ -343,11 +460,11 @@ func (self *Lexer) ReadByte() (byte, error) {
 	return self.buf.ReadByte()
 }
 
-// peek the next byte
+// peek the next byte, 0 at the end of the input
 func (self *Lexer) NextByte() byte {
 	b, err := self.buf.ReadByte()
 	if err != nil {
-		panic(err)
+		return 0
 	}
 
 	_ = self.buf.UnreadByte()
@@ -424,17 +541,88 @@ func (self *Lexer) SkipPrefix(pref string) bool {
 	return b
 }
 
+// Pos returns the position of the next byte to read.
+func (self *Lexer) Pos() Span {
+	offset := len(self.source) - self.buf.Len()
+	for _, c := range self.source[self.pos.Offset:offset] {
+		if c == '\n' {
+			self.pos.Line += 1
+			self.pos.Column = 1
+		} else {
+			self.pos.Column += 1
+		}
+	}
+	self.pos.Offset = offset
+
+	return self.pos
+}
+
 func (self *Lexer) Parse() (Token, error) {
+	adjacent := self.afterLParen
+	self.afterLParen = false
 	skipped := true
 	for skipped {
 		skipped = self.SkipWhiteSpace() || self.SkipComment()
+		if skipped {
+			adjacent = false
+		}
 	}
 
 	if self.Eof() {
 		return nil, io.EOF
 	}
 
-	return self.ReadToken()
+	span := self.Pos()
+	token, err := self.ReadToken()
+	if err != nil {
+		return nil, err
+	}
+	if reserved, ok := token.(Reserved); ok && adjacent && len(reserved.Val) > 1 && reserved.Val[0] == '@' {
+		token = Annotation{Val: reserved.Val[1:]}
+	}
+	_, self.afterLParen = token.(LParen)
+
+	return withSpan(token, span), nil
+}
+
+func withSpan(token Token, span Span) Token {
+	switch val := token.(type) {
+	case LParen:
+		val.span = span
+		return val
+	case RParen:
+		val.span = span
+		return val
+	case String:
+		val.span = span
+		return val
+	case Identifier:
+		val.span = span
+		return val
+	case Keyword:
+		val.span = span
+		return val
+	case Reserved:
+		val.span = span
+		return val
+	case Annotation:
+		val.span = span
+		return val
+	case Integer:
+		val.span = span
+		return val
+	case Nan:
+		val.span = span
+		return val
+	case Inf:
+		val.span = span
+		return val
+	case FloatVal:
+		val.span = span
+		return val
+	}
+
+	return token
 }
 
 func (self *Lexer) ReadToken() (Token, error) {
@@ -444,6 +632,9 @@ func (self *Lexer) ReadToken() (Token, error) {
 		return RParen{}, nil
 	} else if self.SkipPrefix("\"") {
 		str, err := self.ReadStringToken()
+		if err == io.EOF {
+			return nil, errors.New("unexpected end of file in string")
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -501,7 +692,7 @@ func (self *Lexer) ReadStringToken() (string, error) {
 				if err != nil {
 					return "", err
 				}
-				result += string(n)
+				result += string(rune(n))
 				if self.SkipPrefix("}") {
 					return "", fmt.Errorf("expected end with }")
 				}
@@ -511,7 +702,8 @@ func (self *Lexer) ReadStringToken() (string, error) {
 					if err != nil {
 						return "", err
 					}
-					result += string(to_hex(ecs)*16 + c2)
+					// `\hh` escapes a raw byte, not a code point
+					result += string([]byte{to_hex(ecs)*16 + c2})
 				} else {
 					return "", fmt.Errorf("UnexpectedEof")
 				}
@@ -574,6 +766,18 @@ func to_hex(c rune) byte {
 		return byte(c) - '0'
 	}
 }
+
+// Literal returns the source text of the number, keyword or identifier
+// starting at span, as written with its sign, base prefix and underscores.
+func Literal(source string, span Span) string {
+	end := span.Offset
+	for end < len(source) && isIdChar(source[end]) {
+		end += 1
+	}
+
+	return source[span.Offset:end]
+}
+
 func isIdChar(b byte) bool {
 	if b >= '0' && b <= '9' {
 		return true
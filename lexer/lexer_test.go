@@ -86,3 +86,69 @@ func TestInteger(t *testing.T) {
 	testInteger("0x10", "10")
 
 }
+
+func TestFloat(t *testing.T) {
+	testFloat := func(input string, expected string) {
+		lexer := NewLexer(input)
+		token, err := lexer.Parse()
+		assert.Nil(t, err)
+		_, ok := token.(Float)
+		assert.True(t, ok, input)
+
+		assert.Equal(t, expected, token.String())
+	}
+
+	testFloat("1.5", "1.5")
+	testFloat("1.", "1")
+	testFloat("1.e3", "1e3")
+	testFloat("-1_000.000_1e+1_0", "-1000.0001e10")
+	testFloat("0x1.fffffep+127", "0x1.fffffep127")
+	testFloat("-0x1p-149", "-0x1p-149")
+	testFloat("0x1_0.0_1P1", "0x10.01p1")
+	testFloat("-inf", "-inf")
+	testFloat("nan:0x7f_ffff", "nan:0x7fffff")
+}
+
+func TestSpan(t *testing.T) {
+	lexer := NewLexer("(module\n  ;; comment\n  (func $f) \"é\" 12)")
+
+	var spans []Span
+	for {
+		token, err := lexer.Parse()
+		if err != nil {
+			assert.Equal(t, io.EOF, err)
+			break
+		}
+		spans = append(spans, token.Span())
+	}
+
+	assert.Equal(t, []Span{
+		{Offset: 0, Line: 1, Column: 1},
+		{Offset: 1, Line: 1, Column: 2},
+		{Offset: 23, Line: 3, Column: 3},
+		{Offset: 24, Line: 3, Column: 4},
+		{Offset: 29, Line: 3, Column: 9},
+		{Offset: 31, Line: 3, Column: 11},
+		{Offset: 33, Line: 3, Column: 13},
+		{Offset: 38, Line: 3, Column: 17},
+		{Offset: 40, Line: 3, Column: 19},
+	}, spans)
+}
+
+func TestAnnotation(t *testing.T) {
+	lexer := NewLexer("(@custom @x ( @y)")
+	var tokens []Token
+	for {
+		token, err := lexer.Parse()
+		if err != nil {
+			assert.Equal(t, io.EOF, err)
+			break
+		}
+		tokens = append(tokens, token)
+	}
+
+	assert.Equal(t, 6, len(tokens))
+	assert.Equal(t, "custom", tokens[1].(Annotation).Val)
+	assert.Equal(t, "@x", tokens[2].(Reserved).Val)
+	assert.Equal(t, "@y", tokens[4].(Reserved).Val)
+}
@@ -0,0 +1,219 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ontio/wast-parser/lexer"
+)
+
+// The errors below are the kinds of parse errors callers may want to tell
+// apart with errors.As. Error prefixes the message with the position, Message
+// returns it alone, worded like the assert_malformed messages of the spec
+// testsuite.
+
+// UnexpectedTokenError reports a token which is not allowed at its position,
+// Found is empty at the end of the input.
+type UnexpectedTokenError struct {
+	Span     lexer.Span
+	Expected []string
+	Found    string
+}
+
+func (self *UnexpectedTokenError) Message() string {
+	found := self.Found
+	if found == "" {
+		found = "eof"
+	}
+	if len(self.Expected) == 0 {
+		return fmt.Sprintf("unexpected token %s", found)
+	}
+
+	return fmt.Sprintf("unexpected token %s, expected %s", found, strings.Join(self.Expected, " or "))
+}
+
+func (self *UnexpectedTokenError) Error() string {
+	return locate(self.Span, self.Message())
+}
+
+// ConstantOverflowError reports a numeric literal out of the range of its
+// type, Type is the value type of a constant instruction or empty otherwise.
+type ConstantOverflowError struct {
+	Span    lexer.Span
+	Literal string
+	Type    string
+}
+
+func (self *ConstantOverflowError) Message() string {
+	if self.Type == "" {
+		return fmt.Sprintf("constant out of range: %s", self.Literal)
+	}
+
+	return fmt.Sprintf("%s constant out of range: %s", self.Type, self.Literal)
+}
+
+func (self *ConstantOverflowError) Error() string {
+	return locate(self.Span, self.Message())
+}
+
+// InvalidUTF8Error reports source text or a name which is not valid UTF-8.
+type InvalidUTF8Error struct {
+	Span lexer.Span
+}
+
+func (self *InvalidUTF8Error) Message() string {
+	return "malformed UTF-8 encoding"
+}
+
+func (self *InvalidUTF8Error) Error() string {
+	return locate(self.Span, self.Message())
+}
+
+// UnknownInstructionError reports a keyword in instruction position which
+// names no instruction.
+type UnknownInstructionError struct {
+	Span lexer.Span
+	Name string
+}
+
+func (self *UnknownInstructionError) Message() string {
+	return fmt.Sprintf("unknown operator %s", self.Name)
+}
+
+func (self *UnknownInstructionError) Error() string {
+	return locate(self.Span, self.Message())
+}
+
+// UnknownIdentifierError reports a `$id` reference with no matching
+// definition, Kind is the index space searched, e.g. `func` or `label`.
+type UnknownIdentifierError struct {
+	Span lexer.Span
+	Kind string
+	Name string
+}
+
+func (self *UnknownIdentifierError) Message() string {
+	return fmt.Sprintf("unknown %s $%s", self.Kind, self.Name)
+}
+
+func (self *UnknownIdentifierError) Error() string {
+	return locate(self.Span, self.Message())
+}
+
+// DuplicateIdentifierError reports a `$id` defined twice in one index space.
+type DuplicateIdentifierError struct {
+	Span lexer.Span
+	Kind string
+	Name string
+}
+
+func (self *DuplicateIdentifierError) Message() string {
+	return fmt.Sprintf("duplicate %s $%s", self.Kind, self.Name)
+}
+
+func (self *DuplicateIdentifierError) Error() string {
+	return locate(self.Span, self.Message())
+}
+
+// InlineTypeError reports a type use whose inline signature differs from the
+// type of its index.
+type InlineTypeError struct {
+	Span  lexer.Span
+	Index uint32
+}
+
+func (self *InlineTypeError) Message() string {
+	return fmt.Sprintf("inline function type doesn't match type %d", self.Index)
+}
+
+func (self *InlineTypeError) Error() string {
+	return locate(self.Span, self.Message())
+}
+
+// MismatchingLabelError reports the label after an `end` or `else` which is
+// not the label of its block.
+type MismatchingLabelError struct {
+	Span lexer.Span
+	Name string
+}
+
+func (self *MismatchingLabelError) Message() string {
+	return fmt.Sprintf("mismatching label $%s", self.Name)
+}
+
+func (self *MismatchingLabelError) Error() string {
+	return locate(self.Span, self.Message())
+}
+
+// FeatureError reports a construct of a proposal outside the enabled set,
+// Feature holds the proposals missing. The Span is the zero value for errors
+// found outside the parser, e.g. by the encoder.
+type FeatureError struct {
+	Span    lexer.Span
+	Feature Features
+	What    string
+}
+
+func (self *FeatureError) Message() string {
+	return fmt.Sprintf("%s requires the %s proposal", self.What, self.Feature)
+}
+
+func (self *FeatureError) Error() string {
+	if self.Span == (lexer.Span{}) {
+		return self.Message()
+	}
+
+	return locate(self.Span, self.Message())
+}
+
+func locate(span lexer.Span, msg string) string {
+	return fmt.Sprintf("%s: %s", span, msg)
+}
+
+// isLocated reports whether err carries a position already.
+func isLocated(err error) bool {
+	switch err.(type) {
+	case *Error, *UnexpectedTokenError, *ConstantOverflowError, *InvalidUTF8Error,
+		*UnknownInstructionError, *UnknownIdentifierError, *DuplicateIdentifierError, *InlineTypeError,
+		*MismatchingLabelError, *FeatureError:
+		return true
+	}
+
+	return false
+}
+
+func describeToken(token lexer.Token) string {
+	if token == nil {
+		return ""
+	}
+	switch val := token.(type) {
+	case lexer.Keyword:
+		return val.Val
+	case lexer.Reserved:
+		return val.Val
+	case lexer.Identifier:
+		return val.Val
+	case lexer.Annotation:
+		return "@" + val.Val
+	case lexer.String:
+		return fmt.Sprintf("%q", val.Val)
+	}
+
+	return token.String()
+}
+
+// Unexpected returns an error for the next token, expected lists what would
+// have been accepted instead.
+func (self *ParserBuffer) Unexpected(expected ...string) error {
+	return &UnexpectedTokenError{Span: self.Pos(), Expected: expected, Found: describeToken(self.PeekToken())}
+}
+
+// UnexpectedPrev is Unexpected for the token read last.
+func (self *ParserBuffer) UnexpectedPrev(expected ...string) error {
+	if self.curr == 0 {
+		return self.Unexpected(expected...)
+	}
+	token := self.tokens[self.curr-1]
+
+	return &UnexpectedTokenError{Span: token.Span(), Expected: expected, Found: describeToken(token)}
+}
@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/ontio/wast-parser/lexer"
+)
+
+// Features is a set of post-MVP proposals, the zero value is the MVP alone.
+type Features uint32
+
+const (
+	FeatureSignExt Features = 1 << iota
+	FeatureSatTrunc
+	FeatureMultiValue
+	FeatureBulkMemory
+	FeatureReferenceTypes
+	FeatureSimd
+	FeatureThreads
+	FeatureTailCall
+	FeatureExceptions
+	FeatureMultiMemory
+	FeatureMemory64
+
+	// AllFeatures enables every proposal the parser knows.
+	AllFeatures = FeatureMemory64<<1 - 1
+)
+
+var featureNames = []string{
+	"sign-extension-ops",
+	"nontrapping-float-to-int-conversions",
+	"multi-value",
+	"bulk-memory",
+	"reference-types",
+	"simd",
+	"threads",
+	"tail-call",
+	"exception-handling",
+	"multi-memory",
+	"memory64",
+}
+
+// Has reports whether every proposal of f is in the set.
+func (self Features) Has(f Features) bool {
+	return self&f == f
+}
+
+// String lists the proposal names separated by `,`.
+func (self Features) String() string {
+	var names []string
+	for i, name := range featureNames {
+		if self&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "mvp"
+	}
+
+	return strings.Join(names, ",")
+}
+
+// Features returns the proposals the parse accepts, all of them unless
+// restricted with SetFeatures.
+func (self *ParserBuffer) Features() Features {
+	return self.features
+}
+
+// SetFeatures restricts the parse to the MVP and the proposals in features,
+// anything else fails with a FeatureError.
+func (self *ParserBuffer) SetFeatures(features Features) {
+	self.features = features
+}
+
+// Require returns a FeatureError located at span if f is not enabled, what
+// names the construct which needs it.
+func (self *ParserBuffer) Require(span lexer.Span, f Features, what string) error {
+	if self.features.Has(f) {
+		return nil
+	}
+
+	return &FeatureError{Span: span, Feature: f &^ self.features, What: what}
+}
@@ -1,19 +1,41 @@
 package parser
 
 import (
-	"errors"
 	"fmt"
-	"github.com/ontio/wast-parser/lexer"
 	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ontio/wast-parser/lexer"
 )
 
 type Parse interface {
 	Parse(parser *ParserBuffer) error
 }
 
+// Error is a parse error located in the source.
+type Error struct {
+	Span lexer.Span
+	Err  error
+}
+
+func (self *Error) Error() string {
+	return fmt.Sprintf("%s: %s", self.Span, self.Err)
+}
+
+func (self *Error) Unwrap() error {
+	return self.Err
+}
+
 type ParserBuffer struct {
+	source string
 	tokens []lexer.Token
 	curr   int
+	// position of the end of the input
+	end lexer.Span
+	// proposals accepted by the parse
+	features Features
 }
 
 func NewParserBuffer(input string) (*ParserBuffer, error) {
@@ -25,13 +47,83 @@ func NewParserBuffer(input string) (*ParserBuffer, error) {
 			if err == io.EOF {
 				break
 			}
-			return nil, err
+			if err == lexer.ErrInvalidUTF8 {
+				return nil, &InvalidUTF8Error{Span: lex.Pos()}
+			}
+			return nil, &Error{Span: lex.Pos(), Err: err}
 		}
 
 		tokens = append(tokens, token)
 	}
 
-	return &ParserBuffer{tokens: tokens, curr: 0}, nil
+	tokens, err := skipAnnotations(tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ParserBuffer{source: input, tokens: tokens, curr: 0, end: lex.Pos(), features: AllFeatures}, nil
+}
+
+// knownAnnotations are the annotations kept in the token stream, any other
+// annotation is dropped with its contents.
+var knownAnnotations = map[string]bool{
+	"custom": true,
+}
+
+func skipAnnotations(tokens []lexer.Token) ([]lexer.Token, error) {
+	result := tokens[:0]
+	for i := 0; i < len(tokens); i++ {
+		annot, ok := tokens[i].(lexer.Annotation)
+		if !ok || knownAnnotations[annot.Val] {
+			result = append(result, tokens[i])
+			continue
+		}
+		// drop the `(` already kept and everything up to the matching `)`
+		result = result[:len(result)-1]
+		depth := 1
+		for depth > 0 {
+			i += 1
+			if i == len(tokens) {
+				return nil, &Error{Span: annot.Span(), Err: fmt.Errorf("unclosed annotation @%s", annot.Val)}
+			}
+			switch tokens[i].Type() {
+			case lexer.LParenType:
+				depth += 1
+			case lexer.RParenType:
+				depth -= 1
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Pos returns the position of the next token, or of the end of the input if
+// all tokens are consumed.
+func (self *ParserBuffer) Pos() lexer.Span {
+	return self.spanAt(self.curr)
+}
+
+func (self *ParserBuffer) spanAt(curr int) lexer.Span {
+	if curr < len(self.tokens) {
+		return self.tokens[curr].Span()
+	}
+
+	return self.end
+}
+
+// Errorf returns an error located at the next token.
+func (self *ParserBuffer) Errorf(format string, a ...interface{}) error {
+	return &Error{Span: self.Pos(), Err: fmt.Errorf(format, a...)}
+}
+
+// wrapError locates err at the next token, unless it is located already.
+func (self *ParserBuffer) wrapError(err error) error {
+	if isLocated(err) {
+		return err
+	}
+
+	return &Error{Span: self.Pos(), Err: err}
 }
 
 func (self *ParserBuffer) Empty() bool {
@@ -58,6 +150,21 @@ func (self *ParserBuffer) ExpectString() (string, error) {
 	return str, nil
 }
 
+// ExpectName reads a string which must be valid UTF-8, as the names of
+// imports and exports.
+func (self *ParserBuffer) ExpectName() (string, error) {
+	span := self.Pos()
+	str, err := self.ExpectString()
+	if err != nil {
+		return "", err
+	}
+	if !utf8.ValidString(str) {
+		return "", &InvalidUTF8Error{Span: span}
+	}
+
+	return str, nil
+}
+
 func (self *ParserBuffer) ExpectReserved() error {
 	cursor := self.Cursor()
 	_, err := cursor.Reserved()
@@ -75,12 +182,24 @@ func (self *ParserBuffer) ExpectKeywordMatch(expect string) error {
 		return err
 	}
 	if kw != expect {
-		return fmt.Errorf("expect keyword: %s, got: %s", expect, kw)
+		return self.UnexpectedPrev(expect)
 	}
 
 	return nil
 }
 
+// ExpectAnnotation reads the `@name` of an annotation, the `(` before it is
+// read already.
+func (self *ParserBuffer) ExpectAnnotation(expect string) error {
+	annot, ok := self.PeekToken().(lexer.Annotation)
+	if !ok || annot.Val != expect {
+		return self.Unexpected("@" + expect)
+	}
+	self.curr += 1
+
+	return nil
+}
+
 func (self *ParserBuffer) ExpectLParen() error {
 	cursor := self.Cursor()
 	err := cursor.ExpectLparen()
@@ -107,21 +226,26 @@ func (self *ParserBuffer) ExpectKeyword() (string, error) {
 	cursor := self.Cursor()
 	kw := cursor.Keyword()
 	if len(kw) == 0 {
-		return "", errors.New("expect keyword")
+		return "", self.Unexpected("keyword")
 	}
 
 	self.curr = cursor.curr
 	return kw, nil
 }
 
+// Literal returns the source text of a number token, for messages quoting it
+// as written.
+func (self *ParserBuffer) Literal(token lexer.Token) string {
+	return lexer.Literal(self.source, token.Span())
+}
+
 func (self *ParserBuffer) Float() (val lexer.Float, err error) {
-	if token := self.ReadToken(); token != nil {
-		if t, ok := token.(lexer.Float); ok {
-			return t, nil
-		}
+	if t, ok := self.PeekToken().(lexer.Float); ok {
+		self.curr += 1
+		return t, nil
 	}
 
-	return val, errors.New("expect float")
+	return val, self.Unexpected("float")
 }
 
 func (self *ParserBuffer) ExpectInteger() (lexer.Integer, error) {
@@ -135,21 +259,69 @@ func (self *ParserBuffer) ExpectInteger() (lexer.Integer, error) {
 	return val, nil
 }
 
-func (self *ParserBuffer) ExpectInt64() (int64, error) {
+// expectInt reads an integer of the given bit size. Negative literals are
+// only accepted if signed, and are returned as their two's complement bits.
+// ty names the type in overflow errors.
+func (self *ParserBuffer) expectInt(bits int, signed bool, ty string) (uint64, error) {
 	val, err := self.ExpectInteger()
 	if err != nil {
 		return 0, err
 	}
-	return val.ToInt(64)
+	base := 10
+	if val.Hex {
+		base = 16
+	}
+	overflow := &ConstantOverflowError{Span: val.Span(), Literal: self.Literal(val), Type: ty}
+	if strings.HasPrefix(val.Val, "-") {
+		if !signed {
+			return 0, overflow
+		}
+		value, err := strconv.ParseInt(val.Val, base, bits)
+		if err != nil {
+			return 0, overflow
+		}
+		return uint64(value) & (^uint64(0) >> uint(64-bits)), nil
+	}
+	value, err := strconv.ParseUint(val.Val, base, bits)
+	if err != nil {
+		return 0, overflow
+	}
+
+	return value, nil
+}
+
+// ExpectI8 reads an 8 bit lane of a v128 constant, signed or unsigned.
+func (self *ParserBuffer) ExpectI8() (uint8, error) {
+	val, err := self.expectInt(8, true, "i8")
+	return uint8(val), err
+}
+
+// ExpectI16 reads a 16 bit lane of a v128 constant, signed or unsigned.
+func (self *ParserBuffer) ExpectI16() (uint16, error) {
+	val, err := self.expectInt(16, true, "i16")
+	return uint16(val), err
+}
+
+// ExpectI32 reads the literal of an i32 constant, signed or unsigned.
+func (self *ParserBuffer) ExpectI32() (uint32, error) {
+	val, err := self.expectInt(32, true, "i32")
+	return uint32(val), err
+}
+
+// ExpectInt64 reads the literal of an i64 constant, signed or unsigned.
+func (self *ParserBuffer) ExpectInt64() (int64, error) {
+	val, err := self.expectInt(64, true, "i64")
+	return int64(val), err
+}
+
+func (self *ParserBuffer) ExpectUint8() (uint8, error) {
+	val, err := self.expectInt(8, false, "")
+	return uint8(val), err
 }
 
 func (self *ParserBuffer) ExpectUint32() (uint32, error) {
-	val, err := self.ExpectInteger()
-	if err != nil {
-		return 0, err
-	}
-	value, err := val.ToUint(32)
-	return uint32(value), err
+	val, err := self.expectInt(32, false, "")
+	return uint32(val), err
 }
 
 func (self *ParserBuffer) StepBack(num int) {
@@ -161,12 +333,7 @@ func (self *ParserBuffer) StepBack(num int) {
 }
 
 func (self *ParserBuffer) ExpectUint64() (uint64, error) {
-	val, err := self.ExpectInteger()
-	if err != nil {
-		return 0, err
-	}
-
-	return val.ToUint(64)
+	return self.expectInt(64, false, "")
 }
 
 func (self *ParserBuffer) PeekKeyword() (string, error) {
@@ -210,8 +377,11 @@ func (self *ParserBuffer) PeekToken() lexer.Token {
 
 func (self *ParserBuffer) clone() *ParserBuffer {
 	return &ParserBuffer{
-		tokens: self.tokens,
-		curr:   self.curr,
+		source:   self.source,
+		tokens:   self.tokens,
+		curr:     self.curr,
+		end:      self.end,
+		features: self.features,
 	}
 }
 
@@ -258,7 +428,7 @@ func (self *ParserBuffer) Parens(fn func(ps *ParserBuffer) error) error {
 
 	err = fn(self)
 	if err != nil {
-		return err
+		return self.wrapError(err)
 	}
 
 	err = self.ExpectRParen()
@@ -291,13 +461,21 @@ func (self *Cursor) readToken() lexer.Token {
 	return token
 }
 
+// unexpected returns an error for the token the cursor read last, nil at the
+// end of the input.
+func (self *Cursor) unexpected(token lexer.Token, expected ...string) error {
+	span := self.parser.end
+	if token != nil {
+		span = token.Span()
+	}
+
+	return &UnexpectedTokenError{Span: span, Expected: expected, Found: describeToken(token)}
+}
+
 func (self *Cursor) ExpectLparen() error {
 	token := self.readToken()
-	if token == nil {
-		return errors.New("expect lparen, got eof")
-	}
-	if t, ok := token.(lexer.LParen); !ok {
-		return fmt.Errorf("expect lparen, got %s", t)
+	if _, ok := token.(lexer.LParen); !ok {
+		return self.unexpected(token, "(")
 	}
 
 	return nil
@@ -305,11 +483,8 @@ func (self *Cursor) ExpectLparen() error {
 
 func (self *Cursor) ExpectRparen() error {
 	token := self.readToken()
-	if token == nil {
-		return errors.New("expect rparen, got eof")
-	}
-	if t, ok := token.(lexer.RParen); !ok {
-		return fmt.Errorf("expect rparen, got %s", t)
+	if _, ok := token.(lexer.RParen); !ok {
+		return self.unexpected(token, ")")
 	}
 
 	return nil
@@ -336,32 +511,30 @@ func (self *Cursor) Id() string {
 }
 
 func (self *Cursor) Integer() (val lexer.Integer, err error) {
-	if token := self.readToken(); token != nil {
-		if t, ok := token.(lexer.Integer); ok {
-			return t, nil
-		}
+	token := self.readToken()
+	if t, ok := token.(lexer.Integer); ok {
+		return t, nil
 	}
 
-	return val, errors.New("expect integer")
+	return val, self.unexpected(token, "integer")
 }
 
 func (self *Cursor) String() (string, error) {
-	if token := self.readToken(); token != nil {
-		if t, ok := token.(lexer.String); ok {
-			return string(t.Val), nil
-		}
+	token := self.readToken()
+	if t, ok := token.(lexer.String); ok {
+		return string(t.Val), nil
 	}
 
-	return "", errors.New("expect string token")
+	return "", self.unexpected(token, "string")
 }
+
 func (self *Cursor) Reserved() (string, error) {
-	if token := self.readToken(); token != nil {
-		if t, ok := token.(lexer.Reserved); ok {
-			return string(t.Val), nil
-		}
+	token := self.readToken()
+	if t, ok := token.(lexer.Reserved); ok {
+		return string(t.Val), nil
 	}
 
-	return "", errors.New("expect string token")
+	return "", self.unexpected(token, "reserved")
 }
 
 func (self *ParserBuffer) Dump() string {
@@ -1,8 +1,12 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+
+	"github.com/ontio/wast-parser/lexer"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestParser(t *testing.T) {
@@ -23,3 +27,50 @@ func TestParser(t *testing.T) {
 
 	fmt.Printf("tokens: %v", parser)
 }
+
+func TestErrorPosition(t *testing.T) {
+	_, err := NewParserBuffer("(module\n  \"abc")
+	perr, ok := err.(*Error)
+	assert.True(t, ok)
+	assert.Equal(t, 2, perr.Span.Line)
+
+	ps, err := NewParserBuffer("(module\n  (memory 1)\n  (func $f) x)")
+	assert.Nil(t, err)
+	err = ps.Parens(func(ps *ParserBuffer) error {
+		if err := ps.ExpectKeywordMatch("module"); err != nil {
+			return err
+		}
+		for i := 0; i < 2; i++ {
+			if err := ps.Parens(func(ps *ParserBuffer) error {
+				for !ps.Empty() {
+					ps.ReadToken()
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		_, err := ps.ExpectString()
+		return err
+	})
+	var unexpected *UnexpectedTokenError
+	assert.True(t, errors.As(err, &unexpected))
+	assert.Equal(t, lexer.Span{Offset: 33, Line: 3, Column: 13}, unexpected.Span)
+	assert.Equal(t, "3:13: unexpected token x, expected string", err.Error())
+}
+
+func TestSkipAnnotations(t *testing.T) {
+	ps, err := NewParserBuffer(`(module (@name "m" (nested)) (@custom "c") (memory 1 (@x)))`)
+	assert.Nil(t, err)
+	var types []lexer.TokenType
+	for ps.PeekToken() != nil {
+		types = append(types, ps.ReadToken().Type())
+	}
+	assert.Equal(t, []lexer.TokenType{lexer.LParenType, lexer.KeywordType,
+		lexer.LParenType, lexer.AnnotationType, lexer.StringType, lexer.RParenType,
+		lexer.LParenType, lexer.KeywordType, lexer.IntegerType, lexer.RParenType,
+		lexer.RParenType}, types)
+
+	_, err = NewParserBuffer(`(module (@x (memory 1)`)
+	assert.EqualError(t, err, "1:10: unclosed annotation @x")
+}
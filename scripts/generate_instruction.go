@@ -131,7 +131,33 @@ func (self *[Name]) Encode(sink *ZeroCopySink) {
 	sink.WriteBytes(inst)
 	[FieldsEncode]
 }
+
+func (self *[Name]) decodeInstrBody(source *ZeroCopySource) error {
+	[decodeBody]
+	return nil
+}
+
+func (self *[Name]) printInstrBody(p *printer) {
+	[printBody]
+}
+`
+	for _, field := range self.Fields {
+		if strings.HasPrefix(field.Type, "MemArg") {
+			template += `
+func (self *[Name]) memArg() (*MemArg, uint32) {
+	return &self.` + field.Name + `, ` + strings.Trim(field.Type, "MemArg<>") + `
+}
 `
+		}
+		if strings.HasPrefix(field.Type, "Lane") {
+			template += `
+func (self *[Name]) lane() (uint8, uint8) {
+	return self.` + field.Name + `, ` + strings.Trim(field.Type, "Lane<>") + `
+}
+`
+		}
+	}
+
 	return generate(template, map[string]interface{}{
 		"Name":         self.Name,
 		"Fields":       self.generateFields(),
@@ -139,9 +165,94 @@ func (self *[Name]) Encode(sink *ZeroCopySink) {
 		"Id":           self.Id[0],
 		"Instruction":  self.generateInstr(),
 		"FieldsEncode": self.generateEncode(),
+		"decodeBody":   self.generateDecodeBody(),
+		"printBody":    self.generatePrintBody(),
 	})
 }
 
+func (self Instruction) generatePrintBody() string {
+	body := ""
+	for _, field := range self.Fields {
+		switch field.Type {
+		case "uint32":
+			body += "p.word(strconv.Itoa(int(int32(self." + field.Name + "))))\n"
+		case "int64":
+			body += "p.word(strconv.FormatInt(self." + field.Name + ", 10))\n"
+		default:
+			if strings.HasPrefix(field.Type, "MemArg") {
+				body += fmt.Sprintf("self.%s.print(p, %s)\n", field.Name, strings.Trim(field.Type, "MemArg<>"))
+			} else if strings.HasPrefix(field.Type, "Lane") {
+				body += "p.word(strconv.Itoa(int(self." + field.Name + ")))\n"
+			} else if field.Type == "DefaultIndex" || field.Type == "MemIndex" {
+				body += "printDefaultIndex(p, self." + field.Name + ")\n"
+			} else {
+				body += "self." + field.Name + ".print(p)\n"
+			}
+		}
+	}
+
+	return body
+}
+
+// opcodeLen is the number of leading bytes of Inst identifying the
+// instruction, the rest are reserved immediates.
+func (self Instruction) opcodeLen() int {
+	if len(self.Inst) > 1 && isPrefix(self.Inst[0]) {
+		return 2
+	}
+
+	return len(self.Inst)
+}
+
+func isPrefix(b byte) bool {
+	return b == 0xfc || b == 0xfd || b == 0xfe
+}
+
+func (self Instruction) generateDecodeBody() string {
+	body := ""
+	if reserved := len(self.Inst) - self.opcodeLen(); reserved > 0 {
+		body += fmt.Sprintf(`if err := source.readReserved(%d); err != nil {
+		return err
+	}
+`, reserved)
+	}
+	for _, field := range self.Fields {
+		switch field.Type {
+		case "uint32":
+			body += generate(`val, err := source.ReadInt32()
+	if err != nil {
+		return err
+	}
+	self.[Name] = uint32(val)
+`, map[string]interface{}{"Name": field.Name})
+		case "int64":
+			body += generate(`val, err := source.ReadInt64()
+	if err != nil {
+		return err
+	}
+	self.[Name] = val
+`, map[string]interface{}{"Name": field.Name})
+		case "OptionId":
+		default:
+			if strings.HasPrefix(field.Type, "Lane") {
+				body += generate(`lane, err := source.ReadByte()
+	if err != nil {
+		return err
+	}
+	self.[Name] = lane
+`, map[string]interface{}{"Name": field.Name})
+				break
+			}
+			body += generate(`if err := self.[Name].Decode(source); err != nil {
+		return err
+	}
+`, map[string]interface{}{"Name": field.Name})
+		}
+	}
+
+	return body
+}
+
 func (self Instruction) generateEncode() string {
 	fieldsEncode := ""
 	for _, field := range self.Fields {
@@ -151,7 +262,13 @@ func (self Instruction) generateEncode() string {
 		case "int64":
 			fieldsEncode += "sink.WriteInt64(self." + field.Name + ")" + "\n"
 		default:
-			fieldsEncode += "self." + field.Name + ".Encode(sink)" + "\n"
+			if strings.HasPrefix(field.Type, "Lane") {
+				fieldsEncode += "sink.WriteByte(self." + field.Name + ")" + "\n"
+			} else if field.Type == "MemIndex" {
+				fieldsEncode += "encodeReservedIndex(sink, self." + field.Name + ")" + "\n"
+			} else {
+				fieldsEncode += "self." + field.Name + ".Encode(sink)" + "\n"
+			}
 		}
 	}
 
@@ -160,7 +277,12 @@ func (self Instruction) generateEncode() string {
 
 func (self Instruction) generateInstr() string {
 	var instr []string
-	for _, b := range self.Inst {
+	for i, b := range self.Inst {
+		if i == 1 && self.opcodeLen() == 2 && b >= 0x80 {
+			// the opcode after a prefix byte is a LEB128 u32
+			instr = append(instr, fmt.Sprintf("0x%x", b), "0x1")
+			continue
+		}
 		instr = append(instr, fmt.Sprintf("0x%x", b))
 	}
 
@@ -174,6 +296,10 @@ func (self Instruction) generateFields() string {
 		ty := field.Type
 		if strings.HasPrefix(ty, "MemArg") {
 			ty = "MemArg"
+		} else if strings.HasPrefix(ty, "Lane") {
+			ty = "uint8"
+		} else if ty == "DefaultIndex" || ty == "MemIndex" {
+			ty = "Index"
 		}
 		fields = append(fields, fmt.Sprintf("%s %s", field.Name, ty))
 	}
@@ -186,14 +312,19 @@ func (self Instruction) generateParseBody() string {
 	for _, field := range self.Fields {
 		switch field.Type {
 		case "uint32":
-			body += parseInt(field.Name, "Uint32")
+			body += parseInt(field.Name, "I32")
 		case "int64":
 			body += parseInt(field.Name, "Int64")
 		case "OptionId":
 			body += parseOptionId(field.Name)
+		case "DefaultIndex", "MemIndex":
+			body += generate(`self.[Name] = parseDefaultIndex(ps)
+`, map[string]interface{}{"Name": field.Name})
 		default:
 			if strings.HasPrefix(field.Type, "MemArg") {
 				body += parseMemArg(field.Name, field.Type)
+			} else if strings.HasPrefix(field.Type, "Lane") {
+				body += parseInt(field.Name, "Uint8")
 			} else {
 				body += parseGeneral(field.Name)
 			}
@@ -267,6 +398,7 @@ func generateParseInstrution(instrs []Instruction) string {
 	return generate(`
 func parseInstr(ps *parser.ParserBuffer) (Instruction, error) {
 	var inst Instruction
+	span := ps.Pos()
 	kw, err := ps.ExpectKeyword()
 	if err != nil {
 		return nil, err
@@ -274,12 +406,162 @@ func parseInstr(ps *parser.ParserBuffer) (Instruction, error) {
 	switch kw {
 	[cases]
 	default:
-		panic(fmt.Sprintf("todo: implement instruction %s", kw))
+		return nil, unknownInstr(ps, span, kw)
 	}
 	err = inst.parseInstrBody(ps)
 	if err != nil {
 		return nil, err
 	}
+	err = requireInstrFeature(ps, span, inst)
+	if err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+`, map[string]interface{}{"cases": strings.Join(cases, "\n")})
+}
+
+// feature names the constant of the proposal an instruction belongs to, the
+// proposals are told apart by their opcodes. It is empty for the MVP.
+func (self Instruction) feature() string {
+	if len(self.Inst) == 0 {
+		return ""
+	}
+	switch op := self.Inst[0]; op {
+	case 0x06, 0x07, 0x08, 0x09, 0x18, 0x19:
+		return "FeatureExceptions"
+	case 0x12, 0x13:
+		return "FeatureTailCall"
+	case 0x25, 0x26, 0xd0, 0xd1, 0xd2:
+		return "FeatureReferenceTypes"
+	case 0xc0, 0xc1, 0xc2, 0xc3, 0xc4:
+		return "FeatureSignExt"
+	case 0xfd:
+		return "FeatureSimd"
+	case 0xfe:
+		return "FeatureThreads"
+	case 0xfc:
+		switch sub := self.Inst[1]; {
+		case sub <= 0x07:
+			return "FeatureSatTrunc"
+		case sub <= 0x0e:
+			return "FeatureBulkMemory"
+		default:
+			return "FeatureReferenceTypes"
+		}
+	}
+
+	return ""
+}
+
+// generateInstrFeature groups the instructions of every proposal into one
+// case, a typed select is the only instruction whose proposal depends on its
+// immediates.
+func generateInstrFeature(instrs []Instruction) string {
+	var features []string
+	grouped := make(map[string][]string)
+	for _, instr := range instrs {
+		feature := instr.feature()
+		if feature == "" {
+			continue
+		}
+		if _, ok := grouped[feature]; !ok {
+			features = append(features, feature)
+		}
+		grouped[feature] = append(grouped[feature], "*"+instr.Name)
+	}
+	var cases []string
+	for _, feature := range features {
+		var lines []string
+		names := grouped[feature]
+		for len(names) > 6 {
+			lines = append(lines, strings.Join(names[:6], ", "))
+			names = names[6:]
+		}
+		lines = append(lines, strings.Join(names, ", "))
+		cases = append(cases, generate(` case [Instrs]:
+		return [Feature]`, map[string]interface{}{"Instrs": strings.Join(lines, ",\n"), "Feature": feature}))
+	}
+
+	return generate(`
+// instrFeature returns the proposal an instruction belongs to, 0 for the
+// MVP.
+func instrFeature(instr Instruction) Features {
+	switch inst := instr.(type) {
+	case *Select:
+		if len(inst.SelectTypes.Types) != 0 {
+			return FeatureReferenceTypes
+		}
+	[cases]
+	}
+	return 0
+}
+`, map[string]interface{}{"cases": strings.Join(cases, "\n")})
+}
+
+// selfEncoded lists the opcodes of instructions whose immediates encode the
+// opcode byte themselves.
+var selfEncoded = map[string][]byte{
+	"Select": {0x1b, 0x1c},
+}
+
+func generateDecodeInstruction(instrs []Instruction) string {
+	var cases []string
+	prefixed := make(map[byte][]string)
+	var prefixes []byte
+	for _, instr := range instrs {
+		if ops, ok := selfEncoded[instr.Name]; ok {
+			var bytes []string
+			for _, op := range ops {
+				bytes = append(bytes, fmt.Sprintf("0x%x", op))
+			}
+			cases = append(cases, generate(` case [Op]:
+		inst = &[Name]{}
+		source.BackUp(1)`, map[string]interface{}{"Op": strings.Join(bytes, ", "), "Name": instr.Name}))
+			continue
+		}
+		if instr.opcodeLen() == 2 {
+			prefix := instr.Inst[0]
+			if _, ok := prefixed[prefix]; !ok {
+				prefixes = append(prefixes, prefix)
+			}
+			prefixed[prefix] = append(prefixed[prefix], generate(` case [Op]:
+			inst = &[Name]{}`, map[string]interface{}{"Op": fmt.Sprintf("0x%x", instr.Inst[1]), "Name": instr.Name}))
+			continue
+		}
+		cases = append(cases, generate(` case [Op]:
+		inst = &[Name]{}`, map[string]interface{}{"Op": fmt.Sprintf("0x%x", instr.Inst[0]), "Name": instr.Name}))
+	}
+	for _, prefix := range prefixes {
+		cases = append(cases, generate(` case [Prefix]:
+		sub, err := source.ReadUint32()
+		if err != nil {
+			return nil, err
+		}
+		switch sub {
+		[cases]
+		default:
+			return nil, source.Errorf(pos, "illegal opcode 0x%x 0x%x", op, sub)
+		}`, map[string]interface{}{"Prefix": fmt.Sprintf("0x%x", prefix), "cases": strings.Join(prefixed[prefix], "\n")}))
+	}
+
+	return generate(`
+func decodeInstr(source *ZeroCopySource) (Instruction, error) {
+	var inst Instruction
+	pos := source.Pos()
+	op, err := source.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	[cases]
+	default:
+		return nil, source.Errorf(pos, "illegal opcode 0x%x", op)
+	}
+	err = inst.decodeInstrBody(source)
+	if err != nil {
+		return nil, err
+	}
 	return inst, nil
 }
 `, map[string]interface{}{"cases": strings.Join(cases, "\n")})
@@ -292,6 +574,12 @@ func main() {
 (Else (0x05) else (Id OptionId))
 (Loop (0x03) loop (BlockType BlockType))
 (End (0x0b) end (Id OptionId))
+(Try (0x06) try (BlockType BlockType))
+(Catch (0x07) catch (Index Index))
+(CatchAll (0x19) catch_all)
+(Delegate (0x18) delegate (Index Index))
+(Throw (0x08) throw (Index Index))
+(Rethrow (0x09) rethrow (Index Index))
 
 (Unreachable (0x00) unreachable)
 (Nop (0x01) nop)
@@ -311,8 +599,8 @@ func main() {
 (GlobalGet (0x23) (global.get get_global) (Index Index)) 
 (GlobalSet (0x24) (global.set set_global) (Index Index)) 
 
-(TableGet (0x25) table.get (Index Index))
-(TableSet (0x26) table.set (Index Index))
+(TableGet (0x25) table.get (Index DefaultIndex))
+(TableSet (0x26) table.set (Index DefaultIndex))
 
 (I32Load (0x28) i32.load (MemArg MemArg<4>))
 (I64Load (0x29) i64.load (MemArg MemArg<8>))
@@ -339,20 +627,20 @@ func main() {
 (I64Store32 (0x3e) i64.store32 (MemArg MemArg<4>))
 
 ;; Lots of bulk memory proposal here as well
-(MemorySize (0x3f 0x00) (memory.size current_memory))
-(MemoryGrow (0x40 0x00) (memory.grow grow_memory))
-;;MemoryInit(MemoryInit<'a>) : [0xfc 0x08] : "memory.init"
-(MemoryCopy (0xfc 0x0a 0x00 0x00) memory.copy)
-(MemoryFill (0xfc 0x0b 0x00) memory.fill)
+(MemorySize (0x3f) (memory.size current_memory) (Mem MemIndex))
+(MemoryGrow (0x40) (memory.grow grow_memory) (Mem MemIndex))
+(MemoryInit (0xfc 0x08) memory.init (Impl MemoryInitInner))
+(MemoryCopy (0xfc 0x0a) memory.copy (Impl MemoryCopyInner))
+(MemoryFill (0xfc 0x0b) memory.fill (Mem MemIndex))
 (DataDrop (0xfc 0x09) data.drop (Index Index))
 (ElemDrop (0xfc 0x0d) elem.drop (Index Index))
-;;(TableInit table.init (Impl TableInit))
-(TableCopy (0xfc 0x0e 0x00 0x00) table.copy)
-(TableFill (0xfc 0x11) table.fill (Index Index))
-(TableSize (0xfc 0x10) table.size (Index Index))
-(TableGrow (0xfc 0x0f) table.grow (Index Index))
+(TableInit (0xfc 0x0c) table.init (Impl TableInitInner))
+(TableCopy (0xfc 0x0e) table.copy (Impl TableCopyInner))
+(TableFill (0xfc 0x11) table.fill (Index DefaultIndex))
+(TableSize (0xfc 0x10) table.size (Index DefaultIndex))
+(TableGrow (0xfc 0x0f) table.grow (Index DefaultIndex))
 
-(RefNull (0xd0) ref.null)
+(RefNull (0xd0) ref.null (Type HeapType))
 (RefIsNull (0xd1) ref.is_null)
 (RefHost (0xff) ref.host (Val uint32))
 (RefFunc (0xd2) ref.func (Index Index))
@@ -590,28 +878,28 @@ func main() {
 
 (V128Load (0xfd 0x00) v128.load (MemArg MemArg<16>))
 (V128Store (0xfd 0x01) v128.store (MemArg MemArg<16>))
-;;(V128Const (0xfd 0x02) v128.const (V128Const V128Const))
-
-;;I8x16Splat : [0xfd 0x04] : "i8x16.splat"
-;;I8x16ExtractLaneS(i32) : [0xfd 0x05] : "i8x16.extract_lane_s"
-;;I8x16ExtractLaneU(i32) : [0xfd 0x06] : "i8x16.extract_lane_u"
-;;I8x16ReplaceLane(i32) : [0xfd 0x07] : "i8x16.replace_lane"
-;;I16x8Splat : [0xfd 0x08] : "i16x8.splat"
-;;I16x8ExtractLaneS(i32) : [0xfd 0x09] : "i16x8.extract_lane_s"
-;;I16x8ExtractLaneU(i32) : [0xfd 0x0a] : "i16x8.extract_lane_u"
-;;I16x8ReplaceLane(i32) : [0xfd 0x0b] : "i16x8.replace_lane"
-;;I32x4Splat : [0xfd 0x0c] : "i32x4.splat"
-;;I32x4ExtractLane(i32) : [0xfd 0x0d] : "i32x4.extract_lane"
-;;I32x4ReplaceLane(i32) : [0xfd 0x0e] : "i32x4.replace_lane"
-;;I64x2Splat : [0xfd 0x0f] : "i64x2.splat"
-;;I64x2ExtractLane(i32) : [0xfd 0x10] : "i64x2.extract_lane"
-;;I64x2ReplaceLane(i32) : [0xfd 0x11] : "i64x2.replace_lane"
-;;F32x4Splat : [0xfd 0x12] : "f32x4.splat"
-;;F32x4ExtractLane(i32) : [0xfd 0x13] : "f32x4.extract_lane"
-;;F32x4ReplaceLane(i32) : [0xfd 0x14] : "f32x4.replace_lane"
-;;F64x2Splat : [0xfd 0x15] : "f64x2.splat"
-;;F64x2ExtractLane(i32) : [0xfd 0x16] : "f64x2.extract_lane"
-;;F64x2ReplaceLane(i32) : [0xfd 0x17] : "f64x2.replace_lane"
+(V128Const (0xfd 0x02) v128.const (Val V128Bits))
+
+(I8x16Splat (0xfd 0x04) i8x16.splat)
+(I8x16ExtractLaneS (0xfd 0x05) i8x16.extract_lane_s (Lane Lane<16>))
+(I8x16ExtractLaneU (0xfd 0x06) i8x16.extract_lane_u (Lane Lane<16>))
+(I8x16ReplaceLane (0xfd 0x07) i8x16.replace_lane (Lane Lane<16>))
+(I16x8Splat (0xfd 0x08) i16x8.splat)
+(I16x8ExtractLaneS (0xfd 0x09) i16x8.extract_lane_s (Lane Lane<8>))
+(I16x8ExtractLaneU (0xfd 0x0a) i16x8.extract_lane_u (Lane Lane<8>))
+(I16x8ReplaceLane (0xfd 0x0b) i16x8.replace_lane (Lane Lane<8>))
+(I32x4Splat (0xfd 0x0c) i32x4.splat)
+(I32x4ExtractLane (0xfd 0x0d) i32x4.extract_lane (Lane Lane<4>))
+(I32x4ReplaceLane (0xfd 0x0e) i32x4.replace_lane (Lane Lane<4>))
+(I64x2Splat (0xfd 0x0f) i64x2.splat)
+(I64x2ExtractLane (0xfd 0x10) i64x2.extract_lane (Lane Lane<2>))
+(I64x2ReplaceLane (0xfd 0x11) i64x2.replace_lane (Lane Lane<2>))
+(F32x4Splat (0xfd 0x12) f32x4.splat)
+(F32x4ExtractLane (0xfd 0x13) f32x4.extract_lane (Lane Lane<4>))
+(F32x4ReplaceLane (0xfd 0x14) f32x4.replace_lane (Lane Lane<4>))
+(F64x2Splat (0xfd 0x15) f64x2.splat)
+(F64x2ExtractLane (0xfd 0x16) f64x2.extract_lane (Lane Lane<2>))
+(F64x2ReplaceLane (0xfd 0x17) f64x2.replace_lane (Lane Lane<2>))
 
 (I8x16Eq (0xfd 0x18) i8x16.eq)
 (I8x16Ne (0xfd 0x19) i8x16.ne)
@@ -741,7 +1029,7 @@ func main() {
 (F64x2ConvertI64x2U (0xfd 0xb2) f64x2.convert_i64x2_u)
 (V8x16Swizzle (0xfd 0xc0) v8x16.swizzle)
 
-;;V8x16Shuffle(V8x16Shuffle) : [0xfd 0xc1] : "v8x16.shuffle"
+(V8x16Shuffle (0xfd 0xc1) v8x16.shuffle (Lanes ShuffleLanes))
 (V8x16LoadSplat (0xfd 0xc2) v8x16.load_splat (MemArg MemArg<1>))
 (V16x8LoadSplat (0xfd 0xc3) v16x8.load_splat (MemArg MemArg<2>))
 (V32x4LoadSplat (0xfd 0xc4) v32x4.load_splat (MemArg MemArg<4>))
@@ -778,19 +1066,24 @@ func main() {
 	}
 
 	parseInstr := generateParseInstrution(allInstrs)
+	decodeInstr := generateDecodeInstruction(allInstrs)
+	instrFeature := generateInstrFeature(allInstrs)
 
 	goFile := generate(`
 package ast
 
 import (
-	"fmt"
+	"strconv"
 
 	"github.com/ontio/wast-parser/parser"
 )
 
 [Instrs]
 [parseInstr]
-`, map[string]interface{}{"Instrs": all, "parseInstr": parseInstr})
+[decodeInstr]
+[instrFeature]
+`, map[string]interface{}{"Instrs": all, "parseInstr": parseInstr, "decodeInstr": decodeInstr,
+		"instrFeature": instrFeature})
 
 	err := ioutil.WriteFile("../ast/instruction.go", []byte(goFile), 0666)
 	if err != nil {
@@ -0,0 +1,61 @@
+package spectest
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/ontio/wast-parser/ast"
+	"github.com/ontio/wast-parser/interp"
+)
+
+// HostModule builds the `spectest` module the scripts import from: print
+// functions writing their arguments to out, constant globals, a table and a
+// memory.
+func HostModule(out io.Writer) map[string]interp.Extern {
+	module := make(map[string]interp.Extern)
+	prints := map[string][]ast.ValType{
+		"print":         nil,
+		"print_i32":     {ast.I32},
+		"print_i64":     {ast.I64},
+		"print_f32":     {ast.F32},
+		"print_f64":     {ast.F64},
+		"print_i32_f32": {ast.I32, ast.F32},
+		"print_f64_f64": {ast.F64, ast.F64},
+	}
+	for name, params := range prints {
+		var ty ast.FunctionType
+		for _, param := range params {
+			ty.Params = append(ty.Params, ast.FuncParam{Val: param})
+		}
+		module[name] = interp.NewHostFunction(ty, func(caller *interp.Instance, args []ast.WastValue) ([]ast.WastValue, error) {
+			var vals []string
+			for _, arg := range args {
+				vals = append(vals, arg.String())
+			}
+			_, err := fmt.Fprintln(out, strings.Join(vals, " "))
+			return nil, err
+		})
+	}
+
+	for _, global := range []struct {
+		name string
+		ty   ast.ValType
+		val  ast.WastValue
+	}{
+		{"global_i32", ast.I32, ast.WastI32{Val: 666}},
+		{"global_i64", ast.I64, ast.WastI64{Val: 666}},
+		{"global_f32", ast.F32, ast.WastF32{Bits: math.Float32bits(666.6)}},
+		{"global_f64", ast.F64, ast.WastF64{Bits: math.Float64bits(666.6)}},
+	} {
+		// the values match the types, NewGlobal can not fail
+		module[global.name], _ = interp.NewGlobal(ast.GlobalValType{Type: global.ty}, global.val)
+	}
+
+	// the sizes are within the limits, the constructors can not fail
+	module["table"], _ = interp.NewTable(ast.TableType{Limits: ast.Limits{Min: 10, Max: 20, HasMax: true}, Elem: ast.FuncRef})
+	module["memory"], _ = interp.NewMemory(ast.MemoryType{Limits: ast.Limits{Min: 1, Max: 2, HasMax: true}})
+
+	return module
+}
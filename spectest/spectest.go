@@ -0,0 +1,410 @@
+// Package spectest runs spec test scripts end to end: modules are
+// instantiated with the interpreter, actions executed and every assertion
+// checked, failures are reported with the line of their directive.
+package spectest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/ontio/wast-parser/ast"
+	"github.com/ontio/wast-parser/interp"
+	"github.com/ontio/wast-parser/parser"
+)
+
+// Failure is a directive which did not behave as the script expects.
+type Failure struct {
+	Line int
+	Msg  string
+}
+
+// Result is the outcome of the directives of one script. Skipped counts the
+// directives the runner does not support, e.g. the meta commands.
+type Result struct {
+	File     string
+	Passed   int
+	Skipped  int
+	Failures []Failure
+}
+
+// Ok reports whether no directive failed.
+func (self *Result) Ok() bool {
+	return len(self.Failures) == 0
+}
+
+// String sums up the result on one line followed by a line per failure.
+func (self *Result) String() string {
+	status := "PASS"
+	if !self.Ok() {
+		status = "FAIL"
+	}
+	lines := []string{fmt.Sprintf("%s %s: %d passed, %d failed, %d skipped", status, self.File,
+		self.Passed, len(self.Failures), self.Skipped)}
+	for _, failure := range self.Failures {
+		lines = append(lines, fmt.Sprintf("%s:%d: %s", self.File, failure.Line, failure.Msg))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Runner runs scripts, Out receives what the print functions of the
+// `spectest` module write and may be nil.
+type Runner struct {
+	Out io.Writer
+}
+
+// RunFile parses and runs the script at path, only a script which does not
+// parse is an error.
+func (self *Runner) RunFile(path string) (*Result, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ps, err := parser.NewParserBuffer(string(raw))
+	if err != nil {
+		return nil, err
+	}
+	var wast ast.Wast
+	err = wast.Parse(ps)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+
+	return self.Run(&wast, filepath.Base(path)), nil
+}
+
+// Run executes the directives of wast in order, file names the script in
+// the result. Every run starts from a fresh `spectest` module.
+func (self *Runner) Run(wast *ast.Wast, file string) *Result {
+	out := self.Out
+	if out == nil {
+		out = ioutil.Discard
+	}
+	state := &script{
+		result:    &Result{File: file},
+		instances: make(map[string]*interp.Instance),
+		defs:      make(map[string]ast.QuoteModule),
+		imports:   interp.Imports{"spectest": HostModule(out)},
+	}
+	for _, dir := range wast.Directives {
+		state.run(dir)
+	}
+
+	return state.result
+}
+
+// script is the state of a run: the instances by name, the last one being
+// the target of actions without a name, and the registered modules.
+type script struct {
+	result    *Result
+	instances map[string]*interp.Instance
+	current   *interp.Instance
+	defs      map[string]ast.QuoteModule
+	lastDef   ast.QuoteModule
+	imports   interp.Imports
+}
+
+// errSkipped marks the directives the runner does not support.
+var errSkipped = errors.New("unsupported directive")
+
+// stage is the step of the processing of a module which rejected it.
+type stage int
+
+const (
+	stageMalformed stage = iota
+	stageInvalid
+	stageUnlinkable
+	stageUninstantiable
+	stageUnsupported
+)
+
+func (self stage) String() string {
+	switch self {
+	case stageMalformed:
+		return "malformed"
+	case stageInvalid:
+		return "invalid"
+	case stageUnlinkable:
+		return "unlinkable"
+	case stageUninstantiable:
+		return "uninstantiable"
+	}
+
+	return "unsupported"
+}
+
+func (self *script) run(dir ast.WastDirective) {
+	err := self.directive(dir)
+	switch {
+	case err == errSkipped:
+		self.result.Skipped += 1
+	case err != nil:
+		self.result.Failures = append(self.result.Failures, Failure{Line: dir.Line(), Msg: err.Error()})
+	default:
+		self.result.Passed += 1
+	}
+}
+
+// directive runs one directive, it returns why the directive failed.
+func (self *script) directive(dir ast.WastDirective) error {
+	switch val := dir.(type) {
+	case ast.Module:
+		return self.define(val, val.Name)
+	case ast.Quote:
+		return self.define(val, val.Name)
+	case ast.ModuleDefinitionDirective:
+		name := moduleName(val.Module)
+		self.defs[name] = val.Module
+		self.lastDef = val.Module
+		return nil
+	case ast.ModuleInstanceDirective:
+		def := self.lastDef
+		if val.Definition.IsSome() {
+			def = self.defs[val.Definition.ToId().Name]
+		}
+		if def == nil {
+			return fmt.Errorf("unknown module definition")
+		}
+		return self.define(def, val.Instance)
+	case ast.RegisterDirective:
+		inst, err := self.instance(val.Module)
+		if err != nil {
+			return err
+		}
+		self.imports[val.Name] = inst.Exports()
+		return nil
+	case ast.WastInvoke, ast.WastExecuteGet:
+		_, err := self.action(val.(ast.WastExecute))
+		return err
+	case ast.AssertReturnDirective:
+		results, err := self.action(val.Exec)
+		if err != nil {
+			return err
+		}
+		return matchResults(val.Results, results)
+	case ast.AssertReturnCanonicalNanDirective:
+		return self.assertNan(val.Exec, val.Match)
+	case ast.AssertReturnArithmeticNanDirective:
+		return self.assertNan(val.Exec, val.Match)
+	case ast.AssertTrapDirective:
+		_, err := self.action(val.Exec)
+		return expectTrap(err, val.Msg)
+	case ast.AssertExhaustionDirective:
+		_, err := self.action(val.Call)
+		return expectTrap(err, val.Message)
+	case ast.AssertMalformedDirective:
+		return self.expectRejected(val.Module, stageMalformed, val.Msg)
+	case ast.AssertInvalidDirective:
+		return self.expectRejected(val.Module, stageInvalid, val.Msg)
+	case ast.AssertUnlinkableDirective:
+		return self.expectRejected(val.Module, stageUnlinkable, val.Msg)
+	case ast.AssertUninstantiableDirective:
+		return self.expectRejected(val.Module, stageUninstantiable, val.Msg)
+	}
+
+	// exceptions and the meta commands
+	return errSkipped
+}
+
+// define instantiates a module of the script and makes it the current one.
+func (self *script) define(module ast.QuoteModule, name ast.OptionId) error {
+	inst, stage, err := self.instantiate(module)
+	if err != nil {
+		// actions on the module fail rather than run on the previous one
+		self.current = nil
+		return fmt.Errorf("module is %s: %s", stage, err)
+	}
+	self.current = inst
+	if name.IsSome() {
+		self.instances[name.ToId().Name] = inst
+	}
+
+	return nil
+}
+
+// instantiate takes a module through every stage, it returns the one which
+// failed along with the error.
+func (self *script) instantiate(quote ast.QuoteModule) (*interp.Instance, stage, error) {
+	module, err := build(quote)
+	if err != nil {
+		return nil, stageMalformed, err
+	}
+	// the module is validated by Instantiate, its errors tell the stages apart
+	inst, err := interp.Instantiate(module, self.imports)
+	switch err.(type) {
+	case nil:
+		return inst, 0, nil
+	case *ast.ValidationError:
+		return nil, stageInvalid, err
+	case *interp.LinkError:
+		return nil, stageUnlinkable, err
+	case *interp.Trap:
+		return nil, stageUninstantiable, err
+	}
+
+	return nil, stageUnsupported, err
+}
+
+// build parses a quoted module or decodes a binary one and resolves it. The
+// module of the directive is copied first, so running a script again starts
+// from the tree as parsed.
+func build(quote ast.QuoteModule) (*ast.Module, error) {
+	var module *ast.Module
+	switch val := quote.(type) {
+	case ast.Quote:
+		ps, err := parser.NewParserBuffer(strings.Join(val.Data, ""))
+		if err != nil {
+			return nil, err
+		}
+		var wat ast.Wat
+		err = wat.Parse(ps)
+		if err != nil {
+			return nil, err
+		}
+		if ps.PeekToken() != nil {
+			return nil, fmt.Errorf("unexpected tokens after the module")
+		}
+		module = &wat.Module
+	case ast.Module:
+		module = val.Clone()
+	default:
+		return nil, fmt.Errorf("unsupported module %T", quote)
+	}
+
+	if bin, ok := module.Kind.(ast.ModuleKindBinary); ok {
+		var data []byte
+		for _, b := range bin.Bins {
+			data = append(data, b...)
+		}
+		return ast.DecodeModule(data)
+	}
+	err := module.Resolve()
+
+	return module, err
+}
+
+// expectRejected checks that a module fails at the expected stage with an
+// error containing msg. The errors carry their context, e.g. the function
+// which is invalid, so msg is not required to be a prefix.
+func (self *script) expectRejected(module ast.QuoteModule, expected stage, msg string) error {
+	_, actual, err := self.instantiate(module)
+	switch {
+	case err == nil:
+		return fmt.Errorf("expected the module to be %s (%q), it was instantiated", expected, msg)
+	case actual != expected:
+		return fmt.Errorf("expected the module to be %s (%q), it is %s: %s", expected, msg, actual, err)
+	case !strings.Contains(err.Error(), msg):
+		return fmt.Errorf("expected %q, found %q", msg, err)
+	}
+
+	return nil
+}
+
+func (self *script) instance(name ast.OptionId) (*interp.Instance, error) {
+	if name.IsSome() {
+		inst, ok := self.instances[name.ToId().Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown module $%s", name.ToId().Name)
+		}
+		return inst, nil
+	}
+	if self.current == nil {
+		return nil, fmt.Errorf("no module to run the action on")
+	}
+
+	return self.current, nil
+}
+
+func (self *script) action(exec ast.WastExecute) ([]ast.WastValue, error) {
+	switch val := exec.(type) {
+	case ast.WastInvoke:
+		inst, err := self.instance(val.Module)
+		if err != nil {
+			return nil, err
+		}
+		return inst.Invoke(val.Name, val.Args...)
+	case ast.WastExecuteGet:
+		inst, err := self.instance(val.Module)
+		if err != nil {
+			return nil, err
+		}
+		result, err := inst.GetGlobal(val.Global)
+		if err != nil {
+			return nil, err
+		}
+		return []ast.WastValue{result}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported action %T", exec)
+}
+
+func (self *script) assertNan(exec ast.WastExecute, match func(ast.WastValue) bool) error {
+	results, err := self.action(exec)
+	if err != nil {
+		return err
+	}
+	if len(results) != 1 || !match(results[0]) {
+		return fmt.Errorf("expected a NaN, found %s", valueList(results))
+	}
+
+	return nil
+}
+
+func matchResults(expected, actual []ast.WastValue) error {
+	if len(expected) == len(actual) {
+		matched := true
+		for i, val := range expected {
+			matched = matched && val.Match(actual[i])
+		}
+		if matched {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("expected %s, found %s", valueList(expected), valueList(actual))
+}
+
+// expectTrap checks that an action trapped with a message starting with
+// msg, as the reference interpreter compares them.
+func expectTrap(err error, msg string) error {
+	trap, ok := err.(*interp.Trap)
+	switch {
+	case err == nil:
+		return fmt.Errorf("expected trap %q, the action returned", msg)
+	case !ok:
+		return err
+	case !strings.HasPrefix(trap.Msg, msg):
+		return fmt.Errorf("expected trap %q, found %q", msg, trap.Msg)
+	}
+
+	return nil
+}
+
+func valueList(vals []ast.WastValue) string {
+	var strs []string
+	for _, val := range vals {
+		strs = append(strs, val.String())
+	}
+
+	return "[" + strings.Join(strs, " ") + "]"
+}
+
+// moduleName is the name a module definition is kept under, empty for none.
+func moduleName(module ast.QuoteModule) string {
+	var name ast.OptionId
+	switch val := module.(type) {
+	case ast.Module:
+		name = val.Name
+	case ast.Quote:
+		name = val.Name
+	}
+	if !name.IsSome() {
+		return ""
+	}
+
+	return name.ToId().Name
+}
@@ -0,0 +1,91 @@
+package spectest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ontio/wast-parser/ast"
+	"github.com/ontio/wast-parser/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	ps, err := parser.NewParserBuffer(`(module $lib
+  (import "spectest" "print_i32" (func $print (param i32)))
+  (import "spectest" "global_i32" (global $g i32))
+  (import "spectest" "memory" (memory 1))
+  (func (export "add") (param i32) (result i32)
+    (call $print (local.get 0))
+    (i32.add (local.get 0) (global.get $g)))
+  (func $recurse (export "recurse") (call $recurse)))
+(register "lib" $lib)
+(module
+  (import "lib" "add" (func $add (param i32) (result i32)))
+  (func (export "twice") (param i32) (result i32)
+    (call $add (call $add (local.get 0))))
+  (func (export "nan") (result f32) (f32.div (f32.const 0) (f32.const 0))))
+(assert_return (invoke "twice" (i32.const 1)) (i32.const 1333))
+(assert_return (invoke "nan") (f32.const nan:canonical))
+(assert_return (invoke $lib "add" (i32.const 0)) (i32.const 666))
+(assert_trap (invoke "twice" (i32.const 1)) "wrong")
+(assert_exhaustion (invoke $lib "recurse") "call stack exhausted")
+(assert_invalid (module (func (result i32))) "type mismatch")
+(assert_malformed (module quote "(func i32.frob)") "unknown operator")
+(assert_malformed (module binary "\00asm\02\00\00\00") "unknown binary version")
+(assert_invalid (module quote "(func (call 3))") "unknown function")
+(assert_unlinkable (module (import "lib" "missing" (func))) "unknown import")
+(assert_unlinkable (module (import "spectest" "global_i32" (global i64))) "incompatible import type")
+(assert_trap (module (func $start unreachable) (start $start)) "unreachable")
+(module definition $def (func (export "one") (result i32) (i32.const 1)))
+(module instance $one $def)
+(assert_return (invoke $one "one") (i32.const 1))
+(assert_return (get $one "missing") (i32.const 1))
+(input "other.wast")
+`)
+	assert.Nil(t, err)
+	var wast ast.Wast
+	assert.Nil(t, wast.Parse(ps))
+
+	var out bytes.Buffer
+	runner := &Runner{Out: &out}
+	result := runner.Run(&wast, "run.wast")
+	assert.Equal(t, 17, result.Passed)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Equal(t, []Failure{
+		{Line: 18, Msg: `expected trap "wrong", the action returned`},
+		{Line: 30, Msg: `unknown global export "missing"`},
+	}, result.Failures)
+	assert.False(t, result.Ok())
+	assert.Equal(t, `FAIL run.wast: 17 passed, 2 failed, 1 skipped
+run.wast:18: expected trap "wrong", the action returned
+run.wast:30: unknown global export "missing"`, result.String())
+	assert.Equal(t, "(i32.const 1)\n(i32.const 667)\n(i32.const 0)\n(i32.const 1)\n(i32.const 667)\n", out.String())
+
+	// the directives keep their modules as parsed, a second run is the same
+	out.Reset()
+	assert.Equal(t, result, runner.Run(&wast, "run.wast"))
+	assert.Equal(t, "(i32.const 1)\n(i32.const 667)\n(i32.const 0)\n(i32.const 1)\n(i32.const 667)\n", out.String())
+
+	ps, err = parser.NewParserBuffer(`(assert_invalid (module quote "(func i32.frob)") "unknown operator")
+(assert_malformed (module (func (result i32))) "type mismatch")
+(assert_invalid (module (func (result i32))) "unknown function")
+(assert_malformed (module quote "(func $f) (func $f)") "unknown operator")`)
+	assert.Nil(t, err)
+	wast = ast.Wast{}
+	assert.Nil(t, wast.Parse(ps))
+	result = runner.Run(&wast, "stage.wast")
+	assert.Equal(t, 4, len(result.Failures))
+	assert.Contains(t, result.Failures[0].Msg, `expected the module to be invalid ("unknown operator"), it is malformed`)
+	assert.Contains(t, result.Failures[1].Msg, `expected the module to be malformed ("type mismatch"), it is invalid`)
+	assert.Contains(t, result.Failures[2].Msg, `expected "unknown function", found "func 0: type mismatch`)
+	assert.Contains(t, result.Failures[3].Msg, `expected "unknown operator", found`)
+}
+
+func TestRunFiles(t *testing.T) {
+	runner := &Runner{}
+	for _, file := range []string{"../tests/conversions.wast", "../tests/memory_redundancy.wast"} {
+		result, err := runner.RunFile(file)
+		assert.Nil(t, err)
+		assert.True(t, result.Ok(), result.String())
+	}
+}
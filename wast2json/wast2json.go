@@ -0,0 +1,355 @@
+// Package wast2json converts a parsed spec test script to the JSON manifest
+// written by the wast2json tool of the reference interpreter. Every module of
+// the script goes to a numbered file next to the manifest, binary modules as
+// .wasm and quoted text modules as .wat.
+package wast2json
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ontio/wast-parser/ast"
+)
+
+// Script is the manifest of a converted script.
+type Script struct {
+	SourceFilename string    `json:"source_filename"`
+	Commands       []Command `json:"commands"`
+	// Files holds the contents of the module files named by the commands.
+	Files map[string][]byte `json:"-"`
+	// Warnings lists the directives left out of the manifest.
+	Warnings []string `json:"-"`
+}
+
+// Command is one directive of the script, only the fields which apply to
+// Type are set.
+type Command struct {
+	Type       string  `json:"type"`
+	Line       int     `json:"line"`
+	Name       string  `json:"name,omitempty"`
+	As         string  `json:"as,omitempty"`
+	Instance   string  `json:"instance,omitempty"`
+	Module     string  `json:"module,omitempty"`
+	Filename   string  `json:"filename,omitempty"`
+	Text       string  `json:"text,omitempty"`
+	ModuleType string  `json:"module_type,omitempty"`
+	Action     *Action `json:"action,omitempty"`
+	Expected   []Value `json:"expected,omitempty"`
+}
+
+// Action is the invocation of an export or the read of an exported global.
+type Action struct {
+	Type   string  `json:"type"`
+	Module string  `json:"module,omitempty"`
+	Field  string  `json:"field"`
+	Args   []Value `json:"args"`
+}
+
+// Value is a constant argument or result. Value holds the bits of a number
+// as an unsigned decimal string, the lane values of a v128 as a list of such
+// strings and "null" for a null reference.
+type Value struct {
+	Type     string      `json:"type"`
+	LaneType string      `json:"lane_type,omitempty"`
+	Value    interface{} `json:"value"`
+}
+
+type converter struct {
+	base   string
+	script *Script
+}
+
+// Convert turns the directives of wast into a manifest, source is the name
+// of the script file and gives the names of the module files.
+func Convert(wast *ast.Wast, source string) (*Script, error) {
+	conv := &converter{
+		base:   strings.TrimSuffix(filepath.Base(source), filepath.Ext(source)),
+		script: &Script{SourceFilename: filepath.Base(source), Commands: []Command{}, Files: make(map[string][]byte)},
+	}
+	for _, dir := range wast.Directives {
+		cmd, err := conv.directive(dir)
+		if err == errSkipped {
+			conv.script.Warnings = append(conv.script.Warnings, fmt.Sprintf("line %d: skipped %s", dir.Line(), metaName(dir)))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", dir.Line(), err)
+		}
+		cmd.Line = dir.Line()
+		conv.script.Commands = append(conv.script.Commands, cmd)
+	}
+
+	return conv.script, nil
+}
+
+// WriteDir writes the manifest as <name>.json into dir along with the
+// module files.
+func (self *Script) WriteDir(dir string) error {
+	manifest, err := json.MarshalIndent(self, "", "  ")
+	if err != nil {
+		return err
+	}
+	base := strings.TrimSuffix(self.SourceFilename, filepath.Ext(self.SourceFilename))
+	err = ioutil.WriteFile(filepath.Join(dir, base+".json"), manifest, 0644)
+	if err != nil {
+		return err
+	}
+	for name, data := range self.Files {
+		err = ioutil.WriteFile(filepath.Join(dir, name), data, 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// errSkipped marks the meta commands, the manifest has no command for them.
+var errSkipped = errors.New("skipped directive")
+
+func metaName(dir ast.WastDirective) string {
+	switch dir.(type) {
+	case ast.ScriptDirective:
+		return "script"
+	case ast.InputDirective:
+		return "input"
+	}
+
+	return "output"
+}
+
+func (self *converter) directive(dir ast.WastDirective) (Command, error) {
+	switch val := dir.(type) {
+	case ast.Module:
+		filename, err := self.binaryModule(val)
+		if err != nil {
+			return Command{}, err
+		}
+		return Command{Type: "module", Name: moduleName(val.Name), Filename: filename}, nil
+	case ast.Quote:
+		filename := self.file("wat", quoteText(val))
+		return Command{Type: "module", Name: moduleName(val.Name), Filename: filename, ModuleType: "text"}, nil
+	case ast.ModuleDefinitionDirective:
+		filename, moduleType, err := self.moduleFile(val.Module)
+		if err != nil {
+			return Command{}, err
+		}
+		return Command{Type: "module_definition", Name: definitionName(val.Module), Filename: filename,
+			ModuleType: moduleType}, nil
+	case ast.ModuleInstanceDirective:
+		return Command{Type: "module_instance", Instance: moduleName(val.Instance),
+			Module: moduleName(val.Definition)}, nil
+	case ast.ScriptDirective, ast.InputDirective, ast.OutputDirective:
+		return Command{}, errSkipped
+	case ast.RegisterDirective:
+		return Command{Type: "register", Name: moduleName(val.Module), As: val.Name}, nil
+	case ast.WastInvoke, ast.WastExecuteGet:
+		action, err := executeAction(val.(ast.WastExecute))
+		if err != nil {
+			return Command{}, err
+		}
+		return Command{Type: "action", Action: action}, nil
+	case ast.AssertReturnDirective:
+		action, err := executeAction(val.Exec)
+		if err != nil {
+			return Command{}, err
+		}
+		expected, err := values(val.Results)
+		if err != nil {
+			return Command{}, err
+		}
+		return Command{Type: "assert_return", Action: action, Expected: expected}, nil
+	case ast.AssertReturnCanonicalNanDirective:
+		action, err := executeAction(val.Exec)
+		if err != nil {
+			return Command{}, err
+		}
+		return Command{Type: "assert_return_canonical_nan", Action: action}, nil
+	case ast.AssertReturnArithmeticNanDirective:
+		action, err := executeAction(val.Exec)
+		if err != nil {
+			return Command{}, err
+		}
+		return Command{Type: "assert_return_arithmetic_nan", Action: action}, nil
+	case ast.AssertTrapDirective:
+		action, err := executeAction(val.Exec)
+		if err != nil {
+			return Command{}, err
+		}
+		return Command{Type: "assert_trap", Action: action, Text: val.Msg}, nil
+	case ast.AssertExhaustionDirective:
+		action, err := executeAction(val.Call)
+		if err != nil {
+			return Command{}, err
+		}
+		return Command{Type: "assert_exhaustion", Action: action, Text: val.Message}, nil
+	case ast.AssertExceptionDirective:
+		action, err := executeAction(val.Exec)
+		if err != nil {
+			return Command{}, err
+		}
+		return Command{Type: "assert_exception", Action: action}, nil
+	case ast.AssertMalformedDirective:
+		return self.assertModule("assert_malformed", val.Module, val.Msg)
+	case ast.AssertInvalidDirective:
+		return self.assertModule("assert_invalid", val.Module, val.Msg)
+	case ast.AssertUnlinkableDirective:
+		return self.assertModule("assert_unlinkable", val.Module, val.Msg)
+	case ast.AssertUninstantiableDirective:
+		return self.assertModule("assert_uninstantiable", val.Module, val.Msg)
+	}
+
+	return Command{}, fmt.Errorf("unsupported directive %T", dir)
+}
+
+func (self *converter) assertModule(ty string, module ast.QuoteModule, text string) (Command, error) {
+	filename, moduleType, err := self.moduleFile(module)
+	if err != nil {
+		return Command{}, err
+	}
+
+	return Command{Type: ty, Filename: filename, Text: text, ModuleType: moduleType}, nil
+}
+
+// moduleFile writes a quoted or binary module to its file, it returns the
+// file name along with the module type of the command.
+func (self *converter) moduleFile(module ast.QuoteModule) (string, string, error) {
+	switch val := module.(type) {
+	case ast.Quote:
+		return self.file("wat", quoteText(val)), "text", nil
+	case ast.Module:
+		filename, err := self.binaryModule(val)
+		return filename, "binary", err
+	}
+
+	return "", "", fmt.Errorf("unsupported module %T", module)
+}
+
+// definitionName is the name of a module definition, spelled as moduleName.
+func definitionName(module ast.QuoteModule) string {
+	switch val := module.(type) {
+	case ast.Quote:
+		return moduleName(val.Name)
+	case ast.Module:
+		return moduleName(val.Name)
+	}
+
+	return ""
+}
+
+// quoteText is the source of a quoted module, a bare list of fields as
+// written.
+func quoteText(quote ast.Quote) []byte {
+	return []byte(strings.Join(quote.Data, ""))
+}
+
+// binaryModule encodes a module to the next numbered .wasm file. Modules
+// are only resolved, not validated, assert_invalid expects invalid ones.
+func (self *converter) binaryModule(module ast.Module) (string, error) {
+	err := module.Resolve()
+	if err != nil {
+		return "", err
+	}
+	bin, err := module.Encode()
+	if err != nil {
+		return "", err
+	}
+
+	return self.file("wasm", bin), nil
+}
+
+func (self *converter) file(ext string, data []byte) string {
+	name := fmt.Sprintf("%s.%d.%s", self.base, len(self.script.Files), ext)
+	self.script.Files[name] = data
+
+	return name
+}
+
+// moduleName spells a module identifier with its `$` as wast2json does.
+func moduleName(id ast.OptionId) string {
+	if !id.IsSome() {
+		return ""
+	}
+
+	return "$" + id.ToId().Name
+}
+
+func executeAction(exec ast.WastExecute) (*Action, error) {
+	switch val := exec.(type) {
+	case ast.WastInvoke:
+		return invokeAction(val)
+	case ast.WastExecuteGet:
+		return &Action{Type: "get", Module: moduleName(val.Module), Field: val.Global, Args: []Value{}}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported action %T", exec)
+}
+
+func invokeAction(invoke ast.WastInvoke) (*Action, error) {
+	args, err := values(invoke.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Action{Type: "invoke", Module: moduleName(invoke.Module), Field: invoke.Name, Args: args}, nil
+}
+
+func values(vals []ast.WastValue) ([]Value, error) {
+	result := []Value{}
+	for _, val := range vals {
+		conv, err := value(val)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, conv)
+	}
+
+	return result, nil
+}
+
+func value(val ast.WastValue) (Value, error) {
+	switch val := val.(type) {
+	case ast.WastI32:
+		return Value{Type: "i32", Value: strconv.FormatUint(uint64(val.Val), 10)}, nil
+	case ast.WastI64:
+		return Value{Type: "i64", Value: strconv.FormatUint(val.Val, 10)}, nil
+	case ast.WastF32:
+		if val.Nan != ast.NanNone {
+			return Value{Type: "f32", Value: val.Nan.String()}, nil
+		}
+		return Value{Type: "f32", Value: strconv.FormatUint(uint64(val.Bits), 10)}, nil
+	case ast.WastF64:
+		if val.Nan != ast.NanNone {
+			return Value{Type: "f64", Value: val.Nan.String()}, nil
+		}
+		return Value{Type: "f64", Value: strconv.FormatUint(val.Bits, 10)}, nil
+	case ast.WastV128:
+		var lanes []string
+		for _, lane := range val.Lanes {
+			conv, err := value(lane)
+			if err != nil {
+				return Value{}, err
+			}
+			lanes = append(lanes, conv.Value.(string))
+		}
+		// the lane type is the shape without the count, e.g. i8 for i8x16
+		laneType := val.Shape[:strings.IndexByte(val.Shape, 'x')]
+		return Value{Type: "v128", LaneType: laneType, Value: lanes}, nil
+	case ast.WastRefNull:
+		return Value{Type: val.Type.String() + "ref", Value: "null"}, nil
+	case ast.WastRefExtern:
+		if val.Any {
+			return Value{Type: "externref"}, nil
+		}
+		return Value{Type: "externref", Value: strconv.FormatUint(uint64(val.Val), 10)}, nil
+	case ast.WastRefFunc:
+		return Value{Type: "funcref"}, nil
+	}
+
+	return Value{}, fmt.Errorf("unsupported constant %s", val)
+}
@@ -0,0 +1,108 @@
+package wast2json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ontio/wast-parser/ast"
+	"github.com/ontio/wast-parser/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert(t *testing.T) {
+	ps, err := parser.NewParserBuffer(`(module $m
+  (global (export "g") i32 (i32.const 7))
+  (func (export "div") (param i32 i32) (result i32)
+    (i32.div_s (local.get 0) (local.get 1))))
+(register "lib" $m)
+(assert_return (invoke $m "div" (i32.const 6) (i32.const -3)) (i32.const -2))
+(assert_return (get "g") (i32.const 7))
+(assert_trap (invoke "div" (i32.const 1) (i32.const 0)) "integer divide by zero")
+(assert_malformed (module quote "(func" " i32.frob)") "unknown operator")
+(assert_invalid (module (func (result i32))) "type mismatch")
+(invoke "div" (f32.const 1) (ref.null extern))
+`)
+	assert.Nil(t, err)
+	var wast ast.Wast
+	assert.Nil(t, wast.Parse(ps))
+
+	script, err := Convert(&wast, "dir/div.wast")
+	assert.Nil(t, err)
+	manifest, err := json.Marshal(script)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"source_filename": "div.wast", "commands": [
+{"type": "module", "line": 1, "name": "$m", "filename": "div.0.wasm"},
+{"type": "register", "line": 5, "name": "$m", "as": "lib"},
+{"type": "assert_return", "line": 6,
+ "action": {"type": "invoke", "module": "$m", "field": "div", "args": [
+   {"type": "i32", "value": "6"}, {"type": "i32", "value": "4294967293"}]},
+ "expected": [{"type": "i32", "value": "4294967294"}]},
+{"type": "assert_return", "line": 7,
+ "action": {"type": "get", "field": "g", "args": []},
+ "expected": [{"type": "i32", "value": "7"}]},
+{"type": "assert_trap", "line": 8, "text": "integer divide by zero",
+ "action": {"type": "invoke", "field": "div", "args": [
+   {"type": "i32", "value": "1"}, {"type": "i32", "value": "0"}]}},
+{"type": "assert_malformed", "line": 9, "filename": "div.1.wat", "text": "unknown operator", "module_type": "text"},
+{"type": "assert_invalid", "line": 10, "filename": "div.2.wasm", "text": "type mismatch", "module_type": "binary"},
+{"type": "action", "line": 11, "action": {"type": "invoke", "field": "div", "args": [
+   {"type": "f32", "value": "1065353216"}, {"type": "externref", "value": "null"}]}}
+]}`, string(manifest))
+
+	assert.Equal(t, "(func i32.frob)", string(script.Files["div.1.wat"]))
+	assert.Equal(t, []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}, script.Files["div.0.wasm"][:8])
+	assert.Equal(t, 3, len(script.Files))
+}
+
+func TestConvertPatterns(t *testing.T) {
+	ps, err := parser.NewParserBuffer(`(assert_return (invoke "f") (f32.const nan:canonical) (v128.const i16x8 -1 0 0 0 0 0 0 2))`)
+	assert.Nil(t, err)
+	var wast ast.Wast
+	assert.Nil(t, wast.Parse(ps))
+
+	script, err := Convert(&wast, "f.wast")
+	assert.Nil(t, err)
+	assert.Equal(t, []Value{{Type: "f32", Value: "nan:canonical"},
+		{Type: "v128", LaneType: "i16", Value: []string{"65535", "0", "0", "0", "0", "0", "0", "2"}}},
+		script.Commands[0].Expected)
+}
+
+func TestConvertInvalidModule(t *testing.T) {
+	// invalid modules are still encoded, the reference interpreter rejects
+	// them when it runs the manifest
+	ps, err := parser.NewParserBuffer(`(assert_invalid (module (func (type 5))) "unknown type")`)
+	assert.Nil(t, err)
+	var wast ast.Wast
+	assert.Nil(t, wast.Parse(ps))
+
+	script, err := Convert(&wast, "type.wast")
+	assert.Nil(t, err)
+	assert.Equal(t, "type.0.wasm", script.Commands[0].Filename)
+	decoded, err := ast.DecodeModule(script.Files["type.0.wasm"])
+	assert.Nil(t, err)
+	errs := ast.Validate(decoded)
+	if assert.Len(t, errs, 1) {
+		assert.Contains(t, errs[0].Error(), "unknown type 5")
+	}
+}
+
+func TestConvertDefinitions(t *testing.T) {
+	ps, err := parser.NewParserBuffer(`(module definition $def (func (export "f")))
+(module instance $inst $def)
+(input "other.wast")
+(assert_return (invoke $inst "f"))`)
+	assert.Nil(t, err)
+	var wast ast.Wast
+	assert.Nil(t, wast.Parse(ps))
+
+	script, err := Convert(&wast, "def.wast")
+	assert.Nil(t, err)
+	manifest, err := json.Marshal(script.Commands)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[
+{"type": "module_definition", "line": 1, "name": "$def", "filename": "def.0.wasm", "module_type": "binary"},
+{"type": "module_instance", "line": 2, "instance": "$inst", "module": "$def"},
+{"type": "assert_return", "line": 4, "action": {"type": "invoke", "module": "$inst", "field": "f", "args": []}}
+]`, string(manifest))
+	assert.Equal(t, []string{"line 3: skipped input"}, script.Warnings)
+}